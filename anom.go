@@ -0,0 +1,136 @@
+package taguchi
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// ANOMResult stores an analysis of means of the orthogonal array row SNRs.
+// Alpha: Significance level used for the decision limits.
+// GrandMean: Mean SNR over all rows (the chart's centre line).
+// Factors: Per-factor level means and decision limits.
+type ANOMResult struct {
+	Alpha     float64
+	GrandMean float64
+	Factors   map[string]ANOMFactor
+}
+
+// ANOMFactor stores the analysis of means for one control factor.
+// LevelMeans: Mean SNR at each level.
+// Lower / Upper: Decision limits for each level's mean.
+// Significant: Whether each level's mean falls outside its decision limits.
+type ANOMFactor struct {
+	LevelMeans  []float64
+	Lower       []float64
+	Upper       []float64
+	Significant []bool
+}
+
+// AnalyzeMeans performs an analysis of means (ANOM) on the row SNRs, as a graphical
+// complement to ANOVA: a level whose mean SNR lies outside its decision limits differs
+// significantly from the grand mean.
+//
+// Limits are grand ± h·s·sqrt((k-1)/(k·n)), where s² is the ANOVA error mean square,
+// k the number of levels and n the number of rows at the level. The critical value h is
+// the Bonferroni-adjusted Student t quantile t(1-α/(2k), error DF), a slightly
+// conservative approximation of the tabulated ANOM critical value.
+// It returns an error if the design leaves no degrees of freedom to estimate error.
+func (e *Experiment[P]) AnalyzeMeans(alpha float64) (ANOMResult, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return ANOMResult{}, fmt.Errorf("alpha must be in (0, 1), got %v", alpha)
+	}
+	oaSNR, grandMean := e.computeOASNR()
+	anova, mainEffects, _ := e.computeANOVA(oaSNR, grandMean)
+	if anova.ErrorSS <= 0 || e.array().Rows()-1-sumDF(anova) < 1 {
+		return ANOMResult{}, fmt.Errorf("design has no error degrees of freedom; leave array columns unassigned to estimate error")
+	}
+	s := math.Sqrt(anova.ErrorMS)
+
+	oa := e.array()
+	result := ANOMResult{Alpha: alpha, GrandMean: grandMean, Factors: map[string]ANOMFactor{}}
+	for j, factor := range e.ControlFactors {
+		k := len(factor.Levels)
+		counts := make([]int, k)
+		for i := 0; i < oa.Rows(); i++ {
+			counts[oa.Row(i)[j]-1]++
+		}
+		h := studentTQuantile(1-alpha/float64(2*k), float64(anova.ErrorDF))
+
+		af := ANOMFactor{
+			LevelMeans:  mainEffects[factor.Name],
+			Lower:       make([]float64, k),
+			Upper:       make([]float64, k),
+			Significant: make([]bool, k),
+		}
+		for l := 0; l < k; l++ {
+			if counts[l] == 0 {
+				af.Lower[l], af.Upper[l] = math.NaN(), math.NaN()
+				continue
+			}
+			width := h * s * math.Sqrt(float64(k-1)/float64(k*counts[l]))
+			af.Lower[l] = grandMean - width
+			af.Upper[l] = grandMean + width
+			mean := af.LevelMeans[l]
+			af.Significant[l] = mean < af.Lower[l] || mean > af.Upper[l]
+		}
+		result.Factors[factor.Name] = af
+	}
+	return result, nil
+}
+
+// sumDF returns the total factor degrees of freedom.
+func sumDF(anova ANOVAResult) int {
+	total := 0
+	for _, df := range anova.FactorDF {
+		total += df
+	}
+	return total
+}
+
+// anomChartWidth is the number of character cells used for the plotting area.
+const anomChartWidth = 50
+
+// FprintANOMChart renders an ANOM chart as text: for each factor level the decision
+// limits are drawn as '[' and ']', the grand mean as '|', and the level mean as '*'
+// ('#' when the level is significant).
+func FprintANOMChart(w io.Writer, anom ANOMResult) {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, f := range anom.Factors {
+		for l := range f.LevelMeans {
+			for _, v := range []float64{f.LevelMeans[l], f.Lower[l], f.Upper[l]} {
+				if !math.IsNaN(v) && !math.IsInf(v, 0) {
+					lo, hi = math.Min(lo, v), math.Max(hi, v)
+				}
+			}
+		}
+	}
+	if lo >= hi {
+		lo, hi = anom.GrandMean-1, anom.GrandMean+1
+	}
+	pos := func(v float64) int {
+		p := int(math.Round((v - lo) / (hi - lo) * (anomChartWidth - 1)))
+		return max(0, min(anomChartWidth-1, p))
+	}
+
+	fmt.Fprintf(w, "ANOM chart (alpha = %.2f, grand mean = %.4f)\n", anom.Alpha, anom.GrandMean)
+	fmt.Fprintf(w, "%-20s %s  %.2f .. %.2f\n", "", strings.Repeat(" ", anomChartWidth), lo, hi)
+	for _, name := range sortedKeys(anom.Factors) {
+		f := anom.Factors[name]
+		for l, mean := range f.LevelMeans {
+			line := []byte(strings.Repeat(" ", anomChartWidth))
+			if !math.IsNaN(f.Lower[l]) {
+				line[pos(f.Lower[l])] = '['
+				line[pos(f.Upper[l])] = ']'
+			}
+			line[pos(anom.GrandMean)] = '|'
+			mark := byte('*')
+			if f.Significant[l] {
+				mark = '#'
+			}
+			line[pos(mean)] = mark
+			fmt.Fprintf(w, "%-20s %s  %.4f\n", fmt.Sprintf("%s L%d", name, l+1), line, mean)
+		}
+	}
+}
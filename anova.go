@@ -1,11 +1,27 @@
 package taguchi
 
+import (
+	"fmt"
+	"math"
+)
+
+// PoolIdleColumns sets whether Analyze estimates experimental error from the array
+// columns that hold no factor: the error term becomes the summed SS and degrees of
+// freedom of those columns, which are listed in ANOVAResult.PooledFactors. Without it
+// the error term is whatever variation the factors leave unexplained, which is the same
+// for arrays whose columns account for every degree of freedom (such as L8) but also
+// includes interactions outside the columns on arrays like L18.
+func (e *Experiment[P]) PoolIdleColumns(pool bool) {
+	e.poolIdle = pool
+}
+
 // computeANOVA calculates ANOVA statistics for all factors and returns:
 // - ANOVAResult
 // - mainEffects per factor
 // - SNR per factor (same as mainEffects for convenience)
 func (e *Experiment[P]) computeANOVA(oaSNR []float64, grandMean float64) (ANOVAResult, map[string][]float64, map[string][]float64) {
-	oaRows := len(e.OrthogonalArray)
+	oa := e.array()
+	oaRows := oa.Rows()
 	totalSS := 0.0
 	for _, sn := range oaSNR {
 		totalSS += (sn - grandMean) * (sn - grandMean)
@@ -18,39 +34,11 @@ func (e *Experiment[P]) computeANOVA(oaSNR []float64, grandMean float64) (ANOVAR
 		FactorF:  make(map[string]float64),
 	}
 
-	mainEffects := map[string][]float64{}
-	snrPerFactor := map[string][]float64{}
-
-	for _, factor := range e.ControlFactors {
-		levelMeans := make([]float64, len(factor.Levels))
-		levelCounts := make([]int, len(factor.Levels))
-
-		for i := 0; i < oaRows; i++ {
-			levelIdx := -1
-			for j, f := range e.ControlFactors {
-				if f.Name == factor.Name {
-					levelIdx = e.OrthogonalArray[i][j] - 1
-					break
-				}
-			}
-			if levelIdx >= 0 && levelIdx < len(factor.Levels) {
-				levelMeans[levelIdx] += oaSNR[i]
-				levelCounts[levelIdx]++
-			}
-		}
-
-		for li := range levelMeans {
-			if levelCounts[li] > 0 {
-				levelMeans[li] /= float64(levelCounts[li])
-			} else {
-				levelMeans[li] = 0
-			}
-		}
+	mainEffects := make(map[string][]float64, len(e.ControlFactors))
+	snrPerFactor := make(map[string][]float64, len(e.ControlFactors))
 
-		ss := 0.0
-		for li := range factor.Levels {
-			ss += float64(levelCounts[li]) * (levelMeans[li] - grandMean) * (levelMeans[li] - grandMean)
-		}
+	for j, factor := range e.ControlFactors {
+		ss, levelMeans := columnSS(oa, j, len(factor.Levels), oaSNR, grandMean)
 		dfs := len(factor.Levels) - 1
 		anova.FactorSS[factor.Name] = ss
 		anova.FactorDF[factor.Name] = dfs
@@ -67,9 +55,31 @@ func (e *Experiment[P]) computeANOVA(oaSNR []float64, grandMean float64) (ANOVAR
 		errorDF = 1
 	}
 
+	// Subtract in factor order so the result does not depend on map iteration order,
+	// and treat cancellation noise from a saturated design as exactly zero.
 	errorSS := totalSS
-	for _, ss := range anova.FactorSS {
-		errorSS -= ss
+	for _, factor := range e.ControlFactors {
+		errorSS -= anova.FactorSS[factor.Name]
+	}
+	if math.Abs(errorSS) <= totalSS*1e-12 {
+		errorSS = 0
+	}
+	if e.poolIdle {
+		// Idle columns carry no factor, so their SS estimates experimental error.
+		idleSS, idleDF := 0.0, 0
+		for j := len(e.ControlFactors); j < oa.Columns(); j++ {
+			levels := 0
+			for i := 0; i < oaRows; i++ {
+				levels = max(levels, oa.Row(i)[j])
+			}
+			ss, _ := columnSS(oa, j, levels, oaSNR, grandMean)
+			idleSS += ss
+			idleDF += levels - 1
+			anova.PooledFactors = append(anova.PooledFactors, fmt.Sprintf("column %d", j+1))
+		}
+		if idleDF > 0 {
+			errorSS, errorDF = idleSS, idleDF
+		}
 	}
 	errorMS := errorSS / float64(errorDF)
 	anova.ErrorDF = errorDF
@@ -87,6 +97,29 @@ func (e *Experiment[P]) computeANOVA(oaSNR []float64, grandMean float64) (ANOVAR
 	return anova, mainEffects, snrPerFactor
 }
 
+// columnSS returns the sum of squares of column j of oa, which has the given number of
+// levels, and the mean SNR at each level.
+func columnSS(oa ArraySource, j, levels int, oaSNR []float64, grandMean float64) (float64, []float64) {
+	levelMeans := make([]float64, levels)
+	levelCounts := make([]int, levels)
+	for i := 0; i < oa.Rows(); i++ {
+		levelIdx := oa.Row(i)[j] - 1
+		if levelIdx >= 0 && levelIdx < levels {
+			levelMeans[levelIdx] += oaSNR[i]
+			levelCounts[levelIdx]++
+		}
+	}
+
+	ss := 0.0
+	for li := range levelMeans {
+		if levelCounts[li] > 0 {
+			levelMeans[li] /= float64(levelCounts[li])
+		}
+		ss += float64(levelCounts[li]) * (levelMeans[li] - grandMean) * (levelMeans[li] - grandMean)
+	}
+	return ss, levelMeans
+}
+
 // computeContributions calculates the percentage contribution of each factor
 // based on the ratio of its sum of squares to the total factor sum of squares.
 func computeContributions(anova ANOVAResult) map[string]float64 {
@@ -0,0 +1,182 @@
+package taguchi
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// ArraySource provides row access to an orthogonal array. It lets large generated
+// designs be read lazily instead of being held in memory as [][]int.
+// Rows returned by Row must not be modified by the caller.
+type ArraySource interface {
+	Rows() int
+	Columns() int
+	Row(i int) []int
+}
+
+// MemoryArray is an in-memory orthogonal array; it is the ArraySource used for
+// StandardArrays and user-provided [][]int arrays.
+type MemoryArray [][]int
+
+// Rows returns the number of rows in the array.
+func (m MemoryArray) Rows() int { return len(m) }
+
+// Columns returns the number of columns in the array.
+func (m MemoryArray) Columns() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+// Row returns row i of the array.
+func (m MemoryArray) Row(i int) []int { return m[i] }
+
+// arrayFileMagic identifies files written by WriteArrayFile.
+var arrayFileMagic = [4]byte{'T', 'G', 'O', 'A'}
+
+// arrayFileHeaderSize is the size of the magic, row count and column count.
+const arrayFileHeaderSize = 12
+
+// WriteArrayFile stores an orthogonal array on disk in a compact fixed-width format
+// (one byte per cell) that OpenArrayFile can read lazily, one row at a time.
+func WriteArrayFile(path string, src ArraySource) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, arrayFileHeaderSize)
+	copy(header, arrayFileMagic[:])
+	binary.LittleEndian.PutUint32(header[4:], uint32(src.Rows()))
+	binary.LittleEndian.PutUint32(header[8:], uint32(src.Columns()))
+	if _, err := f.Write(header); err != nil {
+		f.Close()
+		return err
+	}
+
+	buf := make([]byte, src.Columns())
+	for i := 0; i < src.Rows(); i++ {
+		row := src.Row(i)
+		if len(row) != len(buf) {
+			f.Close()
+			return fmt.Errorf("row %d has %d columns, want %d", i+1, len(row), len(buf))
+		}
+		for j, level := range row {
+			if level < 1 || level > 255 {
+				f.Close()
+				return fmt.Errorf("row %d column %d: level %d out of range 1-255", i+1, j+1, level)
+			}
+			buf[j] = byte(level)
+		}
+		if _, err := f.Write(buf); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	return f.Close()
+}
+
+// DiskArray is an ArraySource backed by a file written with WriteArrayFile.
+// Only the most recently accessed row is kept in memory, so memory use stays
+// bounded regardless of the number of rows. It is safe for concurrent use.
+// A read error after the file has been opened causes Row to panic.
+type DiskArray struct {
+	mu      sync.Mutex
+	r       io.ReaderAt
+	closer  io.Closer
+	rows    int
+	cols    int
+	buf     []byte
+	lastIdx int
+	lastRow []int
+}
+
+// OpenArrayFile opens an orthogonal array stored with WriteArrayFile for lazy row access.
+// The caller must Close the returned array once the experiment is no longer needed.
+func OpenArrayFile(path string) (*DiskArray, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	header := make([]byte, arrayFileHeaderSize)
+	if _, err := f.ReadAt(header, 0); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("read array header: %w", err)
+	}
+	if [4]byte(header[:4]) != arrayFileMagic {
+		f.Close()
+		return nil, fmt.Errorf("%s is not an orthogonal array file", path)
+	}
+	rows := int(binary.LittleEndian.Uint32(header[4:]))
+	cols := int(binary.LittleEndian.Uint32(header[8:]))
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if want := int64(arrayFileHeaderSize) + int64(rows)*int64(cols); info.Size() != want {
+		f.Close()
+		return nil, fmt.Errorf("array file %s has %d bytes, want %d", path, info.Size(), want)
+	}
+
+	return &DiskArray{
+		r:       f,
+		closer:  f,
+		rows:    rows,
+		cols:    cols,
+		buf:     make([]byte, cols),
+		lastIdx: -1,
+	}, nil
+}
+
+// Rows returns the number of rows in the array.
+func (d *DiskArray) Rows() int { return d.rows }
+
+// Columns returns the number of columns in the array.
+func (d *DiskArray) Columns() int { return d.cols }
+
+// Row reads row i from disk, or returns it from the single-row cache.
+func (d *DiskArray) Row(i int) []int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if i == d.lastIdx {
+		return d.lastRow
+	}
+	if i < 0 || i >= d.rows {
+		panic(fmt.Sprintf("taguchi: row %d out of range [0,%d)", i, d.rows))
+	}
+	off := int64(arrayFileHeaderSize) + int64(i)*int64(d.cols)
+	if _, err := d.r.ReadAt(d.buf, off); err != nil {
+		panic(fmt.Sprintf("taguchi: read array row %d: %v", i, err))
+	}
+	row := make([]int, d.cols)
+	for j, b := range d.buf {
+		row[j] = int(b)
+	}
+	d.lastIdx, d.lastRow = i, row
+	return row
+}
+
+// Close releases the underlying file.
+func (d *DiskArray) Close() error {
+	return d.closer.Close()
+}
+
+// materializeArray reads every row of src into memory.
+func materializeArray(src ArraySource) [][]int {
+	switch m := src.(type) {
+	case MemoryArray:
+		return m
+	case *designArray:
+		return *m
+	}
+	oa := make([][]int, src.Rows())
+	for i := range oa {
+		oa[i] = append([]int(nil), src.Row(i)...)
+	}
+	return oa
+}
@@ -0,0 +1,82 @@
+package taguchi
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDiskArray_AnalyzesLikeMemory verifies that an experiment backed by an
+// on-disk array generates the same trials and analysis as the in-memory array.
+func TestDiskArray_AnalyzesLikeMemory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "l9.oa")
+	if err := WriteArrayFile(path, MemoryArray(StandardArrays[L9])); err != nil {
+		t.Fatalf("WriteArrayFile: %v", err)
+	}
+	disk, err := OpenArrayFile(path)
+	if err != nil {
+		t.Fatalf("OpenArrayFile: %v", err)
+	}
+	defer disk.Close()
+
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2, 3}},
+		{Name: "B", Levels: []float64{1, 2, 3}},
+		{Name: "C", Levels: []float64{1, 2, 3}},
+	}
+	mem, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L9, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	lazy, err := NewExperimentFromFactorsUsingSource(SmallerTheBetter{}, factors, disk, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingSource: %v", err)
+	}
+	if d := DiffDesigns(mem, lazy); !d.Empty() {
+		t.Fatalf("designs differ: %+v", d)
+	}
+
+	memTrials, lazyTrials := mem.GenerateTrials(), lazy.GenerateTrials()
+	if len(memTrials) != len(lazyTrials) {
+		t.Fatalf("trials: got %d, want %d", len(lazyTrials), len(memTrials))
+	}
+	for i := range memTrials {
+		obs := []float64{memTrials[i].Control["A"] + 2*memTrials[i].Control["B"]}
+		mem.AddResult(memTrials[i], obs)
+		lazy.AddResult(lazyTrials[i], obs)
+	}
+
+	want, got := mem.Analyze(), lazy.Analyze()
+	for _, f := range factors {
+		for i := range want.MainEffects[f.Name] {
+			if !almostEqual(got.MainEffects[f.Name][i], want.MainEffects[f.Name][i]) {
+				t.Errorf("MainEffects[%s][%d]: got %.4f, want %.4f", f.Name, i, got.MainEffects[f.Name][i], want.MainEffects[f.Name][i])
+			}
+		}
+	}
+}
+
+// TestSourceArraySpec_LevelMismatch verifies that factors whose level counts differ from
+// their columns are rejected for source-backed and loaded designs, naming the factor.
+func TestSourceArraySpec_LevelMismatch(t *testing.T) {
+	spec, err := SourceArraySpec(MemoryArray(StandardArrays[L18]))
+	if err != nil {
+		t.Fatalf("SourceArraySpec: %v", err)
+	}
+	if spec.Levels[0] != 2 || spec.Levels[1] != 3 {
+		t.Errorf("Levels: got %v, want [2 3 ...]", spec.Levels[:2])
+	}
+
+	factors := []ControlFactor{{Name: "Threads", Levels: []float64{1, 2, 4}}}
+	_, err = NewExperimentFromFactorsUsingSource(SmallerTheBetter{}, factors, MemoryArray(StandardArrays[L8]), nil)
+	if err == nil || !strings.Contains(err.Error(), "Threads") || !strings.Contains(err.Error(), "column 1") {
+		t.Errorf("three-level factor on L8: got %v, want an error naming Threads and column 1", err)
+	}
+
+	doc := `{"version": 1, "goal": {"type": "Smaller-the-Better"},
+		"controlFactors": [{"Name": "Threads", "Levels": [1, 2, 4]}],
+		"orthogonalArray": [[1], [2]]}`
+	if _, err := LoadExperiment[struct{}](strings.NewReader(doc)); err == nil {
+		t.Error("expected LoadExperiment to reject a three-level factor on a two-level column")
+	}
+}
@@ -0,0 +1,197 @@
+package taguchi
+
+import "fmt"
+
+// ArraySpec is an orthogonal array together with the level count of each column, so
+// that factors can be checked against the columns they are assigned to.
+// Name: Standard array name, or empty for a custom array.
+// Rows: The array itself, with 1-based levels.
+// Levels: Number of levels of each column.
+type ArraySpec struct {
+	Name   ArrayType
+	Rows   [][]int
+	Levels []int
+}
+
+// NewArraySpec builds the spec of a custom array, deriving each column's level count
+// from the highest level it uses. It returns an error if the array is empty, ragged,
+// or uses levels below 1.
+func NewArraySpec(rows [][]int) (ArraySpec, error) {
+	if len(rows) == 0 {
+		return ArraySpec{}, fmt.Errorf("orthogonal array must not be empty")
+	}
+	for i, row := range rows {
+		if len(row) != len(rows[0]) {
+			return ArraySpec{}, fmt.Errorf("orthogonal array row %d has %d columns, row 1 has %d", i+1, len(row), len(rows[0]))
+		}
+		for j, v := range row {
+			if v < 1 {
+				return ArraySpec{}, fmt.Errorf("orthogonal array row %d column %d uses level %d; levels start at 1", i+1, j+1, v)
+			}
+		}
+	}
+	return ArraySpec{Rows: rows, Levels: ColumnLevels(rows)}, nil
+}
+
+// ValidateArray checks that a user-provided array is a usable strength-2 orthogonal
+// array: rectangular, with levels from 1 up to each column's highest level, every level
+// appearing equally often within a column, and every pair of columns containing each
+// combination of their levels equally often. Analysis of a malformed array attributes
+// effects to the wrong factors, so NewExperimentUsingArray rejects it.
+func ValidateArray(oa [][]int) error {
+	spec, err := NewArraySpec(oa)
+	if err != nil {
+		return err
+	}
+	rows := len(oa)
+	for j, levels := range spec.Levels {
+		if rows%levels != 0 {
+			return fmt.Errorf("orthogonal array column %d has %d levels, which do not divide %d rows", j+1, levels, rows)
+		}
+		counts := make([]int, levels)
+		for _, row := range oa {
+			counts[row[j]-1]++
+		}
+		for l, n := range counts {
+			if n != rows/levels {
+				return fmt.Errorf("orthogonal array column %d is unbalanced: level %d appears %d times, want %d", j+1, l+1, n, rows/levels)
+			}
+		}
+	}
+	for a := range spec.Levels {
+		for b := a + 1; b < len(spec.Levels); b++ {
+			la, lb := spec.Levels[a], spec.Levels[b]
+			if rows%(la*lb) != 0 {
+				return fmt.Errorf("orthogonal array columns %d and %d cannot be orthogonal: %d level pairs do not divide %d rows", a+1, b+1, la*lb, rows)
+			}
+			counts := make([]int, la*lb)
+			for _, row := range oa {
+				counts[(row[a]-1)*lb+row[b]-1]++
+			}
+			for k, n := range counts {
+				if n != rows/(la*lb) {
+					return fmt.Errorf("orthogonal array columns %d and %d are not orthogonal: level pair (%d,%d) appears %d times, want %d", a+1, b+1, k/lb+1, k%lb+1, n, rows/(la*lb))
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// RegisterArray adds a project-specific array to StandardArrays under name, after
+// checking it with ValidateArray, so that it can be referenced by name like the built-in
+// arrays (NewExperiment, SelectArray, SuggestArrays, ArrayInfo, ...). Names already in
+// use are rejected. StandardArrays is not synchronized: register arrays during program
+// initialization, before experiments are built concurrently.
+func RegisterArray(name ArrayType, rows [][]int) error {
+	if name == "" {
+		return fmt.Errorf("array name must not be empty")
+	}
+	if _, ok := StandardArrays[name]; ok {
+		return fmt.Errorf("orthogonal array %s is already registered", name)
+	}
+	if err := ValidateArray(rows); err != nil {
+		return fmt.Errorf("register %s: %w", name, err)
+	}
+	StandardArrays[name] = rows
+	return nil
+}
+
+// StandardArraySpec returns the spec of a standard array from StandardArrays.
+func StandardArraySpec(name ArrayType) (ArraySpec, error) {
+	rows, ok := StandardArrays[name]
+	if !ok {
+		return ArraySpec{}, fmt.Errorf("orthogonal array %s not defined", name)
+	}
+	spec, err := NewArraySpec(rows)
+	spec.Name = name
+	return spec, err
+}
+
+// ColumnLevels returns the number of levels used in each column of an orthogonal
+// array. Mixed-level arrays such as L18 and L36 combine two- and three-level columns.
+func ColumnLevels(oa [][]int) []int {
+	if len(oa) == 0 {
+		return nil
+	}
+	levels := make([]int, len(oa[0]))
+	for _, row := range oa {
+		for j, v := range row {
+			levels[j] = max(levels[j], v)
+		}
+	}
+	return levels
+}
+
+// SourceArraySpec builds the spec of an array read from an ArraySource, streaming its
+// rows once to derive the column level counts; Rows is left nil so that large on-disk
+// arrays are not loaded into memory.
+func SourceArraySpec(src ArraySource) (ArraySpec, error) {
+	if src.Rows() == 0 {
+		return ArraySpec{}, fmt.Errorf("orthogonal array must not be empty")
+	}
+	levels := make([]int, src.Columns())
+	for i := 0; i < src.Rows(); i++ {
+		row := src.Row(i)
+		if len(row) != len(levels) {
+			return ArraySpec{}, fmt.Errorf("orthogonal array row %d has %d columns, want %d", i+1, len(row), len(levels))
+		}
+		for j, v := range row {
+			if v < 1 {
+				return ArraySpec{}, fmt.Errorf("orthogonal array row %d column %d uses level %d; levels start at 1", i+1, j+1, v)
+			}
+			levels[j] = max(levels[j], v)
+		}
+	}
+	return ArraySpec{Levels: levels}, nil
+}
+
+// Validate checks that the array has a column for every control factor and that each
+// factor has exactly as many levels as the column it is assigned to.
+func (s ArraySpec) Validate(controlFactors []ControlFactor) error {
+	label := "orthogonal array"
+	if s.Name != "" {
+		label += " " + string(s.Name)
+	}
+	if len(controlFactors) > len(s.Levels) {
+		return fmt.Errorf("%s cannot accommodate %d factors", label, len(controlFactors))
+	}
+	for j, factor := range controlFactors {
+		if len(factor.Levels) != s.Levels[j] {
+			return fmt.Errorf("factor %s has %d levels but column %d of %s has %d", factor.Name, len(factor.Levels), j+1, label, s.Levels[j])
+		}
+	}
+	return nil
+}
+
+// SelectArray returns the standard array with the fewest rows that accommodates the
+// factors, i.e. whose first columns match the factors' level counts in order. For
+// mixed-level arrays such as L18 the two-level factor must therefore come first.
+// Ties are broken by array name.
+func SelectArray(factors []ControlFactor) (ArrayType, error) {
+	var best ArrayType
+	bestRows := 0
+	for name, rows := range StandardArrays {
+		if bestRows > 0 && (len(rows) > bestRows || len(rows) == bestRows && name > best) {
+			continue
+		}
+		spec, err := StandardArraySpec(name)
+		if err != nil || spec.Validate(factors) != nil {
+			continue
+		}
+		best, bestRows = name, len(rows)
+	}
+	if bestRows == 0 {
+		return "", fmt.Errorf("no standard orthogonal array accommodates %d factors with level counts %v", len(factors), levelCounts(factors))
+	}
+	return best, nil
+}
+
+// levelCounts returns the number of levels of each factor.
+func levelCounts(factors []ControlFactor) []int {
+	counts := make([]int, len(factors))
+	for i, f := range factors {
+		counts[i] = len(f.Levels)
+	}
+	return counts
+}
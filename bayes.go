@@ -0,0 +1,298 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// BayesianOptions configures AnalyzeBayesian. Zero fields take their defaults.
+// Draws: Posterior draws kept after burn-in (default 4000).
+// BurnIn: Initial draws discarded (default 1000).
+// Credibility: Mass of the central credible intervals (default 0.95).
+// Seed: Random seed, for reproducible results (default 1).
+type BayesianOptions struct {
+	Draws       int
+	BurnIn      int
+	Credibility float64
+	Seed        int64
+}
+
+// Posterior summarizes the posterior distribution of a quantity.
+// Mean / SD: Posterior mean and standard deviation.
+// Lower / Upper: Central credible interval at the configured credibility.
+// Samples: The posterior draws themselves.
+type Posterior struct {
+	Mean    float64
+	SD      float64
+	Lower   float64
+	Upper   float64
+	Samples []float64
+}
+
+// BayesianResult stores the output of AnalyzeBayesian.
+// Effects: Posterior of each level's effect on the row SNR, relative to the overall mean.
+// ProbOptimal: Posterior probability that each level is the best level of its factor.
+// OptimalLevels: The level with the highest posterior mean effect, per factor.
+// OptimalSNR: Posterior of the predicted SNR at OptimalLevels.
+// Sigma: Posterior of the residual standard deviation of the row SNRs.
+type BayesianResult struct {
+	Credibility   float64
+	Effects       map[string][]Posterior
+	ProbOptimal   map[string][]float64
+	OptimalLevels map[string]float64
+	OptimalSNR    Posterior
+	Sigma         Posterior
+}
+
+// Weakly informative inverse-gamma prior shape and scale for the variances.
+const (
+	bayesPriorShape = 1e-3
+	bayesPriorScale = 1e-3
+)
+
+// AnalyzeBayesian fits a hierarchical Bayesian model to the row SNRs, as a probabilistic
+// alternative to ANOVA F-ratios:
+//
+//	SNR_i = μ + Σ_f α_f[level_f(i)] + ε_i,  ε_i ~ N(0, σ²),  α_f[l] ~ N(0, τ_f²)
+//
+// with a flat prior on μ and weak inverse-gamma priors on σ² and each τ_f². The factor
+// variances τ_f² let the data decide how much each factor's effects are shrunk towards
+// zero. The posterior is sampled with a Gibbs sampler.
+// It returns an error if any row has no results or a non-finite SNR.
+func (e *Experiment[P]) AnalyzeBayesian(opts BayesianOptions) (BayesianResult, error) {
+	if opts.Draws <= 0 {
+		opts.Draws = 4000
+	}
+	if opts.BurnIn <= 0 {
+		opts.BurnIn = 1000
+	}
+	if opts.Credibility <= 0 || opts.Credibility >= 1 {
+		opts.Credibility = 0.95
+	}
+	if opts.Seed == 0 {
+		opts.Seed = 1
+	}
+
+	y, _ := e.computeOASNR()
+	for i, v := range y {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return BayesianResult{}, fmt.Errorf("row %d has non-finite SNR %v", i+1, v)
+		}
+	}
+	for i, n := range e.rowResultCounts() {
+		if n == 0 {
+			return BayesianResult{}, fmt.Errorf("row %d has no results", i+1)
+		}
+	}
+
+	oa := e.array()
+	n := len(y)
+	level := make([][]int, len(e.ControlFactors))
+	for f := range e.ControlFactors {
+		level[f] = make([]int, n)
+		for i := 0; i < n; i++ {
+			level[f][i] = oa.Row(i)[f] - 1
+		}
+	}
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	mu := mean(y)
+	sigma2 := variance(y) + 1e-6
+	alpha := make([][]float64, len(e.ControlFactors))
+	tau2 := make([]float64, len(e.ControlFactors))
+	for f, factor := range e.ControlFactors {
+		alpha[f] = make([]float64, len(factor.Levels))
+		tau2[f] = sigma2
+	}
+
+	fitted := func(i int, skip int) float64 {
+		v := mu
+		for f := range alpha {
+			if f != skip {
+				v += alpha[f][level[f][i]]
+			}
+		}
+		return v
+	}
+
+	effectDraws := make([][][]float64, len(e.ControlFactors))
+	bestCounts := make([][]int, len(e.ControlFactors))
+	for f, factor := range e.ControlFactors {
+		effectDraws[f] = make([][]float64, len(factor.Levels))
+		bestCounts[f] = make([]int, len(factor.Levels))
+	}
+	sigmaDraws := make([]float64, 0, opts.Draws)
+	muDraws := make([]float64, 0, opts.Draws)
+
+	for iter := 0; iter < opts.BurnIn+opts.Draws; iter++ {
+		// μ | rest
+		sum := 0.0
+		for i := 0; i < n; i++ {
+			sum += y[i] - (fitted(i, -1) - mu)
+		}
+		mu = sum/float64(n) + rng.NormFloat64()*math.Sqrt(sigma2/float64(n))
+
+		for f := range alpha {
+			// α_f | rest
+			for l := range alpha[f] {
+				resid, count := 0.0, 0
+				for i := 0; i < n; i++ {
+					if level[f][i] == l {
+						resid += y[i] - fitted(i, f)
+						count++
+					}
+				}
+				prec := float64(count)/sigma2 + 1/tau2[f]
+				alpha[f][l] = resid/sigma2/prec + rng.NormFloat64()/math.Sqrt(prec)
+			}
+			// τ_f² | α_f
+			ss := 0.0
+			for _, a := range alpha[f] {
+				ss += a * a
+			}
+			tau2[f] = sampleInvGamma(rng, bayesPriorShape+float64(len(alpha[f]))/2, bayesPriorScale+ss/2)
+		}
+
+		// σ² | rest
+		ssr := 0.0
+		for i := 0; i < n; i++ {
+			r := y[i] - fitted(i, -1)
+			ssr += r * r
+		}
+		sigma2 = sampleInvGamma(rng, bayesPriorShape+float64(n)/2, bayesPriorScale+ssr/2)
+
+		if iter < opts.BurnIn {
+			continue
+		}
+		muDraws = append(muDraws, mu)
+		sigmaDraws = append(sigmaDraws, math.Sqrt(sigma2))
+		for f := range alpha {
+			best := 0
+			for l, a := range alpha[f] {
+				effectDraws[f][l] = append(effectDraws[f][l], a)
+				if a > alpha[f][best] {
+					best = l
+				}
+			}
+			bestCounts[f][best]++
+		}
+	}
+
+	result := BayesianResult{
+		Credibility:   opts.Credibility,
+		Effects:       map[string][]Posterior{},
+		ProbOptimal:   map[string][]float64{},
+		OptimalLevels: map[string]float64{},
+		Sigma:         summarizePosterior(sigmaDraws, opts.Credibility),
+	}
+	optimalSNR := append([]float64(nil), muDraws...)
+	for f, factor := range e.ControlFactors {
+		effects := make([]Posterior, len(factor.Levels))
+		probs := make([]float64, len(factor.Levels))
+		best := 0
+		for l := range factor.Levels {
+			effects[l] = summarizePosterior(effectDraws[f][l], opts.Credibility)
+			probs[l] = float64(bestCounts[f][l]) / float64(opts.Draws)
+			if effects[l].Mean > effects[best].Mean {
+				best = l
+			}
+		}
+		for d := range optimalSNR {
+			optimalSNR[d] += effectDraws[f][best][d]
+		}
+		result.Effects[factor.Name] = effects
+		result.ProbOptimal[factor.Name] = probs
+		result.OptimalLevels[factor.Name] = factor.Levels[best]
+	}
+	result.OptimalSNR = summarizePosterior(optimalSNR, opts.Credibility)
+	return result, nil
+}
+
+// rowResultCounts returns the number of results matching each orthogonal array row.
+func (e *Experiment[P]) rowResultCounts() []int {
+	rowResults := e.rowResults()
+	counts := make([]int, len(rowResults))
+	for i, results := range rowResults {
+		counts[i] = len(results)
+	}
+	return counts
+}
+
+// summarizePosterior computes the mean, standard deviation and central credible
+// interval of a set of draws.
+func summarizePosterior(draws []float64, credibility float64) Posterior {
+	sorted := append([]float64(nil), draws...)
+	sort.Float64s(sorted)
+	tail := (1 - credibility) / 2
+	return Posterior{
+		Mean:    mean(draws),
+		SD:      math.Sqrt(variance(draws)),
+		Lower:   quantileSorted(sorted, tail),
+		Upper:   quantileSorted(sorted, 1-tail),
+		Samples: draws,
+	}
+}
+
+// quantileSorted returns the q-quantile of sorted values by linear interpolation.
+func quantileSorted(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return math.NaN()
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	return sorted[lo] + (pos-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+func mean(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// variance returns the sample variance (n-1 denominator) of values.
+func variance(values []float64) float64 {
+	if len(values) < 2 {
+		return 0
+	}
+	m := mean(values)
+	ss := 0.0
+	for _, v := range values {
+		ss += (v - m) * (v - m)
+	}
+	return ss / float64(len(values)-1)
+}
+
+// sampleGamma draws from Gamma(shape, 1) using the Marsaglia-Tsang method.
+func sampleGamma(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// Boost: Gamma(a) = Gamma(a+1) · U^(1/a).
+		return sampleGamma(rng, shape+1) * math.Pow(rng.Float64(), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := rng.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := rng.Float64()
+		if math.Log(u) < 0.5*x*x+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+// sampleInvGamma draws from InverseGamma(shape, scale).
+func sampleInvGamma(rng *rand.Rand, shape, scale float64) float64 {
+	return scale / sampleGamma(rng, shape)
+}
@@ -0,0 +1,95 @@
+package taguchi
+
+import (
+	"fmt"
+	"testing"
+)
+
+// newBenchExperiment builds an L18 experiment with 8 factors and 5 noise levels,
+// with every trial recorded using reps observations.
+func newBenchExperiment(b *testing.B, reps int) *Experiment[struct{}] {
+	b.Helper()
+	oa := StandardArrays[L18]
+	factors := make([]ControlFactor, len(oa[0]))
+	for j := range factors {
+		factors[j] = ControlFactor{Name: string(rune('A' + j)), Levels: []float64{1, 2, 3}}
+	}
+	factors[0].Levels = []float64{1, 2}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{0, 1, 2, 3, 4}}}
+
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L18, noise)
+	if err != nil {
+		b.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	obs := make([]float64, reps)
+	for i, trial := range exp.GenerateTrials() {
+		for k := range obs {
+			obs[k] = float64(i%7 + k + 1)
+		}
+		exp.AddResult(trial, append([]float64(nil), obs...))
+	}
+	return exp
+}
+
+func BenchmarkGenerateTrials(b *testing.B) {
+	exp := newBenchExperiment(b, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		exp.GenerateTrials()
+	}
+}
+
+func BenchmarkAnalyze(b *testing.B) {
+	exp := newBenchExperiment(b, 10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		exp.Analyze()
+	}
+}
+
+// BenchmarkAnalyzeL64 analyzes a saturated L64 screening design with 63 two-level
+// factors and 8 noise levels.
+func BenchmarkAnalyzeL64(b *testing.B) {
+	factors := make([]ControlFactor, 63)
+	for j := range factors {
+		factors[j] = ControlFactor{Name: fmt.Sprintf("F%d", j+1), Levels: []float64{0, 1}}
+	}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{0, 1, 2, 3, 4, 5, 6, 7}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L64, noise)
+	if err != nil {
+		b.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{float64(i%11 + 1)})
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		exp.Analyze()
+	}
+}
+
+// BenchmarkAnalyzeL81 analyzes a saturated L81 design with 40 three-level factors and
+// 8 noise levels, including trial generation.
+func BenchmarkAnalyzeL81(b *testing.B) {
+	factors := make([]ControlFactor, 40)
+	for j := range factors {
+		factors[j] = ControlFactor{Name: fmt.Sprintf("F%d", j+1), Levels: []float64{0, 1, 2}}
+	}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{0, 1, 2, 3, 4, 5, 6, 7}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L81, noise)
+	if err != nil {
+		b.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		exp.Results = nil
+		for k, trial := range exp.GenerateTrials() {
+			exp.AddResult(trial, []float64{float64(k%11 + 1)})
+		}
+		exp.Analyze()
+	}
+}
@@ -0,0 +1,86 @@
+package benchmarks
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/marijaaleksic/taguchi"
+)
+
+// newExperiment builds an L18 experiment with 8 factors and 5 noise levels and
+// records results trial results, cycling through the generated trials.
+func newExperiment(b *testing.B, results int) *taguchi.Experiment[struct{}] {
+	b.Helper()
+	oa := taguchi.StandardArrays[taguchi.L18]
+	factors := make([]taguchi.ControlFactor, len(oa[0]))
+	for j := range factors {
+		factors[j] = taguchi.ControlFactor{Name: fmt.Sprintf("F%d", j), Levels: []float64{1, 2, 3}}
+	}
+	factors[0].Levels = []float64{1, 2}
+	noise := []taguchi.NoiseFactor{{Name: "N", Levels: []float64{0, 1, 2, 3, 4}}}
+
+	exp, err := taguchi.NewExperimentFromFactors(taguchi.SmallerTheBetter{}, factors, taguchi.L18, noise)
+	if err != nil {
+		b.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	trials := exp.GenerateTrials()
+	exp.Results = make([]taguchi.TrialResult, 0, results)
+	for i := 0; i < results; i++ {
+		exp.AddResult(trials[i%len(trials)], []float64{float64(i%13 + 1)})
+	}
+	return exp
+}
+
+func BenchmarkGenerateTrials(b *testing.B) {
+	exp := newExperiment(b, 0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		exp.GenerateTrials()
+	}
+}
+
+func BenchmarkAnalyze(b *testing.B) {
+	for _, n := range []int{1_000, 10_000, 100_000, 1_000_000} {
+		b.Run(fmt.Sprintf("results=%d", n), func(b *testing.B) {
+			exp := newExperiment(b, n)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				exp.Analyze()
+			}
+		})
+	}
+}
+
+func BenchmarkSave(b *testing.B) {
+	exp := newExperiment(b, 10_000)
+	var buf bytes.Buffer
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		if err := exp.Save(&buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.SetBytes(int64(buf.Len()))
+}
+
+func BenchmarkLoad(b *testing.B) {
+	exp := newExperiment(b, 10_000)
+	var buf bytes.Buffer
+	if err := exp.Save(&buf); err != nil {
+		b.Fatal(err)
+	}
+	data := buf.Bytes()
+	b.SetBytes(int64(len(data)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := taguchi.LoadExperiment[struct{}](bytes.NewReader(data)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
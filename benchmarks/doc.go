@@ -0,0 +1,10 @@
+// Package benchmarks holds the performance regression suite for the taguchi package.
+//
+// Run it with:
+//
+//	go test -run '^$' -bench . -benchmem ./benchmarks > new.txt
+//
+// and compare against the recorded baseline with benchstat:
+//
+//	benchstat baseline.txt new.txt
+package benchmarks
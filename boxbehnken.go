@@ -0,0 +1,161 @@
+package taguchi
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MethodBoxBehnken is the analysis method reported for Box–Behnken follow-up designs.
+const MethodBoxBehnken = "Box–Behnken (stepwise quadratic regression on SNR)"
+
+// boxBehnkenBlocks are the balanced incomplete blocks of the standard Box–Behnken
+// designs for 6 and 7 factors (0-based factor indices); smaller designs use every pair.
+var boxBehnkenBlocks = map[int][][]int{
+	6: {{0, 1, 3}, {1, 2, 4}, {2, 3, 5}, {0, 3, 4}, {1, 4, 5}, {0, 2, 5}},
+	7: {{3, 4, 5}, {0, 5, 6}, {1, 4, 6}, {0, 1, 3}, {2, 3, 6}, {0, 2, 4}, {1, 2, 5}},
+}
+
+// GenerateBoxBehnken returns a Box–Behnken design for 3 to 7 three-level factors: every
+// ±1 combination of each block of factors with the other factors at their center,
+// plus one center run, with levels 1, 2 and 3 for low, center and high. Blocks are the
+// pairs of factors for up to 5 factors and Box and Behnken's triples for 6 and 7. No
+// run sets every factor to an extreme, and a full quadratic model is estimable.
+func GenerateBoxBehnken(nFactors int) ([][]int, error) {
+	blocks, ok := boxBehnkenBlocks[nFactors]
+	switch {
+	case ok:
+	case nFactors >= 3 && nFactors <= 5:
+		for a := 0; a < nFactors; a++ {
+			for b := a + 1; b < nFactors; b++ {
+				blocks = append(blocks, []int{a, b})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("Box-Behnken design supports 3 to 7 factors, got %d", nFactors)
+	}
+
+	var design [][]int
+	for _, block := range blocks {
+		for signs := 0; signs < 1<<len(block); signs++ {
+			row := make([]int, nFactors)
+			for j := range row {
+				row[j] = 2
+			}
+			for b, j := range block {
+				row[j] = 1 + 2*(signs>>b&1)
+			}
+			design = append(design, row)
+		}
+	}
+	center := make([]int, nFactors)
+	for j := range center {
+		center[j] = 2
+	}
+	return append(design, center), nil
+}
+
+// FollowUpBoxBehnken moves from screening to optimization: it analyzes the experiment
+// and returns a new one with the same goal, noise factors and params type, running a
+// Box–Behnken design (see GenerateBoxBehnken) over the topFactors factors with the
+// largest contributions. Each of them gets three levels around its optimal level: the
+// optimum and its neighboring levels in this experiment, mirrored when the optimum is
+// at the first or last level (by ratio for PowerOfTwo factors). The other factors are held at their optimal levels.
+// Level rules are checked against the new levels. The follow-up is analyzed with a
+// quadratic model, like definitive screening designs.
+func (e *Experiment[P]) FollowUpBoxBehnken(topFactors int) (*Experiment[P], error) {
+	result := e.Analyze()
+	var candidates []string
+	for _, f := range e.ControlFactors {
+		if len(f.Levels) >= 2 {
+			candidates = append(candidates, f.Name)
+		}
+	}
+	if topFactors > len(candidates) {
+		return nil, fmt.Errorf("Box-Behnken follow-up needs %d factors with at least 2 levels, experiment has %d", topFactors, len(candidates))
+	}
+	slices.SortStableFunc(candidates, func(a, b string) int {
+		switch ca, cb := result.Contributions[a], result.Contributions[b]; {
+		case ca > cb:
+			return -1
+		case ca < cb:
+			return 1
+		}
+		return 0
+	})
+	top := candidates[:topFactors]
+
+	bbd, err := GenerateBoxBehnken(topFactors)
+	if err != nil {
+		return nil, err
+	}
+	factors := make([]ControlFactor, len(e.ControlFactors))
+	columns := make([]int, len(e.ControlFactors))
+	for j, f := range e.ControlFactors {
+		opt := slices.Index(f.Levels, result.OptimalLevels[f.Name])
+		if opt < 0 {
+			return nil, fmt.Errorf("factor %s has no optimal level; record results before following up", f.Name)
+		}
+		follow := f
+		follow.Components = nil
+		columns[j] = slices.Index(top, f.Name)
+		if columns[j] < 0 {
+			follow.Levels = []float64{f.Levels[opt]}
+			if f.Components != nil {
+				follow.Levels = []float64{1}
+				follow.Components = make(map[string][]float64, len(f.Components))
+				for name, values := range f.Components {
+					follow.Components[name] = []float64{values[opt]}
+				}
+			}
+			factors[j] = follow
+			continue
+		}
+		if f.Components != nil {
+			return nil, fmt.Errorf("compound factor %s cannot be re-leveled for a Box-Behnken follow-up", f.Name)
+		}
+		optimal := f.Levels[opt]
+		low, high := optimal, optimal
+		if opt > 0 {
+			low = f.Levels[opt-1]
+		}
+		if opt < len(f.Levels)-1 {
+			high = f.Levels[opt+1]
+		}
+		// Mirror geometrically for power-of-two levels, which are spaced by ratios.
+		geometric := f.Rules != nil && f.Rules.PowerOfTwo
+		switch {
+		case opt == 0 && geometric:
+			low = optimal * optimal / high
+		case opt == 0:
+			low = 2*optimal - high
+		case opt == len(f.Levels)-1 && geometric:
+			high = optimal * optimal / low
+		case opt == len(f.Levels)-1:
+			high = 2*optimal - low
+		}
+		follow.Levels = []float64{low, optimal, high}
+		factors[j] = follow
+	}
+	if err := checkLevelRules(factors); err != nil {
+		return nil, fmt.Errorf("Box-Behnken follow-up: %w", err)
+	}
+
+	design := make([][]int, len(bbd))
+	for i, run := range bbd {
+		design[i] = make([]int, len(factors))
+		for j, c := range columns {
+			design[i][j] = 1
+			if c >= 0 {
+				design[i][j] = run[c]
+			}
+		}
+	}
+	return &Experiment[P]{
+		ControlFactors:  factors,
+		NoiseFactors:    e.NoiseFactors,
+		Goal:            e.Goal,
+		OrthogonalArray: design,
+		controlAs:       e.controlAs,
+		design:          designBoxBehnken,
+	}, nil
+}
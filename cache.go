@@ -0,0 +1,96 @@
+package taguchi
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// MeasurementCache memoizes the observations of deterministic measurement functions by
+// control × noise configuration, so that configurations repeated across replications or
+// merged experiments are measured only once. It is safe for concurrent use.
+type MeasurementCache struct {
+	mu      sync.Mutex
+	entries map[string][]float64
+	hits    int
+	misses  int
+}
+
+// NewMeasurementCache creates an empty measurement cache.
+func NewMeasurementCache() *MeasurementCache {
+	return &MeasurementCache{entries: map[string][]float64{}}
+}
+
+// Measure returns the cached observations for the trial's configuration, calling measure
+// only if the configuration has not been measured yet. Errors are not cached.
+func (c *MeasurementCache) Measure(trial Trial, measure func(Trial) ([]float64, error)) ([]float64, error) {
+	key := configurationKey(trial)
+	c.mu.Lock()
+	if obs, ok := c.entries[key]; ok {
+		c.hits++
+		c.mu.Unlock()
+		return append([]float64(nil), obs...), nil
+	}
+	c.misses++
+	c.mu.Unlock()
+	return c.store(key, trial, measure)
+}
+
+// Refresh always calls measure, replacing any cached observations for the trial's
+// configuration. Use it to force a fresh run, e.g. after the system under test changed.
+func (c *MeasurementCache) Refresh(trial Trial, measure func(Trial) ([]float64, error)) ([]float64, error) {
+	c.mu.Lock()
+	c.misses++
+	c.mu.Unlock()
+	return c.store(configurationKey(trial), trial, measure)
+}
+
+func (c *MeasurementCache) store(key string, trial Trial, measure func(Trial) ([]float64, error)) ([]float64, error) {
+	obs, err := measure(trial)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = append([]float64(nil), obs...)
+	c.mu.Unlock()
+	return obs, nil
+}
+
+// Wrap adapts a Scheduler.Run measurement function so that its results are cached.
+// If fresh is true every trial is measured again and the cache is updated.
+func (c *MeasurementCache) Wrap(measure func(ScheduledTrial) ([]float64, error), fresh bool) func(ScheduledTrial) ([]float64, error) {
+	return func(st ScheduledTrial) ([]float64, error) {
+		fn := func(Trial) ([]float64, error) { return measure(st) }
+		if fresh {
+			return c.Refresh(st.Trial, fn)
+		}
+		return c.Measure(st.Trial, fn)
+	}
+}
+
+// Stats returns the number of cache hits and misses so far.
+func (c *MeasurementCache) Stats() (hits, misses int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.hits, c.misses
+}
+
+// Clear removes all cached observations.
+func (c *MeasurementCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = map[string][]float64{}
+}
+
+// configurationKey encodes a trial's control and noise settings in sorted key order,
+// ignoring the trial ID.
+func configurationKey(trial Trial) string {
+	var b strings.Builder
+	for _, m := range []map[string]float64{trial.Control, trial.Noise} {
+		for _, k := range sortedKeys(m) {
+			fmt.Fprintf(&b, "%q=%v;", k, m[k])
+		}
+		b.WriteByte('|')
+	}
+	return b.String()
+}
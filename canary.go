@@ -0,0 +1,53 @@
+package taguchi
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CanaryMeasure is a Scheduler.Run measurement function for canary experiments: canary
+// measures the trial's configuration on a canary slice of the system while baseline
+// measures the unchanged rest of it, in parallel. The observations are the paired
+// differences canary[i] - baseline[i], which cancel the noise both slices share, such as
+// traffic swings, so small effects stand out in production. Both functions must return
+// the same number of observations, paired by position.
+//
+// A difference is negative when the canary's response is lower, e.g. for latency
+// improvements; pair it with a goal that accepts negative responses.
+func CanaryMeasure(canary, baseline func(ScheduledTrial) ([]float64, error)) func(ScheduledTrial) ([]float64, error) {
+	return func(st ScheduledTrial) ([]float64, error) {
+		var base []float64
+		var baseErr error
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			base, baseErr = baseline(st)
+		}()
+		trial, err := canary(st)
+		<-done
+		if err != nil || baseErr != nil {
+			return nil, errors.Join(canaryErr("canary", err), canaryErr("baseline", baseErr))
+		}
+		return pairedDifference(trial, base)
+	}
+}
+
+// canaryErr labels err with the slice it came from; nil stays nil.
+func canaryErr(slice string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", slice, err)
+}
+
+// pairedDifference returns canary[i] - baseline[i].
+func pairedDifference(canary, baseline []float64) ([]float64, error) {
+	if len(canary) != len(baseline) {
+		return nil, fmt.Errorf("canary has %d observations, baseline %d; they must pair up", len(canary), len(baseline))
+	}
+	diff := make([]float64, len(canary))
+	for i := range canary {
+		diff[i] = canary[i] - baseline[i]
+	}
+	return diff, nil
+}
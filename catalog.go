@@ -0,0 +1,138 @@
+package taguchi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ArrayMetadata describes a standard orthogonal array.
+// Name: The array's name, e.g. L18.
+// Rows: Number of runs.
+// Columns: Number of columns.
+// Levels: Number of levels of each column.
+// MaxFactors: Largest number of control factors the array accommodates (one per column).
+// Strength: Largest t such that every t columns contain each combination of their levels
+// equally often; 2 for all standard arrays, so main effects are unconfounded with each other.
+type ArrayMetadata struct {
+	Name       ArrayType
+	Rows       int
+	Columns    int
+	Levels     []int
+	MaxFactors int
+	Strength   int
+}
+
+// ArrayInfo returns the metadata of a standard array, for tools and UIs that present
+// the available designs.
+func ArrayInfo(name ArrayType) (ArrayMetadata, error) {
+	spec, err := StandardArraySpec(name)
+	if err != nil {
+		return ArrayMetadata{}, err
+	}
+	return ArrayMetadata{
+		Name:       name,
+		Rows:       len(spec.Rows),
+		Columns:    len(spec.Levels),
+		Levels:     spec.Levels,
+		MaxFactors: len(spec.Levels),
+		Strength:   arrayStrength(spec.Rows, spec.Levels),
+	}, nil
+}
+
+// ListArrays returns the names of the standard arrays, ordered by number of runs and
+// then by name.
+func ListArrays() []ArrayType {
+	names := make([]ArrayType, 0, len(StandardArrays))
+	for name := range StandardArrays {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		ri, rj := len(StandardArrays[names[i]]), len(StandardArrays[names[j]])
+		if ri != rj {
+			return ri < rj
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// arrayStrength returns the strength of oa, checking t = 1, 2, ... until some t columns
+// are unbalanced.
+func arrayStrength(oa [][]int, levels []int) int {
+	strength := 0
+	for t := 1; t <= len(levels); t++ {
+		if !balancedSubsets(oa, levels, t) {
+			break
+		}
+		strength = t
+	}
+	return strength
+}
+
+// balancedSubsets reports whether every t columns of oa contain each combination of
+// their levels equally often.
+func balancedSubsets(oa [][]int, levels []int, t int) bool {
+	cols := make([]int, t)
+	for i := range cols {
+		cols[i] = i
+	}
+	for {
+		combos := 1
+		for _, c := range cols {
+			combos *= levels[c]
+		}
+		if len(oa)%combos != 0 {
+			return false
+		}
+		counts := make(map[string]int, combos)
+		key := make([]byte, t)
+		for _, row := range oa {
+			for i, c := range cols {
+				key[i] = byte(row[c])
+			}
+			counts[string(key)]++
+		}
+		if len(counts) != combos {
+			return false
+		}
+		for _, n := range counts {
+			if n != len(oa)/combos {
+				return false
+			}
+		}
+
+		// Advance to the next combination of t column indexes.
+		i := t - 1
+		for i >= 0 && cols[i] == len(levels)-t+i {
+			i--
+		}
+		if i < 0 {
+			return true
+		}
+		cols[i]++
+		for j := i + 1; j < t; j++ {
+			cols[j] = cols[j-1] + 1
+		}
+	}
+}
+
+// String returns a one-line summary such as "L18: 18 runs, 8 columns (2^1 3^7), strength 2".
+func (m ArrayMetadata) String() string {
+	counts := map[int]int{}
+	for _, l := range m.Levels {
+		counts[l]++
+	}
+	levels := make([]int, 0, len(counts))
+	for l := range counts {
+		levels = append(levels, l)
+	}
+	sort.Ints(levels)
+	mix := ""
+	for i, l := range levels {
+		if i > 0 {
+			mix += " "
+		}
+		mix += fmt.Sprintf("%d^%d", l, counts[l])
+	}
+	return fmt.Sprintf("%s: %d runs, %d columns (%s), strength %d", m.Name, m.Rows, m.Columns, mix, m.Strength)
+}
@@ -0,0 +1,112 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+)
+
+// MethodCentralComposite is the analysis method reported for central composite designs.
+const MethodCentralComposite = "Central composite (stepwise quadratic regression on SNR)"
+
+// CentralCompositeType selects where the axial runs of a central composite design go.
+type CentralCompositeType string
+
+const (
+	// CentralCompositeFaceCentered puts the axial runs on the faces of the factorial
+	// cube, so every factor has three levels: the ends and the middle of its range.
+	CentralCompositeFaceCentered CentralCompositeType = "face-centered"
+	// CentralCompositeRotatable puts the axial runs at distance α = F^(1/4) for F
+	// factorial runs, which makes the prediction variance depend only on the distance
+	// from the center. The design is inscribed: the axial runs sit at the ends of the
+	// ranges and the factorial runs at ±1/α, giving five levels per factor.
+	CentralCompositeRotatable CentralCompositeType = "rotatable"
+)
+
+// GenerateCentralComposite returns a central composite design for nFactors factors: a
+// two-level factorial core (full for up to 4 factors, a resolution V fraction from
+// GenerateFractionalFactorial beyond), two axial runs per factor and one center run.
+// Face-centered designs use levels 1, 2, 3 for -1, 0, +1; rotatable designs use levels
+// 1 to 5 for -α, -1, 0, +1, +α. It also returns α (1 for face-centered designs).
+func GenerateCentralComposite(nFactors int, kind CentralCompositeType) ([][]int, float64, error) {
+	if nFactors < 2 {
+		return nil, 0, fmt.Errorf("central composite design needs at least 2 factors, got %d", nFactors)
+	}
+	core, err := GenerateFractionalFactorial(nFactors, 5)
+	if err != nil {
+		return nil, 0, err
+	}
+	var low, center, high, axialLow, axialHigh int
+	alpha := 1.0
+	switch kind {
+	case CentralCompositeFaceCentered:
+		low, center, high, axialLow, axialHigh = 1, 2, 3, 1, 3
+	case CentralCompositeRotatable:
+		low, center, high, axialLow, axialHigh = 2, 3, 4, 1, 5
+		alpha = math.Pow(float64(len(core.Array)), 0.25)
+	default:
+		return nil, 0, fmt.Errorf("unknown central composite type %q", kind)
+	}
+
+	design := make([][]int, 0, len(core.Array)+2*nFactors+1)
+	for _, run := range core.Array {
+		row := make([]int, nFactors)
+		for j, l := range run {
+			row[j] = low
+			if l == 2 {
+				row[j] = high
+			}
+		}
+		design = append(design, row)
+	}
+	for j := 0; j < nFactors; j++ {
+		for _, axial := range []int{axialLow, axialHigh} {
+			row := make([]int, nFactors)
+			for b := range row {
+				row[b] = center
+			}
+			row[j] = axial
+			design = append(design, row)
+		}
+	}
+	centerRun := make([]int, nFactors)
+	for j := range centerRun {
+		centerRun[j] = center
+	}
+	return append(design, centerRun), alpha, nil
+}
+
+// NewCentralCompositeExperiment builds a response-surface experiment over the factors'
+// ranges on GenerateCentralComposite's design. Every run lies within the ranges: the
+// outermost levels of each factor are its Min and Max. Trials, AddResult and Params
+// work as usual, and Analyze fits a quadratic model by forward selection, on the level
+// values coded from -1 at Min to +1 at Max, like definitive screening designs.
+func NewCentralCompositeExperiment(goal OptimizationGoal, factors []ContinuousFactor, kind CentralCompositeType, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	design, alpha, err := GenerateCentralComposite(len(factors), kind)
+	if err != nil {
+		return nil, err
+	}
+	controlFactors := make([]ControlFactor, len(factors))
+	for j, f := range factors {
+		if !(f.Min < f.Max) {
+			return nil, fmt.Errorf("factor %s: min %v must be less than max %v", f.Name, f.Min, f.Max)
+		}
+		mid, half := (f.Min+f.Max)/2, (f.Max-f.Min)/2
+		coded := []float64{-1, 0, 1}
+		if kind == CentralCompositeRotatable {
+			coded = []float64{-alpha, -1, 0, 1, alpha}
+		}
+		levels := make([]float64, len(coded))
+		for l, c := range coded {
+			levels[l] = mid + half*c/coded[len(coded)-1]
+		}
+		levels[0], levels[len(levels)-1] = f.Min, f.Max
+		controlFactors[j] = ControlFactor{Name: f.Name, Levels: levels}
+	}
+	return &Experiment[struct{}]{
+		ControlFactors:  controlFactors,
+		NoiseFactors:    noiseFactors,
+		Goal:            goal,
+		OrthogonalArray: design,
+		design:          designCentralComposite,
+	}, nil
+}
@@ -0,0 +1,50 @@
+//go:build !tinygo
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/marijaaleksic/taguchi"
+)
+
+// loadDesign reads an experiment written by taguchi plan -format json or by Save.
+func loadDesign(path string) (*taguchi.Experiment[struct{}], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return taguchi.LoadExperiment[struct{}](f)
+}
+
+// matrix writes the design's trials as a GitHub Actions matrix to w.
+func matrix(w io.Writer, design string) error {
+	exp, err := loadDesign(design)
+	if err != nil {
+		return err
+	}
+	m, err := exp.ActionsMatrix()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s\n", m)
+	return err
+}
+
+// collect records the matrix jobs' results under dir into the design and writes the
+// experiment, with its results, to w.
+func collect(w io.Writer, design, dir string) error {
+	exp, err := loadDesign(design)
+	if err != nil {
+		return err
+	}
+	n, err := exp.CollectActionsResults(dir)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "collected %d results\n", n)
+	return exp.Save(w)
+}
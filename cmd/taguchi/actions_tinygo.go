@@ -0,0 +1,18 @@
+//go:build tinygo
+
+package main
+
+import (
+	"errors"
+	"io"
+)
+
+// matrix is not supported under TinyGo.
+func matrix(w io.Writer, design string) error {
+	return errors.New("GitHub Actions matrices are not supported under TinyGo")
+}
+
+// collect is not supported under TinyGo.
+func collect(w io.Writer, design, dir string) error {
+	return errors.New("GitHub Actions results are not supported under TinyGo")
+}
@@ -0,0 +1,100 @@
+// Command taguchi provides command-line tools for the taguchi package.
+//
+// Usage:
+//
+//	taguchi plan [-format go|json] [-o file]
+//	taguchi matrix design.json
+//	taguchi collect [-o file] design.json results-dir
+//
+// The plan command walks through the goal, control factors, noise factors and run
+// budget interactively, suggests orthogonal arrays with their trade-offs, and writes
+// the chosen design as Go source (see taguchi.ExportAsGo) or as the JSON read by
+// taguchi.LoadExperiment.
+//
+// The matrix and collect commands run a JSON design on GitHub Actions: matrix prints
+// the trials as a strategy.matrix (see taguchi.Experiment.ActionsMatrix), one job per
+// trial, and collect records the results the jobs uploaded as artifacts (see
+// taguchi.WriteActionsResult) and writes the experiment with its results.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "plan":
+		fs := flag.NewFlagSet("plan", flag.ExitOnError)
+		format := fs.String("format", "go", "output format: go or json")
+		out := fs.String("o", "", "write the design to this file instead of stdout")
+		fs.Parse(os.Args[2:])
+
+		if *format != "go" && *format != "json" {
+			fmt.Fprintf(os.Stderr, "taguchi plan: unknown format %q\n", *format)
+			os.Exit(2)
+		}
+		var w io.Writer = os.Stdout
+		if *out != "" {
+			f, err := os.Create(*out)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "taguchi plan: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
+		p := &planner{in: bufio.NewScanner(os.Stdin), out: os.Stderr}
+		if err := p.run(w, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "taguchi plan: %v\n", err)
+			os.Exit(1)
+		}
+	case "matrix":
+		if len(os.Args) != 3 {
+			usage()
+			os.Exit(2)
+		}
+		if err := matrix(os.Stdout, os.Args[2]); err != nil {
+			fmt.Fprintf(os.Stderr, "taguchi matrix: %v\n", err)
+			os.Exit(1)
+		}
+	case "collect":
+		fs := flag.NewFlagSet("collect", flag.ExitOnError)
+		out := fs.String("o", "", "write the experiment to this file instead of stdout")
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 2 {
+			usage()
+			os.Exit(2)
+		}
+		var w io.Writer = os.Stdout
+		if *out != "" {
+			f, err := os.Create(*out)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "taguchi collect: %v\n", err)
+				os.Exit(1)
+			}
+			defer f.Close()
+			w = f
+		}
+		if err := collect(w, fs.Arg(0), fs.Arg(1)); err != nil {
+			fmt.Fprintf(os.Stderr, "taguchi collect: %v\n", err)
+			os.Exit(1)
+		}
+	default:
+		usage()
+		os.Exit(2)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: taguchi plan [-format go|json] [-o file]")
+	fmt.Fprintln(os.Stderr, "       taguchi matrix design.json")
+	fmt.Fprintln(os.Stderr, "       taguchi collect [-o file] design.json results-dir")
+}
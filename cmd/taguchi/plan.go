@@ -0,0 +1,191 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/marijaaleksic/taguchi"
+)
+
+// planner asks the planning questions on out and reads the answers from in.
+type planner struct {
+	in  *bufio.Scanner
+	out io.Writer
+}
+
+// run walks through the design questions and writes the chosen design to w.
+func (p *planner) run(w io.Writer, format string) error {
+	goal, err := p.goal()
+	if err != nil {
+		return err
+	}
+	factors, err := p.controlFactors()
+	if err != nil {
+		return err
+	}
+	noise, err := p.noiseFactors()
+	if err != nil {
+		return err
+	}
+	budget, err := p.number("Maximum number of runs, counting noise conditions (0 for no limit)", 0)
+	if err != nil {
+		return err
+	}
+
+	combos := 1
+	for _, n := range noise {
+		combos *= len(n.Levels)
+	}
+	var fits []taguchi.ArraySuggestion
+	fmt.Fprintln(p.out, "\nCandidate designs:")
+	for _, s := range taguchi.SuggestArrays(factors) {
+		runs := s.Runs * combos
+		if budget > 0 && runs > budget {
+			continue
+		}
+		fits = append(fits, s)
+		name := string(s.Array)
+		if name == "" {
+			name = "full factorial"
+		}
+		fmt.Fprintf(p.out, "  %d) %s: %d runs\n", len(fits), name, runs)
+		for _, note := range s.Notes {
+			fmt.Fprintf(p.out, "       - %s\n", note)
+		}
+	}
+	if len(fits) == 0 {
+		return fmt.Errorf("no design fits a budget of %d runs; drop factors or levels, or reduce the noise conditions", budget)
+	}
+	choice, err := p.number("Design to use", 1)
+	if err != nil {
+		return err
+	}
+	if choice < 1 || choice > len(fits) {
+		return fmt.Errorf("no design %d", choice)
+	}
+
+	var exp *taguchi.Experiment[struct{}]
+	if s := fits[choice-1]; s.Array == "" {
+		exp, err = taguchi.NewFullFactorialExperiment(goal, s.Factors, noise)
+	} else {
+		exp, err = taguchi.NewExperimentFromFactors(goal, s.Factors, s.Array, noise)
+	}
+	if err != nil {
+		return err
+	}
+	for _, issue := range exp.CheckDesign() {
+		fmt.Fprintf(p.out, "warning: %s\n", issue)
+	}
+	if format == "json" {
+		return exp.Save(w)
+	}
+	return taguchi.ExportAsGo(w, exp)
+}
+
+// goal asks for the optimization goal.
+func (p *planner) goal() (taguchi.OptimizationGoal, error) {
+	answer, err := p.ask("Goal: smaller, larger, nominal or nominal-variance [smaller]")
+	if err != nil {
+		return nil, err
+	}
+	switch answer {
+	case "", "smaller":
+		return taguchi.SmallerTheBetter{}, nil
+	case "larger":
+		return taguchi.LargerTheBetter{}, nil
+	case "nominal":
+		target, err := p.ask("Target value")
+		if err != nil {
+			return nil, err
+		}
+		v, err := strconv.ParseFloat(target, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target %q", target)
+		}
+		return taguchi.NominalTheBest{Target: v}, nil
+	case "nominal-variance":
+		return taguchi.NominalTheBestI{}, nil
+	}
+	return nil, fmt.Errorf("unknown goal %q", answer)
+}
+
+// controlFactors asks for control factors until an empty name is entered.
+func (p *planner) controlFactors() ([]taguchi.ControlFactor, error) {
+	var factors []taguchi.ControlFactor
+	for {
+		name, levels, err := p.factor("Control factor name (empty to finish)")
+		if err != nil {
+			return nil, err
+		}
+		if name == "" {
+			break
+		}
+		factors = append(factors, taguchi.ControlFactor{Name: name, Levels: levels})
+	}
+	if len(factors) == 0 {
+		return nil, errors.New("at least one control factor is required")
+	}
+	return factors, nil
+}
+
+// noiseFactors asks for noise factors until an empty name is entered.
+func (p *planner) noiseFactors() ([]taguchi.NoiseFactor, error) {
+	var noise []taguchi.NoiseFactor
+	for {
+		name, levels, err := p.factor("Noise factor name (empty to finish)")
+		if err != nil || name == "" {
+			return noise, err
+		}
+		noise = append(noise, taguchi.NoiseFactor{Name: name, Levels: levels})
+	}
+}
+
+// factor asks for a factor name and, unless it is empty, its comma-separated levels.
+func (p *planner) factor(prompt string) (string, []float64, error) {
+	name, err := p.ask(prompt)
+	if err != nil || name == "" {
+		return "", nil, err
+	}
+	answer, err := p.ask("  Levels of " + name + ", comma-separated")
+	if err != nil {
+		return "", nil, err
+	}
+	var levels []float64
+	for _, field := range strings.Split(answer, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("factor %s: invalid level %q", name, field)
+		}
+		levels = append(levels, v)
+	}
+	if len(levels) < 2 {
+		return "", nil, fmt.Errorf("factor %s: at least 2 levels required", name)
+	}
+	return name, levels, nil
+}
+
+// number asks for an integer, returning def for an empty answer.
+func (p *planner) number(prompt string, def int) (int, error) {
+	answer, err := p.ask(fmt.Sprintf("%s [%d]", prompt, def))
+	if err != nil || answer == "" {
+		return def, err
+	}
+	n, err := strconv.Atoi(answer)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", answer)
+	}
+	return n, nil
+}
+
+// ask prints a prompt and returns the trimmed answer. End of input is an empty answer.
+func (p *planner) ask(prompt string) (string, error) {
+	fmt.Fprintf(p.out, "%s: ", prompt)
+	if !p.in.Scan() {
+		return "", p.in.Err()
+	}
+	return strings.TrimSpace(p.in.Text()), nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/marijaaleksic/taguchi"
+)
+
+// plan drives the planner with the scripted answers, one per line.
+func plan(t *testing.T, script ...string) (string, *bytes.Buffer, error) {
+	t.Helper()
+	var prompts, design bytes.Buffer
+	p := &planner{in: bufio.NewScanner(strings.NewReader(strings.Join(script, "\n") + "\n")), out: &prompts}
+	err := p.run(&design, "json")
+	return prompts.String(), &design, err
+}
+
+func TestPlanner(t *testing.T) {
+	prompts, design, err := plan(t,
+		"nominal", "5",
+		"A", "1, 2", "B", "1,2", "C", "1,2", "",
+		"Load", "0,1", "",
+		"",
+		"1",
+	)
+	if err != nil {
+		t.Fatalf("run: %v\n%s", err, prompts)
+	}
+	if !strings.Contains(prompts, "1) L4: 8 runs") || !strings.Contains(prompts, "full factorial: 16 runs") {
+		t.Errorf("candidates: got\n%s\nwant L4 first and the full factorial", prompts)
+	}
+	exp, err := taguchi.LoadExperiment[struct{}](design)
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	if len(exp.OrthogonalArray) != 4 || len(exp.ControlFactors) != 3 || len(exp.NoiseFactors) != 1 {
+		t.Errorf("design: got %d rows, %d control and %d noise factors, want 4, 3 and 1",
+			len(exp.OrthogonalArray), len(exp.ControlFactors), len(exp.NoiseFactors))
+	}
+	if goal, ok := exp.Goal.(taguchi.NominalTheBest); !ok || goal.Target != 5 {
+		t.Errorf("goal: got %#v, want NominalTheBest{Target: 5}", exp.Goal)
+	}
+
+	if _, _, err := plan(t, "", "A", "1,2", "B", "1,2", "", "", "2"); err == nil {
+		t.Error("expected an error for a budget no design fits")
+	}
+	if _, _, err := plan(t, "", "A", "1", ""); err == nil {
+		t.Error("expected an error for a factor with one level")
+	}
+	if _, _, err := plan(t, "", "A", "1,2", "", "", "", "9"); err == nil {
+		t.Error("expected an error for choosing a design that was not offered")
+	}
+}
@@ -0,0 +1,72 @@
+package taguchi
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// CompoundFactor combines settings that only make sense together, such as temperature
+// and time pairs, into one control factor occupying a single column. Level l of the
+// factor (levels are numbered 1..n) sets each component to components[name][l-1];
+// Params and Settings expand a trial's compound level back into the component values.
+func CompoundFactor(name string, components map[string][]float64) (ControlFactor, error) {
+	if len(components) < 2 {
+		return ControlFactor{}, fmt.Errorf("compound factor %s needs at least 2 components, got %d", name, len(components))
+	}
+	n := -1
+	for _, component := range sortedKeys(components) {
+		values := components[component]
+		if n >= 0 && len(values) != n {
+			return ControlFactor{}, fmt.Errorf("compound factor %s: component %s has %d levels, want %d", name, component, len(values), n)
+		}
+		n = len(values)
+	}
+	if n < 2 {
+		return ControlFactor{}, fmt.Errorf("compound factor %s: at least 2 levels required, got %d", name, n)
+	}
+	levels := make([]float64, n)
+	for l := range levels {
+		levels[l] = float64(l + 1)
+	}
+	return ControlFactor{Name: name, Levels: levels, Components: components}, nil
+}
+
+// Settings returns the trial's control settings with every compound factor expanded
+// into its components. The returned map must not be modified.
+func (e *Experiment[P]) Settings(trial Trial) map[string]float64 {
+	settings, cloned := trial.Control, false
+	for _, factor := range e.ControlFactors {
+		if factor.Components == nil {
+			continue
+		}
+		level, ok := trial.Control[factor.Name]
+		if !ok || level < 1 || int(level) > len(factor.Levels) {
+			continue
+		}
+		if !cloned {
+			settings, cloned = maps.Clone(trial.Control), true
+		}
+		for component, values := range factor.Components {
+			settings[component] = values[int(level)-1]
+		}
+	}
+	return settings
+}
+
+// splitCompoundTag separates a `compound=A+B` entry from a `taguchi:"..."` struct tag,
+// returning the component names and the remaining tag.
+func splitCompoundTag(tag string) ([]string, string) {
+	var components []string
+	var rest []string
+	for _, part := range strings.Split(tag, ",") {
+		if names, ok := strings.CutPrefix(strings.TrimSpace(part), "compound="); ok {
+			components = strings.Split(names, "+")
+			continue
+		}
+		if part != "" {
+			rest = append(rest, part)
+		}
+	}
+	return components, strings.Join(rest, ",")
+}
@@ -0,0 +1,77 @@
+package taguchi
+
+import "slices"
+
+// CostSummary aggregates the recorded cost of running an experiment's trials.
+// Costs are in whatever unit the cost callback uses (seconds, dollars, joules, ...).
+// Total: Total cost of all results.
+// Trials: Number of results that contributed.
+// PerLevel: Mean cost per result at each level of each control factor.
+// ExpectedGain: Predicted SNR improvement (dB) of the optimal levels over the average run;
+// 0 for rank-based analyses, whose main effects are not in dB.
+// ExpectedChange: ExpectedGain as a change of the raw response (see DescribeChange).
+// CostPerDB: Total divided by ExpectedGain; 0 when no gain is expected.
+type CostSummary struct {
+	Total          float64
+	Trials         int
+	PerLevel       map[string][]float64
+	ExpectedGain   float64
+	ExpectedChange string
+	CostPerDB      float64
+}
+
+// CostSummary aggregates TrialResult.Cost over the recorded results, per factor level and
+// for the whole campaign, and weighs it against the expected benefit of the optimal
+// levels found by Analyze. It returns nil if no result has a cost.
+func (e *Experiment[P]) CostSummary() *CostSummary {
+	oaSNR, grandMean := e.computeOASNR()
+	_, mainEffects, _ := e.computeANOVA(oaSNR, grandMean)
+	return e.costSummary(MethodParametric, mainEffects, grandMean)
+}
+
+func (e *Experiment[P]) costSummary(method string, mainEffects map[string][]float64, grandMean float64) *CostSummary {
+	if !slices.ContainsFunc(e.Results, func(r TrialResult) bool { return r.Cost != 0 }) {
+		return nil
+	}
+	summary := &CostSummary{PerLevel: make(map[string][]float64, len(e.ControlFactors))}
+	counts := make(map[string][]int, len(e.ControlFactors))
+	for _, factor := range e.ControlFactors {
+		summary.PerLevel[factor.Name] = make([]float64, len(factor.Levels))
+		counts[factor.Name] = make([]int, len(factor.Levels))
+	}
+	for _, r := range e.Results {
+		summary.Total += r.Cost
+		summary.Trials++
+		for _, factor := range e.ControlFactors {
+			for l, level := range factor.Levels {
+				if r.Trial.Control[factor.Name] == level {
+					summary.PerLevel[factor.Name][l] += r.Cost
+					counts[factor.Name][l]++
+				}
+			}
+		}
+	}
+	for name, levels := range summary.PerLevel {
+		for l := range levels {
+			if n := counts[name][l]; n > 0 {
+				levels[l] /= float64(n)
+			}
+		}
+	}
+
+	if !effectsInDB(method) {
+		return summary
+	}
+	for _, factor := range e.ControlFactors {
+		best := grandMean
+		for _, m := range mainEffects[factor.Name] {
+			best = max(best, m)
+		}
+		summary.ExpectedGain += best - grandMean
+	}
+	summary.ExpectedChange = DescribeChange(e.Goal, summary.ExpectedGain)
+	if summary.ExpectedGain > 0 {
+		summary.CostPerDB = summary.Total / summary.ExpectedGain
+	}
+	return summary
+}
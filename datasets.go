@@ -0,0 +1,86 @@
+package taguchi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Dataset is a canonical teaching dataset with its expected analysis.
+// Name / Description: Identifier and short history of the experiment.
+// Observations: Recorded observations for each trial, in GenerateTrials order.
+// ExpectedOptimal: Optimal factor levels the analysis is expected to report.
+type Dataset struct {
+	Name            string
+	Description     string
+	Goal            OptimizationGoal
+	ControlFactors  []ControlFactor
+	NoiseFactors    []NoiseFactor
+	OrthogonalArray [][]int
+	Observations    [][]float64
+	ExpectedOptimal map[string]float64
+}
+
+// Experiment builds the dataset's experiment with all observations recorded.
+func (d Dataset) Experiment() (*Experiment[struct{}], error) {
+	exp, err := NewExperimentFromFactorsUsingArray(d.Goal, d.ControlFactors, d.OrthogonalArray, d.NoiseFactors)
+	if err != nil {
+		return nil, err
+	}
+	trials := exp.GenerateTrials()
+	if len(trials) != len(d.Observations) {
+		return nil, fmt.Errorf("dataset %s: %d trials but %d observation sets", d.Name, len(trials), len(d.Observations))
+	}
+	for i, trial := range trials {
+		exp.AddResult(trial, d.Observations[i])
+	}
+	return exp, nil
+}
+
+// datasets holds the built-in datasets by name.
+var datasets = map[string]Dataset{
+	"ina-tile": {
+		Name: "ina-tile",
+		Description: "Ina Seito tile experiment (1953): seven two-level process factors in an L8 array, " +
+			"response is the number of defective tiles per 100. Levels are coded 1 (current) and 2 (alternative).",
+		Goal: SmallerTheBetter{},
+		ControlFactors: []ControlFactor{
+			{Name: "LimeContent", Levels: []float64{1, 2}},
+			{Name: "AdditiveGranularity", Levels: []float64{1, 2}},
+			{Name: "AgalmatoliteContent", Levels: []float64{1, 2}},
+			{Name: "AgalmatoliteType", Levels: []float64{1, 2}},
+			{Name: "ChargeQuantity", Levels: []float64{1, 2}},
+			{Name: "WasteReturn", Levels: []float64{1, 2}},
+			{Name: "FeldsparContent", Levels: []float64{1, 2}},
+		},
+		OrthogonalArray: StandardArrays[L8],
+		Observations:    [][]float64{{16}, {17}, {12}, {6}, {6}, {68}, {42}, {26}},
+		ExpectedOptimal: map[string]float64{
+			"LimeContent":         1,
+			"AdditiveGranularity": 2,
+			"AgalmatoliteContent": 2,
+			"AgalmatoliteType":    1,
+			"ChargeQuantity":      2,
+			"WasteReturn":         1,
+			"FeldsparContent":     2,
+		},
+	},
+}
+
+// LoadDataset returns the built-in dataset with the given name.
+func LoadDataset(name string) (Dataset, error) {
+	d, ok := datasets[name]
+	if !ok {
+		return Dataset{}, fmt.Errorf("dataset %s not defined", name)
+	}
+	return d, nil
+}
+
+// DatasetNames lists the built-in datasets in alphabetical order.
+func DatasetNames() []string {
+	names := make([]string, 0, len(datasets))
+	for name := range datasets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,49 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+)
+
+// FractionDefective is the goal for pass/fail responses, e.g. the share of requests
+// that fail or of builds that are flaky. Each observation is a fraction defective in
+// [0, 1]: the fraction of one replicate, as recorded by AddPassFailResult, or a single
+// outcome, 1 for a failure and 0 for a success.
+type FractionDefective struct{}
+
+// CalculateSNR computes the omega-transformed SNR of the mean fraction defective p.
+// Formula: -10 * log10(p / (1 - p))
+// It is +Inf for p = 0, -Inf for p = 1 and NaN for fractions outside [0, 1].
+func (f FractionDefective) CalculateSNR(obs []float64) float64 {
+	if len(obs) == 0 {
+		return 0
+	}
+	p := 0.0
+	for _, y := range obs {
+		if y < 0 || y > 1 {
+			return math.NaN()
+		}
+		p += y
+	}
+	p /= float64(len(obs))
+	return -10 * math.Log10(p/(1-p))
+}
+
+// String returns the human-readable name for the FractionDefective goal.
+func (f FractionDefective) String() string {
+	return "Fraction Defective"
+}
+
+// AddPassFailResult records a trial of a pass/fail experiment: the number of defective
+// outcomes out of total, as one replicate. Replicates are weighted equally, so record
+// replicates of similar size. The experiment's goal must be FractionDefective.
+func (e *Experiment[P]) AddPassFailResult(trial Trial, defects, total int) error {
+	if _, ok := e.Goal.(FractionDefective); !ok {
+		return fmt.Errorf("optimization goal %s is not fraction defective", e.Goal)
+	}
+	if total <= 0 || defects < 0 || defects > total {
+		return fmt.Errorf("trial %d: %d defects out of %d outcomes", trial.ID, defects, total)
+	}
+	e.AddResult(trial, []float64{float64(defects) / float64(total)})
+	return nil
+}
@@ -0,0 +1,126 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// DesignIssue is a common design-of-experiments mistake found by CheckDesign.
+// Check: Identifier of the check, e.g. "duplicate-factor".
+// Factors: The factors involved, if any.
+// Message: What is wrong.
+// Fix: A suggested fix.
+type DesignIssue struct {
+	Check   string
+	Factors []string
+	Message string
+	Fix     string
+}
+
+// String returns the issue and its fix as one line.
+func (d DesignIssue) String() string {
+	return fmt.Sprintf("%s: %s (fix: %s)", d.Check, d.Message, d.Fix)
+}
+
+// controllableNoiseWords are name fragments of settings that are normally under the
+// experimenter's control and so should not be noise factors.
+var controllableNoiseWords = []string{
+	"worker", "thread", "gomaxprocs", "gogc", "pool", "batch", "buffer", "timeout",
+	"concurrency", "parallelism", "flag", "algorithm", "cachesize", "retries",
+}
+
+// CheckDesign statically checks the experiment definition for common mistakes, before
+// any runs are spent:
+//   - duplicate-factor: two control factors that look like the same knob;
+//   - linear-span: three or more levels spanning two or more orders of magnitude with
+//     equal steps, which crowds most levels at the top of the range;
+//   - controllable-noise: a noise factor that looks like a setting under your control;
+//   - saturated-unreplicated: every array column holds a factor and there is a single
+//     noise condition, leaving nothing to estimate error from.
+func (e *Experiment[P]) CheckDesign() []DesignIssue {
+	var issues []DesignIssue
+	for i, a := range e.ControlFactors {
+		for _, b := range e.ControlFactors[i+1:] {
+			na, nb := normalizeName(a.Name), normalizeName(b.Name)
+			sameLevels := slices.Equal(a.Levels, b.Levels)
+			if na == nb || sameLevels && (strings.Contains(na, nb) || strings.Contains(nb, na)) {
+				issues = append(issues, DesignIssue{
+					Check:   "duplicate-factor",
+					Factors: []string{a.Name, b.Name},
+					Message: fmt.Sprintf("control factors %s and %s look like the same knob", a.Name, b.Name),
+					Fix:     "keep one of them, or combine them into a single factor",
+				})
+			}
+		}
+	}
+
+	for _, f := range e.ControlFactors {
+		if linearSpan(f.Levels) {
+			issues = append(issues, DesignIssue{
+				Check:   "linear-span",
+				Factors: []string{f.Name},
+				Message: fmt.Sprintf("levels of %s span orders of magnitude with equal steps: %v", f.Name, f.Levels),
+				Fix:     "space the levels geometrically, e.g. 1, 10, 100",
+			})
+		}
+	}
+
+	for _, n := range e.NoiseFactors {
+		name := normalizeName(n.Name)
+		controllable := slices.ContainsFunc(e.ControlFactors, func(c ControlFactor) bool { return normalizeName(c.Name) == name }) ||
+			slices.ContainsFunc(controllableNoiseWords, func(w string) bool { return strings.Contains(name, w) })
+		if controllable {
+			issues = append(issues, DesignIssue{
+				Check:   "controllable-noise",
+				Factors: []string{n.Name},
+				Message: fmt.Sprintf("noise factor %s looks like a setting you control", n.Name),
+				Fix:     "make it a control factor, or record it as a covariate if it only drifts",
+			})
+		}
+	}
+
+	if oa := e.array(); oa.Columns() == len(e.ControlFactors) && e.noiseCombinationCount() == 1 {
+		issues = append(issues, DesignIssue{
+			Check:   "saturated-unreplicated",
+			Message: "every array column holds a factor and there is a single noise condition, so no error variance can be estimated",
+			Fix:     "leave a column free, add noise conditions, or repeat each trial",
+		})
+	}
+	return issues
+}
+
+// normalizeName lowercases name and drops everything but letters and digits, so that
+// "MaxWorkers" and "max_workers" compare equal.
+func normalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
+
+// linearSpan reports whether three or more positive levels span at least two orders of
+// magnitude with (nearly) equal steps.
+func linearSpan(levels []float64) bool {
+	if len(levels) < 3 {
+		return false
+	}
+	sorted := slices.Clone(levels)
+	slices.Sort(sorted)
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo <= 0 || hi/lo < 100 {
+		return false
+	}
+	step := (hi - lo) / float64(len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		if math.Abs(sorted[i]-sorted[i-1]-step) > 0.1*step {
+			return false
+		}
+	}
+	return true
+}
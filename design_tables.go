@@ -0,0 +1,72 @@
+package taguchi
+
+import "math"
+
+// DesignTables holds the design matrix of an analyzed experiment, one row per array row,
+// in three unit systems, each followed by the row's responses.
+// Columns: Column headers: the control factor names, then "Mean" and "SNR".
+// Levels: Level numbers 1..k, as in the orthogonal array.
+// Coded: Levels coded evenly from -1 (first level) to +1 (last level).
+// Actual: Level values in the factors' own units.
+// Every row ends with the mean observation and the SNR of the row; both are NaN for
+// rows without results.
+type DesignTables struct {
+	Columns []string
+	Levels  [][]float64
+	Coded   [][]float64
+	Actual  [][]float64
+}
+
+// DesignTables returns the design matrix in level numbers, coded and actual units, with
+// the responses appended: the appendix tables reviewers usually ask for. It is empty for
+// results not produced by an experiment's analysis.
+func (r AnalysisResult) DesignTables() DesignTables {
+	if r.designTables == nil {
+		return DesignTables{}
+	}
+	return r.designTables()
+}
+
+func (e *Experiment[P]) designTables(oaSNR []float64) DesignTables {
+	oa := e.array()
+	rowResults := e.rowResults()
+	t := DesignTables{
+		Columns: make([]string, 0, len(e.ControlFactors)+2),
+		Levels:  make([][]float64, oa.Rows()),
+		Coded:   make([][]float64, oa.Rows()),
+		Actual:  make([][]float64, oa.Rows()),
+	}
+	for _, factor := range e.ControlFactors {
+		t.Columns = append(t.Columns, factor.Name)
+	}
+	t.Columns = append(t.Columns, "Mean", "SNR")
+
+	for i := 0; i < oa.Rows(); i++ {
+		row := oa.Row(i)
+		levels := make([]float64, 0, len(t.Columns))
+		coded := make([]float64, 0, len(t.Columns))
+		actual := make([]float64, 0, len(t.Columns))
+		for j, factor := range e.ControlFactors {
+			l := row[j]
+			levels = append(levels, float64(l))
+			coded = append(coded, -1+2*float64(l-1)/float64(max(len(factor.Levels)-1, 1)))
+			actual = append(actual, factor.Levels[l-1])
+		}
+
+		sum, n := 0.0, 0
+		for _, k := range rowResults[i] {
+			for _, y := range e.Results[k].Observations {
+				sum += y
+				n++
+			}
+		}
+		meanY, snr := math.NaN(), math.NaN()
+		if n > 0 {
+			meanY, snr = sum/float64(n), oaSNR[i]
+		}
+		t.Levels[i] = append(levels, meanY, snr)
+		t.Coded[i] = append(coded, meanY, snr)
+		t.Actual[i] = append(actual, meanY, snr)
+	}
+	return t
+}
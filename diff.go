@@ -0,0 +1,174 @@
+package taguchi
+
+// LevelChange records the levels of a factor before and after a design change.
+type LevelChange struct {
+	Before []float64
+	After  []float64
+}
+
+// DesignDiff describes the differences between two experiment definitions.
+// AddedFactors / RemovedFactors: Control factors present in only one design.
+// ChangedFactors: Control factors present in both designs whose levels differ.
+// AddedNoise / RemovedNoise: Noise factors present in only one design.
+// ChangedNoise: Noise factors present in both designs whose levels differ.
+// GoalChanged: Whether the optimization goal differs.
+// ArrayChanged: Whether the orthogonal array differs.
+// RowsBefore / RowsAfter: Number of orthogonal array rows in each design.
+type DesignDiff struct {
+	AddedFactors   []string
+	RemovedFactors []string
+	ChangedFactors map[string]LevelChange
+	AddedNoise     []string
+	RemovedNoise   []string
+	ChangedNoise   map[string]LevelChange
+	GoalChanged    bool
+	ArrayChanged   bool
+	RowsBefore     int
+	RowsAfter      int
+}
+
+// Empty reports whether the two compared designs are identical.
+func (d DesignDiff) Empty() bool {
+	return len(d.AddedFactors) == 0 &&
+		len(d.RemovedFactors) == 0 &&
+		len(d.ChangedFactors) == 0 &&
+		len(d.AddedNoise) == 0 &&
+		len(d.RemovedNoise) == 0 &&
+		len(d.ChangedNoise) == 0 &&
+		!d.GoalChanged &&
+		!d.ArrayChanged
+}
+
+// DiffDesigns compares two experiment definitions, a being the prior design and b the new one.
+// Only the design is compared; recorded results are ignored.
+func DiffDesigns[P any, Q any](a *Experiment[P], b *Experiment[Q]) DesignDiff {
+	diff := DesignDiff{
+		ChangedFactors: map[string]LevelChange{},
+		ChangedNoise:   map[string]LevelChange{},
+		RowsBefore:     a.array().Rows(),
+		RowsAfter:      b.array().Rows(),
+	}
+
+	before := make(map[string][]float64, len(a.ControlFactors))
+	for _, f := range a.ControlFactors {
+		before[f.Name] = f.Levels
+	}
+	after := make(map[string][]float64, len(b.ControlFactors))
+	for _, f := range b.ControlFactors {
+		after[f.Name] = f.Levels
+	}
+	diff.AddedFactors, diff.RemovedFactors = diffLevels(a.controlNames(), b.controlNames(), before, after, diff.ChangedFactors)
+
+	before = make(map[string][]float64, len(a.NoiseFactors))
+	for _, f := range a.NoiseFactors {
+		before[f.Name] = f.Levels
+	}
+	after = make(map[string][]float64, len(b.NoiseFactors))
+	for _, f := range b.NoiseFactors {
+		after[f.Name] = f.Levels
+	}
+	diff.AddedNoise, diff.RemovedNoise = diffLevels(a.noiseNames(), b.noiseNames(), before, after, diff.ChangedNoise)
+
+	diff.GoalChanged = goalName(a.Goal) != goalName(b.Goal) || !sameGoalValue(a.Goal, b.Goal)
+	diff.ArrayChanged = !equalArrays(a.array(), b.array())
+
+	return diff
+}
+
+// diffLevels compares two ordered name lists and their levels, filling changed with
+// the factors present in both whose levels differ. Returns the added and removed names.
+func diffLevels(namesA, namesB []string, before, after map[string][]float64, changed map[string]LevelChange) ([]string, []string) {
+	var added, removed []string
+	for _, name := range namesA {
+		levels, ok := after[name]
+		if !ok {
+			removed = append(removed, name)
+			continue
+		}
+		if !equalLevels(before[name], levels) {
+			changed[name] = LevelChange{Before: before[name], After: levels}
+		}
+	}
+	for _, name := range namesB {
+		if _, ok := before[name]; !ok {
+			added = append(added, name)
+		}
+	}
+	return added, removed
+}
+
+// controlNames returns the control factor names in declaration order.
+func (e *Experiment[P]) controlNames() []string {
+	names := make([]string, len(e.ControlFactors))
+	for i, f := range e.ControlFactors {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// noiseNames returns the noise factor names in declaration order.
+func (e *Experiment[P]) noiseNames() []string {
+	names := make([]string, len(e.NoiseFactors))
+	for i, f := range e.NoiseFactors {
+		names[i] = f.Name
+	}
+	return names
+}
+
+func goalName(g OptimizationGoal) string {
+	if g == nil {
+		return ""
+	}
+	return g.String()
+}
+
+// sameGoalValue reports whether two goals with the same name carry the same parameters,
+// by comparing their serialized forms. Of goals that cannot be serialized, a GoalFunc
+// is the same only as itself, since its formula cannot be compared, and other custom
+// goal types are compared by name.
+func sameGoalValue(a, b OptimizationGoal) bool {
+	sa, errA := encodeGoal(a)
+	sb, errB := encodeGoal(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sa.Type == sb.Type && sa.Target == sb.Target && sa.Percentile == sb.Percentile && equalLevels(sa.Signals, sb.Signals)
+	case errA == nil || errB == nil:
+		return false
+	}
+	fa, aFunc := a.(*goalFunc)
+	fb, bFunc := b.(*goalFunc)
+	if aFunc || bFunc {
+		return fa == fb
+	}
+	return true
+}
+
+func equalLevels(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalArrays(a, b ArraySource) bool {
+	if a.Rows() != b.Rows() {
+		return false
+	}
+	for i := 0; i < a.Rows(); i++ {
+		ra, rb := a.Row(i), b.Row(i)
+		if len(ra) != len(rb) {
+			return false
+		}
+		for j := range ra {
+			if ra[j] != rb[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
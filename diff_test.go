@@ -0,0 +1,161 @@
+package taguchi
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestDiffDesigns verifies that DiffDesigns reports changes to control factors, their
+// levels, noise factors, the goal and the orthogonal array, and nothing else.
+func TestDiffDesigns(t *testing.T) {
+	base := func() ([]ControlFactor, []NoiseFactor) {
+		return []ControlFactor{
+			{Name: "A", Levels: []float64{1, 2}},
+			{Name: "B", Levels: []float64{1, 2}},
+		}, []NoiseFactor{
+			{Name: "N", Levels: []float64{0, 1}},
+		}
+	}
+	build := func(t *testing.T, goal OptimizationGoal, array ArrayType, control []ControlFactor, noise []NoiseFactor) *Experiment[struct{}] {
+		t.Helper()
+		exp, err := NewExperimentFromFactors(goal, control, array, noise)
+		if err != nil {
+			t.Fatalf("NewExperimentFromFactors: %v", err)
+		}
+		return exp
+	}
+	control, noise := base()
+	before := build(t, SmallerTheBetter{}, L4, control, noise)
+
+	tests := []struct {
+		name  string
+		after func(t *testing.T) *Experiment[struct{}]
+		check func(t *testing.T, d DesignDiff)
+	}{
+		{
+			name: "identical",
+			after: func(t *testing.T) *Experiment[struct{}] {
+				control, noise := base()
+				return build(t, SmallerTheBetter{}, L4, control, noise)
+			},
+			check: func(t *testing.T, d DesignDiff) {
+				if !d.Empty() {
+					t.Errorf("got %+v, want an empty diff", d)
+				}
+			},
+		},
+		{
+			name: "added and removed control factors",
+			after: func(t *testing.T) *Experiment[struct{}] {
+				_, noise := base()
+				control := []ControlFactor{
+					{Name: "A", Levels: []float64{1, 2}},
+					{Name: "C", Levels: []float64{1, 2}},
+				}
+				return build(t, SmallerTheBetter{}, L4, control, noise)
+			},
+			check: func(t *testing.T, d DesignDiff) {
+				if !slices.Equal(d.AddedFactors, []string{"C"}) || !slices.Equal(d.RemovedFactors, []string{"B"}) {
+					t.Errorf("added %v, removed %v; want [C], [B]", d.AddedFactors, d.RemovedFactors)
+				}
+				if len(d.ChangedFactors) != 0 || d.ArrayChanged {
+					t.Errorf("unexpected changes: %+v", d)
+				}
+			},
+		},
+		{
+			name: "changed levels",
+			after: func(t *testing.T) *Experiment[struct{}] {
+				control, noise := base()
+				control[1].Levels = []float64{1, 4}
+				return build(t, SmallerTheBetter{}, L4, control, noise)
+			},
+			check: func(t *testing.T, d DesignDiff) {
+				change, ok := d.ChangedFactors["B"]
+				if !ok || len(d.ChangedFactors) != 1 {
+					t.Fatalf("ChangedFactors: got %v, want only B", d.ChangedFactors)
+				}
+				if !slices.Equal(change.Before, []float64{1, 2}) || !slices.Equal(change.After, []float64{1, 4}) {
+					t.Errorf("B: got %+v", change)
+				}
+			},
+		},
+		{
+			name: "larger array",
+			after: func(t *testing.T) *Experiment[struct{}] {
+				control, noise := base()
+				return build(t, SmallerTheBetter{}, L8, control, noise)
+			},
+			check: func(t *testing.T, d DesignDiff) {
+				if !d.ArrayChanged || d.RowsBefore != 4 || d.RowsAfter != 8 {
+					t.Errorf("array: changed %v, rows %d -> %d; want true, 4 -> 8", d.ArrayChanged, d.RowsBefore, d.RowsAfter)
+				}
+			},
+		},
+		{
+			name: "noise structure",
+			after: func(t *testing.T) *Experiment[struct{}] {
+				control, _ := base()
+				noise := []NoiseFactor{
+					{Name: "N", Levels: []float64{0, 1, 2}},
+					{Name: "M", Levels: []float64{0, 1}},
+				}
+				return build(t, SmallerTheBetter{}, L4, control, noise)
+			},
+			check: func(t *testing.T, d DesignDiff) {
+				if !slices.Equal(d.AddedNoise, []string{"M"}) || len(d.RemovedNoise) != 0 {
+					t.Errorf("noise added %v, removed %v; want [M], []", d.AddedNoise, d.RemovedNoise)
+				}
+				if change := d.ChangedNoise["N"]; !slices.Equal(change.After, []float64{0, 1, 2}) {
+					t.Errorf("N: got %+v", change)
+				}
+				if d.ArrayChanged {
+					t.Error("noise changes reported as an array change")
+				}
+			},
+		},
+		{
+			name: "goal target",
+			after: func(t *testing.T) *Experiment[struct{}] {
+				control, noise := base()
+				return build(t, NominalTheBest{Target: 3}, L4, control, noise)
+			},
+			check: func(t *testing.T, d DesignDiff) {
+				if !d.GoalChanged {
+					t.Error("goal change not reported")
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, DiffDesigns(before, tt.after(t)))
+		})
+	}
+
+	nominal := build(t, NominalTheBest{Target: 3}, L4, control, noise)
+	if d := DiffDesigns(nominal, build(t, NominalTheBest{Target: 5}, L4, control, noise)); !d.GoalChanged {
+		t.Error("NominalTheBest target change not reported")
+	}
+
+	p99 := func(target float64) OptimizationGoal {
+		return PercentileGoal{Goal: NominalTheBest{Target: target}, Percentile: 99}
+	}
+	percentile := build(t, p99(3), L4, control, noise)
+	if d := DiffDesigns(percentile, build(t, p99(3), L4, control, noise)); d.GoalChanged {
+		t.Error("identical percentile goals reported as changed")
+	}
+	if d := DiffDesigns(percentile, build(t, p99(5), L4, control, noise)); !d.GoalChanged {
+		t.Error("target change of a percentile goal not reported")
+	}
+
+	mean := GoalFunc("custom", func(obs []float64) float64 { return -mean(obs) })
+	worst := GoalFunc("custom", func(obs []float64) float64 { return -slices.Max(obs) })
+	custom := build(t, mean, L4, control, noise)
+	if d := DiffDesigns(custom, build(t, mean, L4, control, noise)); d.GoalChanged {
+		t.Error("the same GoalFunc reported as changed")
+	}
+	if d := DiffDesigns(custom, build(t, worst, L4, control, noise)); !d.GoalChanged {
+		t.Error("GoalFuncs with the same name but different formulas reported as unchanged")
+	}
+}
@@ -0,0 +1,179 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// dOptimalStarts is the number of random starting designs GenerateDOptimal improves.
+const dOptimalStarts = 10
+
+// GenerateDOptimal computes a design with the given number of runs for factor
+// structures no standard array fits, e.g. 7 runs for a 2×3×3 problem. Starting from
+// random designs drawn from seed, a coordinate-exchange search changes one cell at a
+// time whenever that increases det(X'X) of the main-effects model, until no change
+// helps; the best design found is returned. runs must be at least the number of model
+// parameters, 1 + Σ(levels - 1).
+//
+// D-optimal designs are generally not orthogonal, so pass the result to
+// NewExperimentFromFactorsUsingDesign, which analyzes by regression; when the search
+// does return an orthogonal array, NewExperimentUsingArray accepts it as well.
+func GenerateDOptimal(factors []ControlFactor, runs int, seed int64) ([][]int, error) {
+	if len(factors) == 0 {
+		return nil, fmt.Errorf("D-optimal design needs at least 1 factor")
+	}
+	params := 1
+	for _, f := range factors {
+		if len(f.Levels) < 2 {
+			return nil, fmt.Errorf("factor %s needs at least 2 levels, got %d", f.Name, len(f.Levels))
+		}
+		params += len(f.Levels) - 1
+	}
+	if runs < params {
+		return nil, fmt.Errorf("D-optimal design for %d model parameters needs at least %d runs, got %d", params, params, runs)
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	var best [][]int
+	bestRank, bestLogDet := -1, math.Inf(-1)
+	for start := 0; start < dOptimalStarts; start++ {
+		design := make([][]int, runs)
+		for i := range design {
+			design[i] = make([]int, len(factors))
+			for j, f := range factors {
+				design[i][j] = 1 + rng.Intn(len(f.Levels))
+			}
+		}
+		rank, logDet := coordinateExchange(factors, design)
+		if rank > bestRank || rank == bestRank && logDet > bestLogDet {
+			best, bestRank, bestLogDet = design, rank, logDet
+		}
+	}
+	if bestRank < params {
+		return nil, fmt.Errorf("no design with %d runs estimates all %d model parameters", runs, params)
+	}
+	return best, nil
+}
+
+// coordinateExchange improves design in place and returns the rank and log determinant
+// of its information matrix X'X.
+func coordinateExchange(factors []ControlFactor, design [][]int) (int, float64) {
+	info, p := informationMatrix(factors, design)
+	rank, logDet := logDeterminant(info)
+
+	for improved := true; improved; {
+		improved = false
+		for _, row := range design {
+			for j, f := range factors {
+				current := row[j]
+				old := modelRow(factors, row, p)
+				for l := 1; l <= len(f.Levels); l++ {
+					if l == row[j] {
+						continue
+					}
+					prev := row[j]
+					row[j] = l
+					candidate := modelRow(factors, row, p)
+					addOuter(info, old, -1)
+					addOuter(info, candidate, 1)
+					r, d := logDeterminant(info)
+					// Require a minimum gain so rounding noise cannot cycle forever.
+					if r > rank || r == rank && d > logDet+1e-9 {
+						rank, logDet, old = r, d, candidate
+						continue
+					}
+					addOuter(info, candidate, -1)
+					addOuter(info, old, 1)
+					row[j] = prev
+				}
+				if row[j] != current {
+					improved = true
+				}
+			}
+		}
+	}
+	return rank, logDet
+}
+
+// informationMatrix returns X'X of the main-effects model of design and the number of
+// model parameters p.
+func informationMatrix(factors []ControlFactor, design [][]int) ([][]float64, int) {
+	p := 1
+	for _, f := range factors {
+		p += len(f.Levels) - 1
+	}
+	info := make([][]float64, p)
+	for a := range info {
+		info[a] = make([]float64, p)
+	}
+	for _, row := range design {
+		addOuter(info, modelRow(factors, row, p), 1)
+	}
+	return info, p
+}
+
+// modelRow returns the main-effects model row of a design row: an intercept followed by
+// one indicator per factor level after the first.
+func modelRow(factors []ControlFactor, row []int, p int) []float64 {
+	x := make([]float64, 1, p)
+	x[0] = 1
+	for j, f := range factors {
+		for l := 2; l <= len(f.Levels); l++ {
+			if row[j] == l {
+				x = append(x, 1)
+			} else {
+				x = append(x, 0)
+			}
+		}
+	}
+	return x
+}
+
+// addOuter adds sign·x·x' to m.
+func addOuter(m [][]float64, x []float64, sign float64) {
+	for a, xa := range x {
+		if xa == 0 {
+			continue
+		}
+		for b, xb := range x {
+			m[a][b] += sign * xa * xb
+		}
+	}
+}
+
+// logDeterminant returns the numerical rank of the symmetric matrix m and the log of
+// the product of its nonzero pivots, which is log det(m) when m has full rank.
+func logDeterminant(m [][]float64) (int, float64) {
+	n := len(m)
+	a := make([][]float64, n)
+	tol := 0.0
+	for i := range m {
+		a[i] = append([]float64(nil), m[i]...)
+		tol = math.Max(tol, math.Abs(m[i][i]))
+	}
+	tol *= 1e-10
+
+	rank, logDet := 0, 0.0
+	for col := 0; col < n && rank < n; col++ {
+		best := rank
+		for r := rank + 1; r < n; r++ {
+			if math.Abs(a[r][col]) > math.Abs(a[best][col]) {
+				best = r
+			}
+		}
+		if math.Abs(a[best][col]) <= tol {
+			continue
+		}
+		a[rank], a[best] = a[best], a[rank]
+		for r := rank + 1; r < n; r++ {
+			f := a[r][col] / a[rank][col]
+			for c := col; c < n; c++ {
+				a[r][c] -= f * a[rank][c]
+			}
+		}
+		logDet += math.Log(math.Abs(a[rank][col]))
+		rank++
+	}
+	return rank, logDet
+}
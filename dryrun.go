@@ -0,0 +1,27 @@
+package taguchi
+
+import "math/rand"
+
+// dryRunSeed seeds the measurement noise of DryRun so that dry runs are reproducible.
+const dryRunSeed = 1
+
+// DryRun simulates the experiment against a ground-truth model before any real
+// measurement is taken. Every generated trial is "measured" once as
+// model(params, settings, noise) plus Gaussian measurement noise with standard
+// deviation noiseStd, and the simulated results are analyzed. The experiment's own
+// results are left untouched. Settings holds the trial's control settings keyed by
+// factor name (see Settings); experiments built without a params struct, e.g. by
+// NewExperimentFromFactors, get the zero P and should read them instead.
+//
+// Comparing the returned optimal levels and contributions with the model lets users
+// check that the chosen factors, levels and array can detect the effects they expect.
+func (e *Experiment[P]) DryRun(model func(params P, settings, noise map[string]float64) float64, noiseStd float64) AnalysisResult {
+	rng := rand.New(rand.NewSource(dryRunSeed))
+	sim := *e
+	sim.Results = nil
+	for _, trial := range e.GenerateTrials() {
+		y := model(e.Params(trial), e.Settings(trial), trial.Noise) + rng.NormFloat64()*noiseStd
+		sim.AddResult(trial, []float64{y})
+	}
+	return sim.Analyze()
+}
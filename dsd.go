@@ -0,0 +1,263 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// MethodDefinitiveScreening is the analysis method reported for definitive screening
+// designs.
+const MethodDefinitiveScreening = "Definitive screening (stepwise quadratic regression on SNR)"
+
+// maxDefinitiveScreeningFactors bounds the search for a conference matrix.
+const maxDefinitiveScreeningFactors = 100
+
+// GenerateDefinitiveScreening returns a three-level definitive screening design (Jones &
+// Nachtsheim, 2011) for nFactors factors: the rows of a conference matrix C, of -C and
+// a center run, with levels 1, 2 and 3 for low, center and high. Main effects are
+// orthogonal to each other and to every quadratic and two-factor interaction effect,
+// and quadratic effects stay estimable, in 2m+1 runs where m is the order of C.
+// C comes from Paley's construction and m-1 must be prime, so m is the smallest such
+// even order of at least nFactors and surplus columns are dropped: 4 factors take 9
+// runs and 10 factors take 25.
+func GenerateDefinitiveScreening(nFactors int) ([][]int, error) {
+	if nFactors < 2 || nFactors > maxDefinitiveScreeningFactors {
+		return nil, fmt.Errorf("definitive screening design supports 2 to %d factors, got %d", maxDefinitiveScreeningFactors, nFactors)
+	}
+	m := max(nFactors+nFactors%2, 4)
+	for !isPrime(m - 1) {
+		m += 2
+	}
+	c := conferenceMatrix(m)
+
+	level := func(v int) int { return v + 2 }
+	design := make([][]int, 0, 2*m+1)
+	for _, sign := range []int{1, -1} {
+		for _, row := range c {
+			r := make([]int, nFactors)
+			for j := range r {
+				r[j] = level(sign * row[j])
+			}
+			design = append(design, r)
+		}
+	}
+	center := make([]int, nFactors)
+	for j := range center {
+		center[j] = level(0)
+	}
+	return append(design, center), nil
+}
+
+// conferenceMatrix returns a conference matrix of order m, with zero diagonal, ±1
+// elsewhere and C'C = (m-1)I, for a prime m-1: C = [[0, 1ᵀ], [±1, Q]] with the
+// Jacobsthal matrix Q, symmetric for m-1 ≡ 1 (mod 4) and antisymmetric otherwise.
+func conferenceMatrix(m int) [][]int {
+	q := m - 1
+	jq := jacobsthal(q)
+	sign := 1
+	if q%4 == 3 {
+		sign = -1
+	}
+	c := make([][]int, m)
+	for i := range c {
+		c[i] = make([]int, m)
+	}
+	for j := 1; j < m; j++ {
+		c[0][j] = 1
+		c[j][0] = sign
+	}
+	for i := 0; i < q; i++ {
+		for j := 0; j < q; j++ {
+			c[i+1][j+1] = jq[i][j]
+		}
+	}
+	return c
+}
+
+// NewDefinitiveScreeningExperiment initializes an experiment on GenerateDefinitiveScreening's
+// design for three-level control factors, whose levels should be evenly spaced. Its
+// Analyze fits a quadratic model by forward selection (see MethodDefinitiveScreening).
+func NewDefinitiveScreeningExperiment(goal OptimizationGoal, controlFactors []ControlFactor, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	design, err := GenerateDefinitiveScreening(len(controlFactors))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLevelRules(controlFactors); err != nil {
+		return nil, err
+	}
+	spec, err := NewArraySpec(design)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
+	}
+	return &Experiment[struct{}]{
+		ControlFactors:  controlFactors,
+		NoiseFactors:    noiseFactors,
+		Goal:            goal,
+		OrthogonalArray: design,
+		design:          designDefinitiveScreening,
+	}, nil
+}
+
+// analyzeQuadratic fits the row SNRs of a three-level response surface design, such as a
+// definitive screening, Box–Behnken or central composite design, with a second-order
+// model on the levels coded from -1 to +1 (see quadraticCode), selecting terms by
+// forward selection at forwardSelectionAlpha:
+// linear and quadratic terms of every factor, and the two-factor interactions of
+// factors whose linear term has entered. MainEffects hold the fitted SNR at each level
+// with the other factors at their mean, so they show curvature; a factor's SS is that of
+// its linear and quadratic terms. Selected lists the entered terms, e.g. "A", "A²" and
+// "A×B".
+func (e *Experiment[P]) analyzeQuadratic(method string) AnalysisResult {
+	oaSNR, _ := e.computeOASNR()
+	oa := e.array()
+	rowResults := e.rowResults()
+	k := len(e.ControlFactors)
+
+	// Terms are the k linear terms, the k quadratic terms, then the interactions.
+	names := make([]string, 0, 2*k+k*(k-1)/2)
+	var pairs [][2]int
+	for _, f := range e.ControlFactors {
+		names = append(names, f.Name)
+	}
+	for _, f := range e.ControlFactors {
+		names = append(names, f.Name+"²")
+	}
+	for a := 0; a < k; a++ {
+		for b := a + 1; b < k; b++ {
+			names = append(names, e.ControlFactors[a].Name+"×"+e.ControlFactors[b].Name)
+			pairs = append(pairs, [2]int{a, b})
+		}
+	}
+	terms := func(x []float64) []float64 {
+		z := make([]float64, 0, len(names))
+		z = append(z, x...)
+		for _, v := range x {
+			z = append(z, v*v)
+		}
+		for _, p := range pairs {
+			z = append(z, x[p[0]]*x[p[1]])
+		}
+		return z
+	}
+
+	var zs [][]float64
+	var ys []float64
+	for i := 0; i < oa.Rows(); i++ {
+		if len(rowResults[i]) == 0 {
+			continue
+		}
+		x := make([]float64, k)
+		for j := range x {
+			x[j] = e.quadraticCode(j, oa.Row(i)[j]-1)
+		}
+		zs = append(zs, terms(x))
+		ys = append(ys, oaSNR[i])
+	}
+
+	groups := make([][]int, len(names))
+	for t := range groups {
+		groups[t] = []int{t}
+	}
+	entered, order := forwardSelect(zs, ys, groups, func(entered []bool, t int) bool {
+		if t < 2*k {
+			return true
+		}
+		p := pairs[t-2*k]
+		return entered[p[0]] || entered[p[1]]
+	}, forwardSelectionAlpha)
+	fitted := order
+	var selected []string
+	for _, t := range order {
+		selected = append(selected, names[t])
+	}
+	coef, means, errorSS := leastSquares(zs, ys, len(names), fitted)
+	yMean := 0.0
+	if len(ys) > 0 {
+		yMean = mean(ys)
+	}
+
+	anova := ANOVAResult{
+		FactorSS: make(map[string]float64, k),
+		FactorDF: make(map[string]int, k),
+		FactorMS: make(map[string]float64, k),
+		FactorF:  make(map[string]float64, k),
+	}
+	anova.ErrorSS = errorSS
+	anova.ErrorDF = max(len(zs)-1-len(fitted), 1)
+	anova.ErrorMS = errorSS / float64(anova.ErrorDF)
+
+	mainEffects := make(map[string][]float64, k)
+	snrPerFactor := make(map[string][]float64, k)
+	for j, factor := range e.ControlFactors {
+		own := slices.DeleteFunc([]int{j, k + j}, func(t int) bool { return !entered[t] })
+		if len(own) > 0 {
+			rest := slices.DeleteFunc(slices.Clone(fitted), func(t int) bool { return slices.Contains(own, t) })
+			_, _, reducedSS := leastSquares(zs, ys, len(names), rest)
+			ss := math.Max(reducedSS-errorSS, 0)
+			anova.FactorSS[factor.Name] = ss
+			anova.FactorMS[factor.Name] = ss / float64(len(own))
+			anova.FactorF[factor.Name] = anova.FactorMS[factor.Name] / anova.ErrorMS
+		} else {
+			anova.FactorSS[factor.Name] = 0
+			anova.FactorMS[factor.Name] = 0
+			anova.FactorF[factor.Name] = 0
+		}
+		anova.FactorDF[factor.Name] = len(own)
+
+		// Terms without the factor are constant here, so only its own terms vary.
+		effects := make([]float64, len(factor.Levels))
+		for l := range factor.Levels {
+			x := make([]float64, k)
+			for b := range x {
+				x[b] = means[b]
+			}
+			x[j] = e.quadraticCode(j, l)
+			z := terms(x)
+			effects[l] = yMean
+			for _, t := range fitted {
+				if t == j || t == k+j || t >= 2*k && slices.Contains(pairs[t-2*k][:], j) {
+					effects[l] += coef[t] * (z[t] - means[t])
+				}
+			}
+		}
+		mainEffects[factor.Name] = effects
+		snrPerFactor[factor.Name] = e.levelMeanSNR(j, oaSNR)
+	}
+
+	result := AnalysisResult{
+		Method:        method,
+		OptimalLevels: e.findOptimalLevels(mainEffects),
+		SNR:           snrPerFactor,
+		MainEffects:   mainEffects,
+		Contributions: computeContributions(anova),
+		ANOVA:         anova,
+		Selected:      selected,
+	}
+	result = e.runPasses(result, oaSNR)
+	if len(selected) == 0 {
+		result.Diagnostics = append(result.Diagnostics, fmt.Sprintf("Forward selection entered no term at the %g level.", forwardSelectionAlpha))
+	} else {
+		result.Diagnostics = append(result.Diagnostics, fmt.Sprintf("Forward selection entered %s at the %g level.", strings.Join(selected, ", "), forwardSelectionAlpha))
+	}
+	return result
+}
+
+// quadraticCode codes level l (0-based) of control factor j for analyzeQuadratic: evenly
+// from -1 to +1 by level number, or by level value for central composite designs, whose
+// levels are not evenly spaced. Factors held at one level are coded 0.
+func (e *Experiment[P]) quadraticCode(j, l int) float64 {
+	levels := e.ControlFactors[j].Levels
+	n := len(levels)
+	switch {
+	case n < 2:
+		return 0
+	case e.design == designCentralComposite:
+		return -1 + 2*(levels[l]-levels[0])/(levels[n-1]-levels[0])
+	}
+	return -1 + 2*float64(l)/float64(n-1)
+}
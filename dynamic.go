@@ -0,0 +1,95 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+)
+
+// DynamicCharacteristic is the goal of a dynamic (signal-response) experiment, where
+// the response should follow a signal factor proportionally, e.g. output throughput
+// following offered load. Each trial is measured at every signal level, and the SNR
+// 10·log10(β²/σ²) rewards a steep, consistent response: β is the slope of the
+// zero-point proportional fit y = β·M and σ² the error variance around it.
+// Signals: The signal factor levels M. A trial's observations are taken at them in
+// order and repeated per replicate, so observation i belongs to Signals[i % len(Signals)];
+// AddDynamicResult records them in that layout.
+type DynamicCharacteristic struct {
+	Signals []float64
+}
+
+// CalculateSNR computes the dynamic SNR, 10·log10(β²/σ²). It is NaN when the
+// observations do not cover the signal levels a whole number of times, or number fewer
+// than 2, and +Inf when they fit the line exactly.
+func (d DynamicCharacteristic) CalculateSNR(obs []float64) float64 {
+	beta, variance, ok := d.fit(obs)
+	if !ok {
+		return math.NaN()
+	}
+	if variance == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(beta*beta/variance)
+}
+
+// Slope returns β, the slope of the zero-point proportional fit of the observations to
+// the signal levels, or NaN if they do not cover the levels a whole number of times.
+// Once the SNR is maximized, factors that change β but not the SNR adjust the
+// sensitivity to the signal.
+func (d DynamicCharacteristic) Slope(obs []float64) float64 {
+	beta, _, ok := d.fit(obs)
+	if !ok {
+		return math.NaN()
+	}
+	return beta
+}
+
+// String returns the human-readable name for the DynamicCharacteristic goal.
+func (d DynamicCharacteristic) String() string {
+	return "Dynamic"
+}
+
+// fit returns the slope of the zero-point proportional fit and the error variance, with
+// n - 1 degrees of freedom.
+func (d DynamicCharacteristic) fit(obs []float64) (beta, variance float64, ok bool) {
+	k := len(d.Signals)
+	if k == 0 || len(obs) < 2 || len(obs)%k != 0 {
+		return 0, 0, false
+	}
+	sxy, sxx := 0.0, 0.0
+	for i, y := range obs {
+		m := d.Signals[i%k]
+		sxy += m * y
+		sxx += m * m
+	}
+	if sxx == 0 {
+		return 0, 0, false
+	}
+	beta = sxy / sxx
+	for i, y := range obs {
+		r := y - beta*d.Signals[i%k]
+		variance += r * r
+	}
+	return beta, variance / float64(len(obs)-1), true
+}
+
+// AddDynamicResult records a trial of a dynamic experiment: responses holds one slice
+// per replicate (e.g. noise condition) with the response at each of the goal's signal
+// levels, in order. The experiment's goal must be a DynamicCharacteristic.
+func (e *Experiment[P]) AddDynamicResult(trial Trial, responses ...[]float64) error {
+	goal, ok := e.Goal.(DynamicCharacteristic)
+	if !ok {
+		return fmt.Errorf("optimization goal %s is not a dynamic characteristic", e.Goal)
+	}
+	var observations []float64
+	for r, response := range responses {
+		if len(response) != len(goal.Signals) {
+			return fmt.Errorf("replicate %d of trial %d has %d responses for %d signal levels", r+1, trial.ID, len(response), len(goal.Signals))
+		}
+		observations = append(observations, response...)
+	}
+	if len(observations) == 0 {
+		return fmt.Errorf("trial %d has no responses", trial.ID)
+	}
+	e.AddResult(trial, observations)
+	return nil
+}
@@ -0,0 +1,124 @@
+package taguchi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EnergyMeter measures the energy consumed while a function runs, in joules.
+type EnergyMeter interface {
+	MeasureEnergy(fn func() error) (float64, error)
+}
+
+// RAPLMeter reads CPU package energy counters exposed by the Linux powercap framework
+// (Intel RAPL, also used for AMD). Reading the counters usually requires root.
+// Root: powercap sysfs directory; empty means /sys/class/powercap.
+type RAPLMeter struct {
+	Root string
+}
+
+// raplPackage matches top-level RAPL zones; sub-zones (intel-rapl:0:0) are part of
+// their package's count and are skipped to avoid double counting.
+var raplPackage = regexp.MustCompile(`^intel-rapl:\d+$`)
+
+// raplZone is one package energy counter.
+type raplZone struct {
+	dir      string
+	maxRange float64
+}
+
+// MeasureEnergy reads every package counter before and after fn and returns the total
+// energy in joules, correcting for counters that wrapped around in between.
+func (m RAPLMeter) MeasureEnergy(fn func() error) (float64, error) {
+	zones, err := m.zones()
+	if err != nil {
+		return 0, err
+	}
+	before := make([]float64, len(zones))
+	for i, z := range zones {
+		if before[i], err = readMicrojoules(filepath.Join(z.dir, "energy_uj")); err != nil {
+			return 0, err
+		}
+	}
+	if err := fn(); err != nil {
+		return 0, err
+	}
+	total := 0.0
+	for i, z := range zones {
+		after, err := readMicrojoules(filepath.Join(z.dir, "energy_uj"))
+		if err != nil {
+			return 0, err
+		}
+		delta := after - before[i]
+		if delta < 0 {
+			delta += z.maxRange
+		}
+		total += delta
+	}
+	return total / 1e6, nil
+}
+
+func (m RAPLMeter) zones() ([]raplZone, error) {
+	root := m.Root
+	if root == "" {
+		root = "/sys/class/powercap"
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, fmt.Errorf("read powercap zones: %w", err)
+	}
+	var zones []raplZone
+	for _, entry := range entries {
+		if !raplPackage.MatchString(entry.Name()) {
+			continue
+		}
+		dir := filepath.Join(root, entry.Name())
+		maxRange, err := readMicrojoules(filepath.Join(dir, "max_energy_range_uj"))
+		if err != nil {
+			return nil, err
+		}
+		zones = append(zones, raplZone{dir: dir, maxRange: maxRange})
+	}
+	if len(zones) == 0 {
+		return nil, fmt.Errorf("no RAPL package zones under %s", root)
+	}
+	return zones, nil
+}
+
+func readMicrojoules(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(string(data)), 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// EnergyPerOperation adapts a workload into a Scheduler.Run measurement function whose
+// single observation is the energy per operation in joules. work runs the trial and
+// returns the number of operations it completed. Use it with SmallerTheBetter to tune
+// for energy efficiency.
+func EnergyPerOperation(m EnergyMeter, work func(ScheduledTrial) (int, error)) func(ScheduledTrial) ([]float64, error) {
+	return func(st ScheduledTrial) ([]float64, error) {
+		var ops int
+		joules, err := m.MeasureEnergy(func() error {
+			var err error
+			ops, err = work(st)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		if ops <= 0 {
+			return nil, fmt.Errorf("workload completed %d operations", ops)
+		}
+		return []float64{joules / float64(ops)}, nil
+	}
+}
@@ -0,0 +1,35 @@
+package taguchi
+
+import "runtime"
+
+// Environment is a fingerprint of the machine a trial ran on, so that anomalous
+// results can be traced to environment differences.
+// GOMAXPROCS / NumCPU: Go scheduler parallelism and logical CPUs visible to the process.
+// OS / Arch / GoVersion: runtime.GOOS, runtime.GOARCH and runtime.Version().
+// CPUModel: Processor model name, if known.
+// CPUQuota: Container CPU limit in CPUs; 0 when unlimited or unknown.
+// MemoryLimit: Container memory limit in bytes; 0 when unlimited or unknown.
+type Environment struct {
+	GOMAXPROCS  int
+	NumCPU      int
+	OS          string
+	Arch        string
+	GoVersion   string
+	CPUModel    string  `json:",omitempty"`
+	CPUQuota    float64 `json:",omitempty"`
+	MemoryLimit int64   `json:",omitempty"`
+}
+
+// CaptureEnvironment returns the fingerprint of the current process's environment.
+// CPU model and container limits are read on Linux and left empty elsewhere.
+func CaptureEnvironment() Environment {
+	env := Environment{
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+		NumCPU:     runtime.NumCPU(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		GoVersion:  runtime.Version(),
+	}
+	platformEnvironment(&env)
+	return env
+}
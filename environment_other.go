@@ -0,0 +1,6 @@
+//go:build !linux
+
+package taguchi
+
+// platformEnvironment has nothing to add on this platform.
+func platformEnvironment(env *Environment) {}
@@ -11,10 +11,20 @@ import (
 
 const dataSize = 2_000_000
 
+// dataPatternNoise is the data pattern noise factor; decodeDataPattern reads it back from a trial.
+var dataPatternNoise, decodeDataPattern = taguchi.NoiseFromEnum("DataPattern",
+	Random, Sorted, ReverseSorted, ManyDuplicates, NearlySorted)
+
 type ExperimentFactors struct {
-	MaxWorkers []float64
-	Algorithm  []float64
-	GOMAXPROCS []float64
+	MaxWorkers []int
+	Algorithm  []SortAlgorithm
+	GOMAXPROCS []int
+}
+
+type ExperimentParams struct {
+	MaxWorkers int
+	Algorithm  SortAlgorithm
+	GOMAXPROCS int
 }
 
 func main() {
@@ -30,18 +40,16 @@ func main() {
 	taguchi.PrintAnalysisReport(results)
 }
 
-func createExperiment() (*taguchi.Experiment[ExperimentFactors], error) {
+func createExperiment() (*taguchi.Experiment[ExperimentParams], error) {
 	factors := ExperimentFactors{
-		MaxWorkers: []float64{1, 20},
-		Algorithm:  []float64{0, 1},
-		GOMAXPROCS: []float64{4, 8},
+		MaxWorkers: []int{1, 20},
+		Algorithm:  []SortAlgorithm{QuickSort, RadixSort},
+		GOMAXPROCS: []int{4, 8},
 	}
 
-	noise := []taguchi.NoiseFactor{
-		{Name: "DataPattern", Levels: []float64{0, 1, 2, 3, 4}},
-	}
+	noise := []taguchi.NoiseFactor{dataPatternNoise}
 
-	return taguchi.NewExperiment[ExperimentFactors, ExperimentFactors](
+	return taguchi.NewExperiment[ExperimentFactors, ExperimentParams](
 		&taguchi.SmallerTheBetter{},
 		factors,
 		"L4",
@@ -60,7 +68,7 @@ func prepareDatasets(size int) map[DataPattern][]int {
 	return datasets
 }
 
-func runExperiment(exp *taguchi.Experiment[ExperimentFactors], datasets map[DataPattern][]int) {
+func runExperiment(exp *taguchi.Experiment[ExperimentParams], datasets map[DataPattern][]int) {
 	for _, trial := range exp.GenerateTrials() {
 		tc := trialConfig{trial: trial, datasets: datasets}
 		runTrial(exp, tc)
@@ -72,12 +80,13 @@ type trialConfig struct {
 	datasets map[DataPattern][]int
 }
 
-func runTrial(exp *taguchi.Experiment[ExperimentFactors], tc trialConfig) {
-	runtime.GOMAXPROCS(int(tc.trial.Control["GOMAXPROCS"]))
+func runTrial(exp *taguchi.Experiment[ExperimentParams], tc trialConfig) {
+	params := exp.Params(tc.trial)
+	runtime.GOMAXPROCS(params.GOMAXPROCS)
 
-	workers := int(tc.trial.Control["MaxWorkers"])
-	alg := SortAlgorithm(tc.trial.Control["Algorithm"])
-	pattern := DataPattern(tc.trial.Noise["DataPattern"])
+	workers := params.MaxWorkers
+	alg := params.Algorithm
+	pattern := decodeDataPattern(tc.trial)
 
 	data := make([]int, dataSize)
 	copy(data, tc.datasets[pattern])
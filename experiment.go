@@ -1,6 +1,8 @@
 package taguchi
 
 import (
+	"encoding/binary"
+	"errors"
 	"fmt"
 )
 
@@ -12,33 +14,51 @@ func NewExperiment[F any, P any](goal OptimizationGoal, factors F, arrayName Arr
 	if err != nil {
 		return nil, err
 	}
-	oa, ok := StandardArrays[arrayName]
-	if !ok {
-		return nil, fmt.Errorf("orthogonal array %s not defined", arrayName)
+	spec, err := StandardArraySpec(arrayName)
+	if err != nil {
+		return nil, err
 	}
-	if len(controlFactors) > len(oa[0]) {
-		return nil, fmt.Errorf("orthogonal array %s cannot accommodate %d factors", arrayName, len(controlFactors))
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
 	}
 	return &Experiment[P]{
 		ControlFactors:  controlFactors,
 		NoiseFactors:    noiseFactors,
 		Goal:            goal,
-		OrthogonalArray: oa,
+		OrthogonalArray: spec.Rows,
 		controlAs:       buildControlAs[P](),
 	}, nil
 }
 
+// NewExperimentAuto initializes a new generic Taguchi experiment on the smallest standard
+// orthogonal array that accommodates the factors, as chosen by SelectArray.
+func NewExperimentAuto[F any, P any](goal OptimizationGoal, factors F, noiseFactors []NoiseFactor) (*Experiment[P], error) {
+	controlFactors, err := factorsFrom(factors)
+	if err != nil {
+		return nil, err
+	}
+	arrayName, err := SelectArray(controlFactors)
+	if err != nil {
+		return nil, err
+	}
+	return NewExperiment[F, P](goal, factors, arrayName, noiseFactors)
+}
+
 // NewExperimentUsingArray initializes a new generic Taguchi experiment with a user-provided orthogonal array.
 func NewExperimentUsingArray[F any, P any](goal OptimizationGoal, factors F, orthogonalArray [][]int, noiseFactors []NoiseFactor) (*Experiment[P], error) {
 	controlFactors, err := factorsFrom(factors)
 	if err != nil {
 		return nil, err
 	}
-	if len(orthogonalArray) == 0 {
-		return nil, fmt.Errorf("orthogonal array must not be empty")
+	if err := ValidateArray(orthogonalArray); err != nil {
+		return nil, err
+	}
+	spec, err := NewArraySpec(orthogonalArray)
+	if err != nil {
+		return nil, err
 	}
-	if len(controlFactors) > len(orthogonalArray[0]) {
-		return nil, fmt.Errorf("orthogonal array cannot accommodate %d factors", len(controlFactors))
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
 	}
 	return &Experiment[P]{
 		ControlFactors:  controlFactors,
@@ -52,29 +72,39 @@ func NewExperimentUsingArray[F any, P any](goal OptimizationGoal, factors F, ort
 // NewExperimentFromFactors initializes a Taguchi experiment from a pre-built []Factor slice.
 // This is the non-generic constructor for callers who already have []Factor.
 func NewExperimentFromFactors(goal OptimizationGoal, controlFactors []ControlFactor, arrayName ArrayType, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
-	oa, ok := StandardArrays[arrayName]
-	if !ok {
-		return nil, fmt.Errorf("orthogonal array %s not defined", arrayName)
+	if err := checkLevelRules(controlFactors); err != nil {
+		return nil, err
+	}
+	spec, err := StandardArraySpec(arrayName)
+	if err != nil {
+		return nil, err
 	}
-	if len(controlFactors) > len(oa[0]) {
-		return nil, fmt.Errorf("orthogonal array %s cannot accommodate %d factors", arrayName, len(controlFactors))
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
 	}
 	return &Experiment[struct{}]{
 		ControlFactors:  controlFactors,
 		NoiseFactors:    noiseFactors,
 		Goal:            goal,
-		OrthogonalArray: oa,
+		OrthogonalArray: spec.Rows,
 	}, nil
 }
 
 // NewExperimentFromFactorsUsingArray initializes a Taguchi experiment from a pre-built []Factor slice
 // with a user-provided orthogonal array.
 func NewExperimentFromFactorsUsingArray(goal OptimizationGoal, controlFactors []ControlFactor, orthogonalArray [][]int, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
-	if len(orthogonalArray) == 0 {
-		return nil, fmt.Errorf("orthogonal array must not be empty")
+	if err := checkLevelRules(controlFactors); err != nil {
+		return nil, err
 	}
-	if len(controlFactors) > len(orthogonalArray[0]) {
-		return nil, fmt.Errorf("orthogonal array cannot accommodate %d factors", len(controlFactors))
+	if err := ValidateArray(orthogonalArray); err != nil {
+		return nil, err
+	}
+	spec, err := NewArraySpec(orthogonalArray)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
 	}
 	return &Experiment[struct{}]{
 		ControlFactors:  controlFactors,
@@ -84,67 +114,241 @@ func NewExperimentFromFactorsUsingArray(goal OptimizationGoal, controlFactors []
 	}, nil
 }
 
+// NewExperimentFromFactorsUsingDesign initializes an experiment on a design that need not
+// be an orthogonal array, such as one from GenerateDOptimal. Level means are biased on
+// such designs, so Analyze fits the main-effects model by regression instead; the design
+// must have enough distinct runs to estimate every main effect.
+func NewExperimentFromFactorsUsingDesign(goal OptimizationGoal, controlFactors []ControlFactor, design [][]int, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	if err := checkLevelRules(controlFactors); err != nil {
+		return nil, err
+	}
+	spec, err := NewArraySpec(design)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
+	}
+	info, p := informationMatrix(controlFactors, design)
+	if rank, _ := logDeterminant(info); rank < p {
+		return nil, fmt.Errorf("design can estimate only %d of the %d main-effect parameters", rank, p)
+	}
+	return &Experiment[struct{}]{
+		ControlFactors:  controlFactors,
+		NoiseFactors:    noiseFactors,
+		Goal:            goal,
+		OrthogonalArray: design,
+		design:          designRegression,
+	}, nil
+}
+
+// NewFullFactorialExperiment initializes a Taguchi experiment that runs every combination
+// of the control factors' levels (see GenerateFullFactorial) instead of an orthogonal array.
+func NewFullFactorialExperiment(goal OptimizationGoal, controlFactors []ControlFactor, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	return NewExperimentFromFactorsUsingArray(goal, controlFactors, GenerateFullFactorial(controlFactors), noiseFactors)
+}
+
+// NewScreeningExperiment initializes a Taguchi experiment for screening many two-level
+// factors cheaply, on the smallest Plackett–Burman design with more runs than factors.
+func NewScreeningExperiment(goal OptimizationGoal, controlFactors []ControlFactor, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	var errs []error
+	for nRuns := (len(controlFactors)/4 + 1) * 4; nRuns <= 4*(len(controlFactors)+1); nRuns += 4 {
+		oa, err := PlackettBurman(nRuns)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return NewExperimentFromFactorsUsingArray(goal, controlFactors, oa, noiseFactors)
+	}
+	return nil, fmt.Errorf("no Plackett-Burman design for %d factors: %w", len(controlFactors), errors.Join(errs...))
+}
+
+// NewExperimentFromFactorsUsingSource initializes a Taguchi experiment whose orthogonal array
+// is read lazily from src, e.g. a DiskArray for generated designs with thousands of rows.
+func NewExperimentFromFactorsUsingSource(goal OptimizationGoal, controlFactors []ControlFactor, src ArraySource, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	spec, err := SourceArraySpec(src)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
+	}
+	if err := checkLevelRules(controlFactors); err != nil {
+		return nil, err
+	}
+	return &Experiment[struct{}]{
+		ControlFactors: controlFactors,
+		NoiseFactors:   noiseFactors,
+		Goal:           goal,
+		source:         src,
+	}, nil
+}
+
 // Params converts a Trial's Control map into a value of type P using the
 // pre-built converter function. P's exported float64 fields are populated from
-// the corresponding Control map entries (keyed by field name).
+// the corresponding Control map entries (keyed by field name), with compound
+// factors expanded into their components (see Settings).
 func (e *Experiment[P]) Params(trial Trial) P {
 	if e.controlAs == nil {
 		var zero P
 		return zero
 	}
+	trial.Control = e.Settings(trial)
 	return e.controlAs(trial)
 }
 
 // AddResult records the observations from a completed trial into the experiment's results.
+// A trial whose control configuration matches no orthogonal array row is quarantined in
+// AdHocResults instead, so that it cannot silently skew the analysis.
 func (e *Experiment[P]) AddResult(trial Trial, observations []float64) {
-	e.Results = append(e.Results, TrialResult{
+	e.AddResultWithCovariates(trial, observations, nil)
+}
+
+// AddResultWithCovariates records a trial's observations like AddResult, together with
+// noise covariates observed while it ran, e.g. sampled with SampleDuring.
+func (e *Experiment[P]) AddResultWithCovariates(trial Trial, observations []float64, covariates map[string]float64) {
+	e.AddTrialResult(TrialResult{
 		Trial:        trial,
 		Observations: observations,
+		Covariates:   covariates,
 	})
 }
 
-// Analyze performs a full Taguchi analysis on the collected trial results.
+// AddTrialResult records a complete TrialResult, including any covariates and
+// environment fingerprint. Off-design trials are quarantined as in AddResult, and noise
+// replicates are merged when GroupNoiseReplicates is enabled.
+func (e *Experiment[P]) AddTrialResult(result TrialResult) {
+	row := e.rowOf(result.Trial)
+	if row < 0 {
+		e.AdHocResults = append(e.AdHocResults, result)
+		return
+	}
+	result.row = row + 1
+	if e.groupNoise {
+		e.addOuterRun(result)
+		return
+	}
+	e.Results = append(e.Results, result)
+}
+
+// rowOf returns the first orthogonal array row matching the trial's control
+// configuration, or -1 if the trial is off-design.
+func (e *Experiment[P]) rowOf(trial Trial) int {
+	oa := e.array()
+	for i := 0; i < oa.Rows(); i++ {
+		if e.matchesRow(trial, oa.Row(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Analyze performs a full Taguchi analysis on the collected trial results. Latin
+// hypercube and other non-orthogonal designs are analyzed by regression instead (see
+// MethodRegression), supersaturated designs by forward selection and definitive
+// screening, Box–Behnken and central composite designs by a quadratic model.
 func (e *Experiment[P]) Analyze() AnalysisResult {
+	switch e.design {
+	case designSupersaturated:
+		return e.AnalyzeForwardSelection(forwardSelectionAlpha)
+	case designDefinitiveScreening:
+		return e.analyzeQuadratic(MethodDefinitiveScreening)
+	case designBoxBehnken:
+		return e.analyzeQuadratic(MethodBoxBehnken)
+	case designCentralComposite:
+		return e.analyzeQuadratic(MethodCentralComposite)
+	}
+	if e.design == designLatinHypercube || e.design == designRegression {
+		return e.analyzeRegression()
+	}
 	oaSNR, grandMean := e.computeOASNR()
 	anova, mainEffects, snrPerFactor := e.computeANOVA(oaSNR, grandMean)
 	optimalLevels := e.findOptimalLevels(mainEffects)
 	contributions := computeContributions(anova)
 
-	return AnalysisResult{
+	result := AnalysisResult{
+		Method:        MethodParametric,
 		OptimalLevels: optimalLevels,
 		SNR:           snrPerFactor,
 		MainEffects:   mainEffects,
 		Contributions: contributions,
 		ANOVA:         anova,
 	}
+	return e.runPasses(result, oaSNR)
+}
+
+// runPasses attaches data diagnostics and the cost summary to result, moves the optimal levels off
+// unsafe configurations, then runs the registered analysis passes and attaches their sections.
+func (e *Experiment[P]) runPasses(result AnalysisResult, oaSNR []float64) AnalysisResult {
+	result.GrandMean = mean(oaSNR)
+	result.Diagnostics = e.diagnostics()
+	e.avoidUnsafe(&result)
+	result.Cost = e.costSummary(result.Method, result.MainEffects, result.GrandMean)
+	result.Narrative = e.narrative(result, result.GrandMean)
+	result.goal = e.Goal
+	result.designTables = func() DesignTables { return e.designTables(oaSNR) }
+	result.Levels = make(map[string][]float64, len(e.ControlFactors))
+	for _, factor := range e.ControlFactors {
+		result.Levels[factor.Name] = factor.Levels
+		if factor.Duration {
+			if result.Durations == nil {
+				result.Durations = make(map[string]bool)
+			}
+			result.Durations[factor.Name] = true
+		}
+	}
+	result.Sections = runPasses(PassInput{
+		Goal:            e.Goal,
+		ControlFactors:  e.ControlFactors,
+		NoiseFactors:    e.NoiseFactors,
+		OrthogonalArray: e.OrthogonalArray,
+		Array:           e.array(),
+		Results:         e.Results,
+		RowSNR:          oaSNR,
+		Result:          result,
+	})
+	return result
 }
 
 // computeOASNR computes the Signal-to-Noise ratio for each orthogonal array row
 // by collecting all observations across noise conditions and computing SNR once
 // on the combined set. Returns the per-row SNR values and the grand mean.
 func (e *Experiment[P]) computeOASNR() ([]float64, float64) {
-	oaRows := len(e.OrthogonalArray)
+	oa := e.array()
+	oaRows := oa.Rows()
 	oaSNR := make([]float64, oaRows)
 	grandMean := 0.0
 
-	for i := 0; i < oaRows; i++ {
-		var allObs []float64
-		for _, r := range e.Results {
-			match := true
-			for j, factor := range e.ControlFactors {
-				if r.Trial.Control[factor.Name] != factor.Levels[e.OrthogonalArray[i][j]-1] {
-					match = false
-					break
-				}
-			}
-			if match {
-				allObs = append(allObs, r.Observations...)
+	// allObs is reused across rows so that analysis allocates one buffer, not one per row;
+	// results are found by their cached row instead of an index over all of them.
+	var allObs, allWeights []float64
+	weighted, _ := e.Goal.(WeightedGoal)
+	for i, first := range e.firstRows(oa) {
+		if first != i {
+			oaSNR[i] = oaSNR[first]
+			grandMean += oaSNR[i]
+			continue
+		}
+		allObs, allWeights = allObs[:0], allWeights[:0]
+		hasWeights := false
+		for k := range e.Results {
+			if e.resultRow(k) == i {
+				allObs = append(allObs, e.Results[k].Observations...)
+				hasWeights = hasWeights || e.Results[k].Weights != nil
 			}
 		}
-		if len(allObs) > 0 {
-			oaSNR[i] = e.Goal.CalculateSNR(allObs)
-		} else {
+		switch {
+		case len(allObs) == 0:
 			oaSNR[i] = 0
+		case hasWeights && weighted != nil:
+			for k := range e.Results {
+				if e.resultRow(k) == i {
+					allWeights = append(allWeights, weightsOf(e.Results[k])...)
+				}
+			}
+			oaSNR[i] = weighted.CalculateWeightedSNR(allObs, allWeights)
+		default:
+			oaSNR[i] = e.Goal.CalculateSNR(allObs)
 		}
 		grandMean += oaSNR[i]
 	}
@@ -153,6 +357,84 @@ func (e *Experiment[P]) computeOASNR() ([]float64, float64) {
 	return oaSNR, grandMean
 }
 
+// rowResults returns, for each orthogonal array row, the indices of the results that
+// match it. Rows repeating an earlier row's control configuration share its results.
+func (e *Experiment[P]) rowResults() [][]int {
+	oa := e.array()
+	out := make([][]int, oa.Rows())
+	for k := range e.Results {
+		if i := e.resultRow(k); i >= 0 {
+			out[i] = append(out[i], k)
+		}
+	}
+	for i, first := range e.firstRows(oa) {
+		if first != i {
+			out[i] = out[first]
+		}
+	}
+	return out
+}
+
+// resultRow returns the first orthogonal array row matching the k-th result, or -1 if
+// it is off-design. AddTrialResult matches each result once and keeps the row with it;
+// results assigned to Results directly, e.g. by LoadExperiment, are matched on first use.
+func (e *Experiment[P]) resultRow(k int) int {
+	r := &e.Results[k]
+	if r.row == 0 {
+		r.row = e.rowOf(r.Trial) + 1
+	}
+	return r.row - 1
+}
+
+// firstRows returns, for each orthogonal array row, the first row with the same control
+// configuration.
+func (e *Experiment[P]) firstRows(oa ArraySource) []int {
+	first := make([]int, oa.Rows())
+	seen := make(map[string]int, oa.Rows())
+	var key []byte
+	for i := range first {
+		key = key[:0]
+		for _, level := range oa.Row(i)[:len(e.ControlFactors)] {
+			key = binary.AppendUvarint(key, uint64(level))
+		}
+		if j, ok := seen[string(key)]; ok {
+			first[i] = j
+			continue
+		}
+		seen[string(key)] = i
+		first[i] = i
+	}
+	return first
+}
+
+// array returns the experiment's orthogonal array as an ArraySource.
+func (e *Experiment[P]) array() ArraySource {
+	if e.source != nil {
+		return e.source
+	}
+	return (*designArray)(&e.OrthogonalArray)
+}
+
+// designArray is the ArraySource view of an experiment's OrthogonalArray field. Unlike
+// MemoryArray it is a pointer, so array() hands it out without allocating, and it sees
+// later assignments to the field.
+type designArray [][]int
+
+func (d *designArray) Rows() int       { return len(*d) }
+func (d *designArray) Columns() int    { return MemoryArray(*d).Columns() }
+func (d *designArray) Row(i int) []int { return (*d)[i] }
+
+// matchesRow reports whether the trial's control configuration corresponds to
+// the given orthogonal array row.
+func (e *Experiment[P]) matchesRow(trial Trial, row []int) bool {
+	for j, factor := range e.ControlFactors {
+		if trial.Control[factor.Name] != factor.Levels[row[j]-1] {
+			return false
+		}
+	}
+	return true
+}
+
 // findOptimalLevels determines the best level for each control factor by
 // selecting the level with the highest mean SNR (main effect).
 func (e *Experiment[P]) findOptimalLevels(mainEffects map[string][]float64) map[string]float64 {
@@ -1,8 +1,16 @@
 package taguchi
 
 import (
+	"fmt"
 	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 const float64EqualityThreshold = 1e-3
@@ -182,6 +190,40 @@ func TestAnalyze_SNR_NominalTheBest(t *testing.T) {
 	}
 }
 
+// TestAnalyze_SNR_NominalTheBestI verifies the Type I SNR, which rewards a small
+// spread relative to the mean regardless of any target.
+func TestAnalyze_SNR_NominalTheBestI(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+	}
+	oa := [][]int{{1}, {2}}
+	exp, err := NewExperimentFromFactorsUsingArray(NominalTheBestI{}, factors, oa, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+
+	trials := exp.GenerateTrials()
+	// A=1: mean 10, s² = 1 → 10·log10(100) = 20 dB
+	exp.AddResult(trials[0], []float64{9, 10, 11})
+	// A=2: mean 100, s² = 400 → 10·log10(100²/400) ≈ 13.98 dB: twice the relative spread
+	exp.AddResult(trials[1], []float64{80, 100, 120})
+
+	result := exp.Analyze()
+	snrA := result.SNR["A"]
+	if !almostEqual(snrA[0], 20) || !almostEqual(snrA[1], 10*math.Log10(10000.0/400)) {
+		t.Errorf("SNR[A]: got %v, want [20 %.4f]", snrA, 10*math.Log10(10000.0/400))
+	}
+	if result.OptimalLevels["A"] != 1 {
+		t.Errorf("OptimalLevels[A]: got %v, want 1", result.OptimalLevels["A"])
+	}
+	if snr := (NominalTheBestI{}).CalculateSNR([]float64{4, 4}); !math.IsInf(snr, 1) {
+		t.Errorf("no variation: got %v, want +Inf", snr)
+	}
+	if got := DescribeChange(NominalTheBestI{}, 6.0206); got != "~50% smaller coefficient of variation" {
+		t.Errorf("DescribeChange: got %q", got)
+	}
+}
+
 // TestAnalyze_SingleTrialPerRow verifies the degenerate case with 1 noise level
 // where combined and per-trial SNR would produce the same result.
 func TestAnalyze_SingleTrialPerRow(t *testing.T) {
@@ -287,3 +329,2227 @@ func TestAnalyze_ANOVA_BasicSanity(t *testing.T) {
 		}
 	}
 }
+
+// TestVerifyInvariants reports unbalanced and unmatched results and accepts a
+// complete, balanced experiment.
+func TestVerifyInvariants(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	oa := [][]int{{1, 1}, {1, 2}, {2, 1}, {2, 2}}
+
+	exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, oa, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+	trials := exp.GenerateTrials()
+	for i, trial := range trials {
+		exp.AddResult(trial, []float64{float64(i + 1)})
+	}
+	if err := VerifyInvariants(exp); err != nil {
+		t.Errorf("VerifyInvariants on balanced experiment: %v", err)
+	}
+
+	exp.AddResult(trials[0], []float64{1})
+	if err := VerifyInvariants(exp); err == nil {
+		t.Error("VerifyInvariants: expected error for unbalanced rows")
+	}
+
+	exp.Results = exp.Results[:len(trials)]
+	// AddResult would quarantine an off-design trial, so inject it directly.
+	exp.Results = append(exp.Results, TrialResult{Trial: Trial{ID: 99, Control: map[string]float64{"A": 3, "B": 1}}, Observations: []float64{1}})
+	if err := VerifyInvariants(exp); err == nil {
+		t.Error("VerifyInvariants: expected error for unmatched trial")
+	}
+
+	// On L8, each configuration of two factors runs on two rows that share its results.
+	exp, err = NewExperimentFromFactors(SmallerTheBetter{}, factors, L8, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{float64(i + 1)})
+	}
+	if err := VerifyInvariants(exp); err != nil {
+		t.Errorf("VerifyInvariants with repeated configurations: %v", err)
+	}
+	exp.AddResult(exp.GenerateTrials()[0], []float64{1})
+	if err := VerifyInvariants(exp); err == nil {
+		t.Error("VerifyInvariants: expected error for an unbalanced repeated configuration")
+	}
+
+	// Box–Behnken center runs repeat one configuration.
+	var bbFactors []ControlFactor
+	for _, name := range []string{"A", "B", "C"} {
+		bbFactors = append(bbFactors, ControlFactor{Name: name, Levels: []float64{1, 2, 3}})
+	}
+	bb, err := GenerateBoxBehnken(3)
+	if err != nil {
+		t.Fatalf("GenerateBoxBehnken: %v", err)
+	}
+	exp = &Experiment[struct{}]{ControlFactors: bbFactors, Goal: SmallerTheBetter{}, OrthogonalArray: bb, design: designBoxBehnken}
+	for _, trial := range exp.GenerateTrials() {
+		c := trial.Control
+		exp.AddResult(trial, []float64{c["A"] + (c["B"]-2)*(c["B"]-2) + 0.1*float64(trial.ID)})
+	}
+	if err := VerifyInvariants(exp); err != nil {
+		t.Errorf("VerifyInvariants on a Box–Behnken design: %v", err)
+	}
+}
+
+type rowCountPass struct{}
+
+func (rowCountPass) Name() string { return "row-count" }
+
+func (rowCountPass) Run(in PassInput) AnalysisSection {
+	return AnalysisSection{Values: map[string]float64{"rows": float64(len(in.RowSNR))}}
+}
+
+// TestAnalyze_RunsRegisteredPasses verifies that registered analysis passes
+// run inside Analyze and attach their sections to the result.
+func TestAnalyze_RunsRegisteredPasses(t *testing.T) {
+	if err := RegisterAnalysisPass(rowCountPass{}); err != nil {
+		t.Fatalf("RegisterAnalysisPass: %v", err)
+	}
+	defer UnregisterAnalysisPass("row-count")
+	if err := RegisterAnalysisPass(rowCountPass{}); err == nil {
+		t.Error("RegisterAnalysisPass: expected error for duplicate name")
+	}
+
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, [][]int{{1}, {2}}, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{1})
+	}
+
+	result := exp.Analyze()
+	if len(result.Sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(result.Sections))
+	}
+	section := result.Sections[0]
+	if section.Pass != "row-count" || section.Title != "row-count" {
+		t.Errorf("section: got pass %q title %q", section.Pass, section.Title)
+	}
+	if section.Values["rows"] != 2 {
+		t.Errorf("section.Values[rows]: got %v, want 2", section.Values["rows"])
+	}
+}
+
+// TestLimitNoiseCombinations verifies that capped noise combinations are a
+// stratified sample: each noise level appears equally often.
+func TestLimitNoiseCombinations(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+	}
+	noise := []NoiseFactor{
+		{Name: "N1", Levels: []float64{0, 1}},
+		{Name: "N2", Levels: []float64{0, 1, 2, 3}},
+		{Name: "N3", Levels: []float64{0, 1, 2, 3}},
+	}
+	exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, [][]int{{1}, {2}}, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+
+	count := 0
+	exp.NoiseCombinationChunks(5, func(chunk []map[string]float64) bool {
+		count += len(chunk)
+		return true
+	})
+	if count != 32 {
+		t.Errorf("NoiseCombinationChunks: got %d combinations, want 32", count)
+	}
+
+	exp.LimitNoiseCombinations(8, 1)
+	trials := exp.GenerateTrials()
+	if len(trials) != 16 {
+		t.Fatalf("expected 16 trials, got %d", len(trials))
+	}
+	levelCounts := map[float64]int{}
+	for _, trial := range trials[:8] {
+		levelCounts[trial.Noise["N2"]]++
+	}
+	for level, n := range levelCounts {
+		if n != 2 {
+			t.Errorf("N2 level %v: used %d times, want 2", level, n)
+		}
+	}
+}
+
+type dryRunFactors struct {
+	A []float64
+	B []float64
+}
+
+type dryRunParams struct {
+	A float64
+	B float64
+}
+
+// TestStudy drives an experiment through the stable Design, Runner and Analyzer
+// interfaces alone.
+func TestStudy(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, []NoiseFactor{{Name: "N", Levels: []float64{0, 1}}})
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	var study Study = exp
+	var design Design = study
+	var runner Runner = study
+	var analyzer Analyzer = study
+
+	trials := design.GenerateTrials()
+	if len(trials) != 8 {
+		t.Fatalf("GenerateTrials: got %d trials, want 8", len(trials))
+	}
+	for _, trial := range trials {
+		y := 10*trial.Control["A"] + trial.Control["B"] + trial.Noise["N"]
+		runner.AddResult(trial, []float64{y, y + 0.1})
+	}
+	if len(exp.Results) != 8 {
+		t.Errorf("AddResult: got %d results, want 8", len(exp.Results))
+	}
+	result := analyzer.Analyze()
+	if result.Method != MethodParametric || result.OptimalLevels["A"] != 1 || result.OptimalLevels["B"] != 1 {
+		t.Errorf("Analyze: got %s with optimal levels %v, want A=1 B=1", result.Method, result.OptimalLevels)
+	}
+}
+
+// TestDryRun_RecoversModelOptimum verifies that a dry run against a known model
+// finds the model's optimum and leaves the experiment's results untouched.
+func TestDryRun_RecoversModelOptimum(t *testing.T) {
+	exp, err := NewExperiment[dryRunFactors, dryRunParams](
+		SmallerTheBetter{},
+		dryRunFactors{A: []float64{1, 5}, B: []float64{2, 3}},
+		L4,
+		[]NoiseFactor{{Name: "N", Levels: []float64{0, 1}}},
+	)
+	if err != nil {
+		t.Fatalf("NewExperiment: %v", err)
+	}
+
+	result := exp.DryRun(func(p dryRunParams, _, noise map[string]float64) float64 {
+		return 10*p.A + p.B + noise["N"]
+	}, 0.1)
+
+	if result.OptimalLevels["A"] != 1 {
+		t.Errorf("OptimalLevels[A]: got %v, want 1", result.OptimalLevels["A"])
+	}
+	if result.Contributions["A"] < result.Contributions["B"] {
+		t.Errorf("Contributions: A (%.2f%%) should dominate B (%.2f%%)", result.Contributions["A"], result.Contributions["B"])
+	}
+	if len(exp.Results) != 0 {
+		t.Errorf("DryRun recorded %d results in the experiment", len(exp.Results))
+	}
+}
+
+// TestDryRun_FactorsWithoutParams verifies that experiments without a params struct
+// see each trial's settings in the dry-run model.
+func TestDryRun_FactorsWithoutParams(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 5}},
+		{Name: "B", Levels: []float64{2, 3}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+
+	result := exp.DryRun(func(_ struct{}, settings, _ map[string]float64) float64 {
+		return 10*settings["A"] + settings["B"]
+	}, 0.1)
+
+	if result.OptimalLevels["A"] != 1 || result.OptimalLevels["B"] != 2 {
+		t.Errorf("OptimalLevels: got %v, want A=1 B=2", result.OptimalLevels)
+	}
+	if result.Contributions["A"] < 90 {
+		t.Errorf("Contributions[A]: got %.2f%%, want A to dominate", result.Contributions["A"])
+	}
+}
+
+// TestDatasets_ReproduceExpectedAnalysis verifies that every built-in dataset
+// analyzes to its recorded optimal levels.
+func TestDatasets_ReproduceExpectedAnalysis(t *testing.T) {
+	for _, name := range DatasetNames() {
+		d, err := LoadDataset(name)
+		if err != nil {
+			t.Fatalf("LoadDataset(%s): %v", name, err)
+		}
+		exp, err := d.Experiment()
+		if err != nil {
+			t.Fatalf("%s: Experiment: %v", name, err)
+		}
+		result := exp.Analyze()
+		for factor, want := range d.ExpectedOptimal {
+			if got := result.OptimalLevels[factor]; got != want {
+				t.Errorf("%s: OptimalLevels[%s]: got %v, want %v", name, factor, got, want)
+			}
+		}
+	}
+}
+
+// TestCheckGoldenReport pins the report for every built-in dataset.
+func TestCheckGoldenReport(t *testing.T) {
+	for _, name := range DatasetNames() {
+		t.Run(name, func(t *testing.T) {
+			d, err := LoadDataset(name)
+			if err != nil {
+				t.Fatalf("LoadDataset: %v", err)
+			}
+			exp, err := d.Experiment()
+			if err != nil {
+				t.Fatalf("Experiment: %v", err)
+			}
+			if err := CheckGoldenReport("testdata/"+name+".golden", exp.Analyze(), false); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestAnalyzeMeans flags a dominant factor and requires error degrees of freedom.
+func TestAnalyzeMeans(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L8, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		y := 1.0 + 0.05*float64(i%3)
+		if trial.Control["A"] == 2 {
+			y *= 10
+		}
+		exp.AddResult(trial, []float64{y})
+	}
+
+	anom, err := exp.AnalyzeMeans(0.05)
+	if err != nil {
+		t.Fatalf("AnalyzeMeans: %v", err)
+	}
+	a := anom.Factors["A"]
+	if !a.Significant[0] || !a.Significant[1] {
+		t.Errorf("A levels should be significant: %+v", a)
+	}
+	b := anom.Factors["B"]
+	if b.Significant[0] || b.Significant[1] {
+		t.Errorf("B levels should not be significant: %+v", b)
+	}
+
+	small, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range small.GenerateTrials() {
+		small.AddResult(trial, []float64{float64(i + 1)})
+	}
+	if _, err := small.AnalyzeMeans(0.05); err != nil {
+		t.Errorf("AnalyzeMeans with one error DF: %v", err)
+	}
+
+	d, err := LoadDataset("ina-tile")
+	if err != nil {
+		t.Fatalf("LoadDataset: %v", err)
+	}
+	saturated, err := d.Experiment()
+	if err != nil {
+		t.Fatalf("Experiment: %v", err)
+	}
+	if _, err := saturated.AnalyzeMeans(0.05); err == nil {
+		t.Error("AnalyzeMeans: expected error for a saturated design")
+	}
+}
+
+// TestAnalyzeNonparametric verifies rank-based effects and Kruskal-Wallis tests.
+func TestAnalyzeNonparametric(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+		{Name: "C", Levels: []float64{1, 2}},
+		{Name: "D", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L8, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		y := 1.0 + 0.1*float64(i)
+		if trial.Control["A"] == 2 {
+			y *= 1000 // outlier-scale effect
+		}
+		exp.AddResult(trial, []float64{y})
+	}
+
+	result := exp.AnalyzeNonparametric()
+	if result.Method != MethodKruskalWallis {
+		t.Errorf("Method: got %q, want %q", result.Method, MethodKruskalWallis)
+	}
+	if result.OptimalLevels["A"] != 1 {
+		t.Errorf("OptimalLevels[A]: got %v, want 1", result.OptimalLevels["A"])
+	}
+	// A=1 rows hold ranks 5..8, A=2 rows ranks 1..4.
+	if !almostEqual(result.MainEffects["A"][0], 6.5) || !almostEqual(result.MainEffects["A"][1], 2.5) {
+		t.Errorf("MainEffects[A]: got %v, want [6.5 2.5]", result.MainEffects["A"])
+	}
+	// H = 12/(8·9) · (4·2² + 4·2²) = 5.333
+	kw := result.KruskalWallis["A"]
+	if !almostEqual(kw.H, 16.0/3) || kw.DF != 1 {
+		t.Errorf("KruskalWallis[A]: got %+v, want H=5.333 DF=1", kw)
+	}
+	if kw.PValue > 0.05 {
+		t.Errorf("KruskalWallis[A].PValue: got %.4f, want < 0.05", kw.PValue)
+	}
+
+	// Mean ranks are not in dB: the narrative and the cost summary must not claim a gain.
+	d, err := LoadDataset("ina-tile")
+	if err != nil {
+		t.Fatalf("LoadDataset: %v", err)
+	}
+	tile, err := d.Experiment()
+	if err != nil {
+		t.Fatalf("Experiment: %v", err)
+	}
+	for i := range tile.Results {
+		tile.Results[i].Cost = 1
+	}
+	ranked := tile.AnalyzeNonparametric()
+	if len(ranked.Narrative) == 0 {
+		t.Fatal("Narrative: got none")
+	}
+	for _, line := range ranked.Narrative {
+		if strings.Contains(line, "dB") || strings.Contains(line, "%") && strings.Contains(line, "response") {
+			t.Errorf("Narrative: got %q, want no dB claims", line)
+		}
+	}
+	if ranked.Cost == nil || ranked.Cost.ExpectedGain != 0 || ranked.Cost.ExpectedChange != "" {
+		t.Errorf("Cost: got %+v, want no expected gain", ranked.Cost)
+	}
+}
+
+// TestAnalyzeBayesian verifies that the posterior favours the level with the
+// clearly better SNR and that its credible interval excludes zero.
+func TestAnalyzeBayesian(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+		{Name: "C", Levels: []float64{1, 2}},
+		{Name: "D", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L8, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		y := 1.0 + 0.05*float64(i%3)
+		if trial.Control["A"] == 2 {
+			y *= 10
+		}
+		exp.AddResult(trial, []float64{y})
+	}
+
+	result, err := exp.AnalyzeBayesian(BayesianOptions{})
+	if err != nil {
+		t.Fatalf("AnalyzeBayesian: %v", err)
+	}
+	if result.OptimalLevels["A"] != 1 {
+		t.Errorf("OptimalLevels[A]: got %v, want 1", result.OptimalLevels["A"])
+	}
+	if p := result.ProbOptimal["A"][0]; p < 0.95 {
+		t.Errorf("ProbOptimal[A][0]: got %.3f, want >= 0.95", p)
+	}
+	if a1 := result.Effects["A"][0]; a1.Lower <= 0 {
+		t.Errorf("Effects[A][0]: credible interval [%.3f, %.3f] should exclude 0", a1.Lower, a1.Upper)
+	}
+}
+
+// TestAnalyzeWithShrinkage verifies that lasso shrinkage drops a weak factor
+// while keeping the dominant one.
+func TestAnalyzeWithShrinkage(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := 1.0
+		if trial.Control["A"] == 2 {
+			y *= 10
+		}
+		if trial.Control["B"] == 2 {
+			y *= 1.05
+		}
+		exp.AddResult(trial, []float64{y})
+	}
+
+	result, err := exp.AnalyzeWithShrinkage(LassoShrinkage, 2)
+	if err != nil {
+		t.Fatalf("AnalyzeWithShrinkage: %v", err)
+	}
+	if !almostEqual(result.Contributions["B"], 0) {
+		t.Errorf("Contributions[B]: got %.4f, want 0", result.Contributions["B"])
+	}
+	if !almostEqual(result.Contributions["A"], 100) {
+		t.Errorf("Contributions[A]: got %.4f, want 100", result.Contributions["A"])
+	}
+	if result.OptimalLevels["A"] != 1 {
+		t.Errorf("OptimalLevels[A]: got %v, want 1", result.OptimalLevels["A"])
+	}
+
+	if _, err := exp.AnalyzeWithShrinkage(RidgeShrinkage, 0); err == nil {
+		t.Error("AnalyzeWithShrinkage: expected error for non-positive lambda")
+	}
+}
+
+// TestPermutationTest verifies that a strong effect gets a small permutation
+// p-value in a saturated design, and a null factor does not.
+func TestPermutationTest(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+		{Name: "C", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, []NoiseFactor{{Name: "N", Levels: []float64{1, 2, 3}}})
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := trial.Noise["N"]
+		if trial.Control["A"] == 2 {
+			y *= 10
+		}
+		exp.AddResult(trial, []float64{y})
+	}
+
+	p := exp.PermutationTest(999, 1)
+	if p["A"] > 0.05 {
+		t.Errorf("p[A]: got %.4f, want <= 0.05", p["A"])
+	}
+	if p["B"] < 0.2 {
+		t.Errorf("p[B]: got %.4f, want a large p-value for a null factor", p["B"])
+	}
+}
+
+func TestCorrectPValues(t *testing.T) {
+	raw := map[string]float64{"A": 0.01, "B": 0.04, "C": 0.03, "D": 0.005, "E": math.NaN()}
+	tests := []struct {
+		method      Correction
+		adjusted    map[string]float64
+		significant []string
+	}{
+		{HolmCorrection, map[string]float64{"A": 0.03, "B": 0.06, "C": 0.06, "D": 0.02}, []string{"A", "D"}},
+		{BenjaminiHochbergCorrection, map[string]float64{"A": 0.02, "B": 0.04, "C": 0.04, "D": 0.02}, []string{"A", "B", "C", "D"}},
+	}
+	for _, tt := range tests {
+		got, err := CorrectPValues(raw, tt.method, 0.05)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.method, err)
+		}
+		for name, want := range tt.adjusted {
+			if !almostEqual(got.Adjusted[name], want) {
+				t.Errorf("%s adjusted[%s]: got %.4f, want %.4f", tt.method, name, got.Adjusted[name], want)
+			}
+		}
+		count := 0
+		for _, name := range tt.significant {
+			if !got.Significant[name] {
+				t.Errorf("%s: expected %s to be significant", tt.method, name)
+			}
+		}
+		for _, sig := range got.Significant {
+			if sig {
+				count++
+			}
+		}
+		if count != len(tt.significant) {
+			t.Errorf("%s: got %d significant factors, want %d", tt.method, count, len(tt.significant))
+		}
+		if !math.IsNaN(got.Adjusted["E"]) || got.Significant["E"] {
+			t.Errorf("%s: NaN p-value should stay NaN and not significant", tt.method)
+		}
+	}
+
+	if _, err := CorrectPValues(raw, HolmCorrection, 0); err == nil {
+		t.Error("expected an error for alpha = 0")
+	}
+}
+
+// TestAnalyze_AllocationsIndependentOfResults guards the allocation audit: analysis keeps
+// no per-result index, so recording 100 times more results may only add the few
+// allocations of the reused observation buffer growing to the larger rows.
+func TestAnalyze_AllocationsIndependentOfResults(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	allocs := func(results int) float64 {
+		exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+		if err != nil {
+			t.Fatalf("NewExperimentFromFactors: %v", err)
+		}
+		trials := exp.GenerateTrials()
+		for i := 0; i < results; i++ {
+			exp.AddResult(trials[i%len(trials)], []float64{float64(i%len(trials) + 1)})
+		}
+		return testing.AllocsPerRun(5, func() { exp.Analyze() })
+	}
+	if small, large := allocs(100), allocs(10000); large > small+10 {
+		t.Errorf("Analyze allocations: got %.0f for 10000 results, want at most 10 more than the %.0f for 100", large, small)
+	}
+}
+
+func TestCheckNoiseToSignal(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	run := func(effect float64) *Experiment[struct{}] {
+		exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+		if err != nil {
+			t.Fatalf("NewExperimentFromFactors: %v", err)
+		}
+		for i, trial := range exp.GenerateTrials() {
+			base := 10 + effect*trial.Control["A"]
+			// Replicates alternate around the base value; the sign pattern varies per row.
+			d := 5.0
+			if i%2 == 1 {
+				d = -5
+			}
+			exp.AddResult(trial, []float64{base + d, base - d, base + d/2, base - d/2})
+		}
+		return exp
+	}
+
+	if check := run(100).CheckNoiseToSignal(); check.NoiseDominates {
+		t.Errorf("strong effect: got ratio %.3f, want noise not to dominate", check.Ratio)
+	}
+	noisy := run(0)
+	if check := noisy.CheckNoiseToSignal(); !check.NoiseDominates {
+		t.Errorf("no effect: got ratio %.3f, want noise to dominate", check.Ratio)
+	}
+	if result := noisy.Analyze(); len(result.Diagnostics) != 1 {
+		t.Errorf("Diagnostics: got %v, want one warning", result.Diagnostics)
+	}
+
+	// Noise conditions differ strongly but replicates agree: the outer array is not noise.
+	outer, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, []NoiseFactor{{Name: "Load", Levels: []float64{1, 100}}})
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range outer.GenerateTrials() {
+		y := 10*trial.Noise["Load"] + trial.Control["A"]
+		outer.AddResult(trial, []float64{y, y + 0.01, y - 0.01})
+	}
+	if check := outer.CheckNoiseToSignal(); check.NoiseDominates {
+		t.Errorf("outer array: got ratio %.3f, want noise not to dominate", check.Ratio)
+	}
+	if result := outer.Analyze(); len(result.Diagnostics) != 0 {
+		t.Errorf("Diagnostics: got %v, want no warnings", result.Diagnostics)
+	}
+}
+
+func TestRowCoverage(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	trials := exp.GenerateTrials()
+	for _, trial := range trials[:3] {
+		exp.AddResult(trial, []float64{1})
+	}
+
+	coverage := exp.RowCoverage()
+	if len(coverage) != 4 {
+		t.Fatalf("rows: got %d, want 4", len(coverage))
+	}
+	wantResults := []int{2, 1, 0, 0}
+	for i, c := range coverage {
+		if c.Results != wantResults[i] {
+			t.Errorf("row %d results: got %d, want %d", i, c.Results, wantResults[i])
+		}
+		got := exp.GetTrialsForOARow(i)
+		if len(c.Trials) != 2 || len(got) != 2 {
+			t.Fatalf("row %d trials: got %d and %d, want 2", i, len(c.Trials), len(got))
+		}
+		for k := range got {
+			if got[k].ID != c.Trials[k].ID || got[k].ID != trials[i*2+k].ID {
+				t.Errorf("row %d trial %d: got ID %d, want %d", i, k, got[k].ID, trials[i*2+k].ID)
+			}
+		}
+	}
+	if exp.GetTrialsForOARow(4) != nil {
+		t.Error("expected nil for an out-of-range row")
+	}
+}
+
+func TestAnalyze_MixedLevelL50(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{10, 20, 30, 40, 50}},
+		{Name: "C", Levels: []float64{1, 2, 3, 4, 5}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L50, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := 10.0
+		if trial.Control["A"] == 2 {
+			y = 100
+		}
+		exp.AddResult(trial, []float64{y})
+	}
+
+	result := exp.Analyze()
+	if got := result.MainEffects["A"]; len(got) != 2 || !almostEqual(got[0], -20) || !almostEqual(got[1], -40) {
+		t.Errorf("MainEffects[A]: got %v, want [-20 -40]", got)
+	}
+	for _, name := range []string{"B", "C"} {
+		effects := result.MainEffects[name]
+		if len(effects) != 5 {
+			t.Fatalf("MainEffects[%s]: got %d levels, want 5", name, len(effects))
+		}
+		for l, v := range effects {
+			if !almostEqual(v, -30) {
+				t.Errorf("MainEffects[%s][%d]: got %.4f, want -30", name, l, v)
+			}
+		}
+		if result.ANOVA.FactorDF[name] != 4 {
+			t.Errorf("FactorDF[%s]: got %d, want 4", name, result.ANOVA.FactorDF[name])
+		}
+	}
+}
+
+func TestAddResult_QuarantinesOffDesignTrials(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{1})
+	}
+	exp.AddResult(Trial{ID: 99, Control: map[string]float64{"A": 3, "B": 1}}, []float64{100})
+	exp.AddResult(Trial{ID: 100, Control: map[string]float64{"A": 1}}, []float64{100})
+
+	if len(exp.Results) != 4 || len(exp.AdHocResults) != 2 {
+		t.Fatalf("got %d results and %d ad-hoc results, want 4 and 2", len(exp.Results), len(exp.AdHocResults))
+	}
+	if err := VerifyInvariants(exp); err != nil {
+		t.Errorf("VerifyInvariants: %v", err)
+	}
+	if result := exp.Analyze(); len(result.Diagnostics) != 1 {
+		t.Errorf("Diagnostics: got %v, want one warning", result.Diagnostics)
+	}
+}
+
+func TestRenameFactorAndRelabelLevel(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{0, 1}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{float64(i + 1)})
+	}
+	before := exp.Analyze()
+
+	if err := exp.RenameFactor("A", "Threads"); err != nil {
+		t.Fatalf("RenameFactor: %v", err)
+	}
+	if err := exp.RenameFactor("N", "Load"); err != nil {
+		t.Fatalf("RenameFactor noise: %v", err)
+	}
+	if err := exp.RelabelLevel("B", 2, 4); err != nil {
+		t.Fatalf("RelabelLevel: %v", err)
+	}
+	if err := exp.RenameFactor("B", "Threads"); err == nil {
+		t.Error("expected an error when renaming onto an existing factor")
+	}
+	if err := exp.RelabelLevel("B", 3, 5); err == nil {
+		t.Error("expected an error for a missing level")
+	}
+
+	for _, r := range exp.Results {
+		if _, ok := r.Trial.Control["A"]; ok {
+			t.Fatalf("trial %d still uses the old factor name", r.Trial.ID)
+		}
+		if _, ok := r.Trial.Noise["Load"]; !ok {
+			t.Fatalf("trial %d has no renamed noise factor", r.Trial.ID)
+		}
+		if r.Trial.Control["B"] == 2 {
+			t.Fatalf("trial %d still uses the old level", r.Trial.ID)
+		}
+	}
+	after := exp.Analyze()
+	if after.OptimalLevels["Threads"] != before.OptimalLevels["A"] {
+		t.Errorf("OptimalLevels[Threads]: got %v, want %v", after.OptimalLevels["Threads"], before.OptimalLevels["A"])
+	}
+	if !almostEqual(after.Contributions["B"], before.Contributions["B"]) {
+		t.Errorf("Contributions[B]: got %.4f, want %.4f", after.Contributions["B"], before.Contributions["B"])
+	}
+	if err := VerifyInvariants(exp); err != nil {
+		t.Errorf("VerifyInvariants: %v", err)
+	}
+}
+
+func TestAnalyze_FourLevelL16(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2, 3, 4}},
+		{Name: "B", Levels: []float64{1, 2, 3, 4}},
+	}
+	exp, err := NewExperimentFromFactors(LargerTheBetter{}, factors, L16_4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		// SNR = 20·log10(10^A) = 20·A dB, independent of B.
+		exp.AddResult(trial, []float64{math.Pow(10, trial.Control["A"])})
+	}
+
+	result := exp.Analyze()
+	for l, v := range result.MainEffects["A"] {
+		if want := 20 * float64(l+1); !almostEqual(v, want) {
+			t.Errorf("MainEffects[A][%d]: got %.4f, want %.4f", l, v, want)
+		}
+	}
+	if len(result.MainEffects["B"]) != 4 || result.ANOVA.FactorDF["A"] != 3 {
+		t.Errorf("got %d levels for B and %d DF for A, want 4 and 3", len(result.MainEffects["B"]), result.ANOVA.FactorDF["A"])
+	}
+	if result.OptimalLevels["A"] != 4 {
+		t.Errorf("OptimalLevels[A]: got %v, want 4", result.OptimalLevels["A"])
+	}
+}
+
+func TestMeasurementCache(t *testing.T) {
+	calls := 0
+	measure := func(trial Trial) ([]float64, error) {
+		calls++
+		return []float64{trial.Control["A"] * 10}, nil
+	}
+	cache := NewMeasurementCache()
+	a := Trial{ID: 1, Control: map[string]float64{"A": 1, "B": 2}, Noise: map[string]float64{"N": 0}}
+	b := Trial{ID: 7, Control: map[string]float64{"B": 2, "A": 1}, Noise: map[string]float64{"N": 0}}
+	c := Trial{ID: 2, Control: map[string]float64{"A": 1, "B": 2}, Noise: map[string]float64{"N": 1}}
+
+	for _, trial := range []Trial{a, b, c, a} {
+		obs, err := cache.Measure(trial, measure)
+		if err != nil || len(obs) != 1 || obs[0] != 10 {
+			t.Fatalf("Measure(%d): got %v, %v", trial.ID, obs, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("measure calls: got %d, want 2", calls)
+	}
+	if hits, misses := cache.Stats(); hits != 2 || misses != 2 {
+		t.Errorf("Stats: got %d hits and %d misses, want 2 and 2", hits, misses)
+	}
+	if _, err := cache.Refresh(a, measure); err != nil || calls != 3 {
+		t.Errorf("Refresh: got %d calls, err %v; want a fresh measurement", calls, err)
+	}
+}
+
+func TestRAPLMeter(t *testing.T) {
+	root := t.TempDir()
+	write := func(zone, file, value string) {
+		dir := filepath.Join(root, zone)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, file), []byte(value+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("intel-rapl:0", "max_energy_range_uj", "10000000")
+	write("intel-rapl:0", "energy_uj", "9500000")
+	write("intel-rapl:0:0", "max_energy_range_uj", "10000000")
+	write("intel-rapl:0:0", "energy_uj", "0")
+
+	measure := EnergyPerOperation(RAPLMeter{Root: root}, func(ScheduledTrial) (int, error) {
+		// The package counter wraps around: 9.5 J -> 10 J -> 1.5 J is 2 J in total.
+		write("intel-rapl:0", "energy_uj", "1500000")
+		write("intel-rapl:0:0", "energy_uj", "5000000")
+		return 4, nil
+	})
+	obs, err := measure(ScheduledTrial{})
+	if err != nil {
+		t.Fatalf("measure: %v", err)
+	}
+	if len(obs) != 1 || !almostEqual(obs[0], 0.5) {
+		t.Errorf("energy per operation: got %v, want [0.5]", obs)
+	}
+}
+
+type testPattern int
+
+const (
+	patternRandom testPattern = iota
+	patternSorted
+	patternReversed
+)
+
+func (p testPattern) String() string {
+	return [...]string{"random", "sorted", "reversed"}[p]
+}
+
+func TestNoiseFromEnum(t *testing.T) {
+	noise, decode := NoiseFromEnum("Pattern", patternRandom, patternSorted, patternReversed)
+	if noise.Name != "Pattern" || len(noise.Levels) != 3 || noise.Levels[2] != 2 {
+		t.Fatalf("NoiseFromEnum: got %+v", noise)
+	}
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, []NoiseFactor{noise})
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	seen := map[testPattern]int{}
+	for _, trial := range exp.GenerateTrials() {
+		seen[decode(trial)]++
+	}
+	if len(seen) != 3 || seen[patternReversed] != 4 {
+		t.Errorf("decoded patterns: got %v, want each of 3 patterns 4 times", seen)
+	}
+}
+
+type enumFactors struct {
+	Algorithm []testPattern
+	Workers   []int
+	Ratio     []float64
+}
+
+type enumParams struct {
+	Algorithm testPattern
+	Workers   int
+	Ratio     float64
+}
+
+func TestNewExperiment_EnumFactors(t *testing.T) {
+	exp, err := NewExperiment[enumFactors, enumParams](
+		SmallerTheBetter{},
+		enumFactors{
+			Algorithm: []testPattern{patternSorted, patternReversed},
+			Workers:   []int{1, 8},
+			Ratio:     []float64{0.5, 0.75},
+		},
+		L4,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewExperiment: %v", err)
+	}
+	seen := map[testPattern]bool{}
+	for _, trial := range exp.GenerateTrials() {
+		p := exp.Params(trial)
+		seen[p.Algorithm] = true
+		if p.Workers != 1 && p.Workers != 8 {
+			t.Errorf("Workers: got %d", p.Workers)
+		}
+		if p.Ratio != trial.Control["Ratio"] {
+			t.Errorf("Ratio: got %v, want %v", p.Ratio, trial.Control["Ratio"])
+		}
+	}
+	if !seen[patternSorted] || !seen[patternReversed] || len(seen) != 2 {
+		t.Errorf("Algorithm values: got %v", seen)
+	}
+}
+
+func TestNewExperiment_DurationFactors(t *testing.T) {
+	type factors struct {
+		Timeout []time.Duration
+		Retries []int
+	}
+	type params struct {
+		Timeout time.Duration
+		Retries int
+	}
+	exp, err := NewExperiment[factors, params](
+		SmallerTheBetter{},
+		factors{Timeout: []time.Duration{250 * time.Millisecond, 2 * time.Second}, Retries: []int{1, 3}},
+		L4,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewExperiment: %v", err)
+	}
+	if !exp.ControlFactors[0].Duration || exp.ControlFactors[1].Duration {
+		t.Errorf("Duration flags: got %v, %v", exp.ControlFactors[0].Duration, exp.ControlFactors[1].Duration)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		p := exp.Params(trial)
+		if p.Timeout != 250*time.Millisecond && p.Timeout != 2*time.Second {
+			t.Fatalf("Timeout: got %v", p.Timeout)
+		}
+		exp.AddResult(trial, []float64{p.Timeout.Seconds() + float64(p.Retries)})
+	}
+
+	result := exp.Analyze()
+	var report strings.Builder
+	FprintAnalysisReport(&report, result)
+	if !strings.Contains(report.String(), "  - Timeout: 250ms\n") {
+		t.Errorf("report does not print the optimal timeout as a duration:\n%s", report.String())
+	}
+	if !strings.Contains(report.String(), "  - Retries: 1\n") {
+		t.Errorf("report does not print the optimal retries as a number:\n%s", report.String())
+	}
+}
+
+func TestBoolFactors(t *testing.T) {
+	type factors struct {
+		Cache    []bool
+		Compress []bool
+		Workers  []int
+	}
+	type params struct {
+		Cache    bool
+		Compress bool
+		Workers  int
+	}
+	exp, err := NewExperiment[factors, params](
+		LargerTheBetter{},
+		factors{Cache: []bool{false, true}, Compress: []bool{false, true}, Workers: []int{1, 4}},
+		L4,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewExperiment: %v", err)
+	}
+	if got := exp.ControlFactors[0]; got.Levels[0] != 0 || got.Levels[1] != 1 {
+		t.Errorf("Cache levels: got %v, want [0 1]", got.Levels)
+	}
+	on := 0
+	for _, trial := range exp.GenerateTrials() {
+		p := exp.Params(trial)
+		if p.Cache != (trial.Control["Cache"] == 1) {
+			t.Errorf("Cache: got %v for level %v", p.Cache, trial.Control["Cache"])
+		}
+		if p.Cache {
+			on++
+		}
+	}
+	if on != 2 {
+		t.Errorf("Cache on in %d of 4 trials, want 2", on)
+	}
+
+	if f := BoolFactor("Flag"); f.Name != "Flag" || len(f.Levels) != 2 || f.Levels[0] != 0 || f.Levels[1] != 1 {
+		t.Errorf("BoolFactor: got %+v", f)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	web, err := WebServiceLatencyTemplate(4, 1000)
+	if err != nil {
+		t.Fatalf("WebServiceLatencyTemplate: %v", err)
+	}
+	batch, err := BatchJobThroughputTemplate(3)
+	if err != nil {
+		t.Fatalf("BatchJobThroughputTemplate: %v", err)
+	}
+	for _, tmpl := range []Template{web, batch, CompileFlagsTemplate()} {
+		exp, err := tmpl.NewExperiment()
+		if err != nil {
+			t.Errorf("%s: %v", tmpl.Name, err)
+			continue
+		}
+		if len(exp.GenerateTrials()) == 0 || tmpl.NoiseStrategy == "" {
+			t.Errorf("%s: empty template", tmpl.Name)
+		}
+	}
+	if got := web.ControlFactors[0].Levels; got[0] != 4 || got[2] != 16 {
+		t.Errorf("web Workers levels: got %v, want [4 8 16]", got)
+	}
+	if _, err := WebServiceLatencyTemplate(0, 1000); err == nil {
+		t.Error("expected an error for zero CPUs")
+	}
+	if _, err := BatchJobThroughputTemplate(1); err == nil {
+		t.Error("expected an error for a single CPU")
+	}
+}
+
+func TestCheckDesign(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "MaxWorkers", Levels: []float64{1, 2}},
+		{Name: "max_workers", Levels: []float64{1, 2}},
+		{Name: "BatchSize", Levels: []float64{10, 1005, 2000}},
+	}
+	exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, GenerateFullFactorial(factors), []NoiseFactor{{Name: "ThreadCount", Levels: []float64{1, 8}}})
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+	checks := map[string]bool{}
+	for _, issue := range exp.CheckDesign() {
+		checks[issue.Check] = true
+		if issue.Fix == "" {
+			t.Errorf("%s: no suggested fix", issue.Check)
+		}
+	}
+	for _, want := range []string{"duplicate-factor", "linear-span", "controllable-noise"} {
+		if !checks[want] {
+			t.Errorf("CheckDesign did not report %s", want)
+		}
+	}
+	if checks["saturated-unreplicated"] {
+		t.Error("full factorial with noise reported as saturated")
+	}
+
+	three := []ControlFactor{{Name: "A", Levels: []float64{1, 10}}, {Name: "B", Levels: []float64{1, 2}}, {Name: "C", Levels: []float64{1, 2}}}
+	exp, _ = NewExperimentFromFactors(SmallerTheBetter{}, three, L4, nil)
+	issues := exp.CheckDesign()
+	if len(issues) != 1 || issues[0].Check != "saturated-unreplicated" {
+		t.Errorf("saturated L4: got %v, want only saturated-unreplicated", issues)
+	}
+	exp, _ = NewExperimentFromFactors(SmallerTheBetter{}, three[:2], L4, nil)
+	if issues := exp.CheckDesign(); len(issues) != 0 {
+		t.Errorf("clean design: got %v", issues)
+	}
+}
+
+func TestCompoundFactor(t *testing.T) {
+	type factors struct {
+		Bake    [][]float64 `taguchi:"compound=Temperature+Minutes"`
+		Workers []int
+	}
+	type params struct {
+		Temperature float64
+		Minutes     int
+		Workers     int
+	}
+	exp, err := NewExperiment[factors, params](
+		SmallerTheBetter{},
+		factors{Bake: [][]float64{{150, 30}, {200, 10}}, Workers: []int{1, 4}},
+		L4,
+		nil,
+	)
+	if err != nil {
+		t.Fatalf("NewExperiment: %v", err)
+	}
+	if f := exp.ControlFactors[0]; len(f.Levels) != 2 || f.Levels[1] != 2 {
+		t.Fatalf("Bake levels: got %v, want [1 2]", f.Levels)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		p := exp.Params(trial)
+		switch trial.Control["Bake"] {
+		case 1:
+			if p.Temperature != 150 || p.Minutes != 30 {
+				t.Errorf("Bake level 1: got %v/%v, want 150/30", p.Temperature, p.Minutes)
+			}
+		case 2:
+			if p.Temperature != 200 || p.Minutes != 10 {
+				t.Errorf("Bake level 2: got %v/%v, want 200/10", p.Temperature, p.Minutes)
+			}
+		}
+		if _, ok := trial.Control["Temperature"]; ok {
+			t.Error("Params modified the trial's Control map")
+		}
+	}
+
+	if _, err := CompoundFactor("Bake", map[string][]float64{"T": {1, 2}, "M": {1}}); err == nil {
+		t.Error("expected an error for components with different level counts")
+	}
+	type untagged struct{ Bake [][]float64 }
+	if _, err := NewExperiment[untagged, params](SmallerTheBetter{}, untagged{Bake: [][]float64{{1, 2}, {3, 4}}}, L4, nil); err == nil {
+		t.Error("expected an error for a [][]float64 field without a compound tag")
+	}
+}
+
+func TestNarrative(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "Timeout", Levels: []float64{float64(time.Second), float64(100 * time.Millisecond)}, Duration: true},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := 10.0
+		if trial.Control["Timeout"] == float64(time.Second) {
+			y = 40
+		}
+		exp.AddResult(trial, []float64{y, y + trial.Control["B"]/100})
+	}
+	result := exp.Analyze()
+	if len(result.Narrative) == 0 || !strings.HasPrefix(result.Narrative[0], "Factor Timeout explains") ||
+		!strings.Contains(result.Narrative[0], "move from 1s to 100ms") {
+		t.Errorf("Narrative: got %q", result.Narrative)
+	}
+
+	// Enum and bool levels are named by their labels, not their numeric codes.
+	type labeledFactors struct {
+		Algorithm []testPattern
+		Cache     []bool
+	}
+	labeled, err := NewExperiment[labeledFactors, struct{}](SmallerTheBetter{},
+		labeledFactors{Algorithm: []testPattern{patternSorted, patternReversed}, Cache: []bool{false, true}}, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperiment: %v", err)
+	}
+	if got := labeled.ControlFactors[0].Labels; !slices.Equal(got, []string{"sorted", "reversed"}) {
+		t.Errorf("Algorithm labels: got %q, want [sorted reversed]", got)
+	}
+	for _, trial := range labeled.GenerateTrials() {
+		y := 10.0
+		if trial.Control["Algorithm"] == float64(patternSorted) {
+			y = 40
+		}
+		if trial.Control["Cache"] == 0 {
+			y *= 2
+		}
+		labeled.AddResult(trial, []float64{y, y + 0.1})
+	}
+	narrative := strings.Join(labeled.Analyze().Narrative, "\n")
+	if !strings.Contains(narrative, "move from sorted to reversed") || !strings.Contains(narrative, "move from false to true") {
+		t.Errorf("Narrative: got %q, want the enum and bool labels", narrative)
+	}
+}
+
+func TestPercentChange(t *testing.T) {
+	tests := []struct {
+		goal    OptimizationGoal
+		deltaDB float64
+		want    float64
+		text    string
+	}{
+		{SmallerTheBetter{}, 20 * math.Log10(2), -50, "~50% lower response"},
+		{SmallerTheBetter{}, -20 * math.Log10(2), 100, "~100% higher response"},
+		{LargerTheBetter{}, 20 * math.Log10(2), 100, "~100% higher response"},
+		{&NominalTheBest{Target: 1}, 20, -90, "~90% smaller deviation from the target"},
+		{NominalTheBest{Target: 1}, -20, 900, "~900% larger deviation from the target"},
+		{NominalTheBestI{}, 20, -90, "~90% smaller coefficient of variation"},
+		{FractionDefective{}, 10, -90, "~90% lower odds of a defect"},
+		{OperatingWindow{}, 20, -90, "~90% smaller ratio of the lower to the upper threshold"},
+		{DynamicCharacteristic{Signals: []float64{1, 2}}, 20, -90, "~90% smaller error relative to the slope"},
+		{PercentileGoal{Goal: LargerTheBetter{}, Percentile: 99}, 20, 900, "~900% higher response"},
+	}
+	for _, tc := range tests {
+		got, ok := PercentChange(tc.goal, tc.deltaDB)
+		if !ok || math.Abs(got-tc.want) > 1e-9 {
+			t.Errorf("PercentChange(%v, %.2f): got %v, %v, want %v", tc.goal, tc.deltaDB, got, ok, tc.want)
+		}
+		if text := DescribeChange(tc.goal, tc.deltaDB); text != tc.text {
+			t.Errorf("DescribeChange(%v, %.2f): got %q, want %q", tc.goal, tc.deltaDB, text, tc.text)
+		}
+	}
+
+	// PairedDifference SNRs are not in dB, and custom goals have no known scale: no
+	// translation, and the narrative gives the SNR gain alone.
+	for _, goal := range []OptimizationGoal{PairedDifference{}, GoalFunc("custom", func(obs []float64) float64 { return mean(obs) })} {
+		if _, ok := PercentChange(goal, 6); ok {
+			t.Errorf("PercentChange(%v): got ok, want no translation", goal)
+		}
+		if text := DescribeChange(goal, 6); text != "" {
+			t.Errorf("DescribeChange(%v): got %q, want empty", goal, text)
+		}
+	}
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(PairedDifference{Larger: true}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		d := trial.Control["A"] + trial.Control["B"]/10
+		exp.AddResult(trial, []float64{d, d + 0.5, d - 0.25})
+	}
+	narrative := exp.Analyze().Narrative
+	if len(narrative) == 0 {
+		t.Fatal("Narrative: got none for PairedDifference")
+	}
+	for _, line := range narrative {
+		if strings.Contains(line, "i.e.") {
+			t.Errorf("Narrative: got %q, want no percent translation", line)
+		}
+	}
+}
+
+func TestLatinHypercube(t *testing.T) {
+	factors := []ContinuousFactor{{Name: "A", Min: 1, Max: 10}, {Name: "B", Min: 0, Max: 1}}
+	exp, err := NewLatinHypercubeExperiment(SmallerTheBetter{}, factors, 12, 7, nil)
+	if err != nil {
+		t.Fatalf("NewLatinHypercubeExperiment: %v", err)
+	}
+	for j, f := range exp.ControlFactors {
+		for l, v := range f.Levels {
+			lo := factors[j].Min + float64(l)*(factors[j].Max-factors[j].Min)/12
+			if v < lo || v >= lo+(factors[j].Max-factors[j].Min)/12 {
+				t.Errorf("%s level %d = %v, outside its stratum", f.Name, l, v)
+			}
+		}
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := 1 + trial.Control["A"]
+		exp.AddResult(trial, []float64{y, y})
+	}
+	result := exp.Analyze()
+	if result.Method != MethodRegression {
+		t.Errorf("Method: got %q, want %q", result.Method, MethodRegression)
+	}
+	if got, want := result.OptimalLevels["A"], exp.ControlFactors[0].Levels[0]; got != want {
+		t.Errorf("optimal A: got %v, want the lowest level %v", got, want)
+	}
+	if result.Contributions["A"] < 90 {
+		t.Errorf("contribution of A: got %.1f%%, want > 90%%", result.Contributions["A"])
+	}
+
+	if _, err := NewLatinHypercubeExperiment(SmallerTheBetter{}, factors, 3, 7, nil); err == nil {
+		t.Error("expected an error for too few runs")
+	}
+}
+
+func TestWhatIf(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "MaxWorkers", Levels: []float64{4, 9, 20}},
+		{Name: "Cache", Levels: []float64{1, 2, 3}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L9, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := 100 / trial.Control["MaxWorkers"] * trial.Control["Cache"]
+		exp.AddResult(trial, []float64{y, y})
+	}
+	result := exp.Analyze()
+
+	best, err := result.WhatIf("MaxWorkers", 20)
+	if err != nil {
+		t.Fatalf("WhatIf: %v", err)
+	}
+	if best.LossDB != 0 || best.SNR != best.OptimalSNR || best.Change != "~0% lower response" {
+		t.Errorf("optimal level: got %+v", best)
+	}
+	got, err := result.WhatIf("MaxWorkers", 9)
+	if err != nil {
+		t.Fatalf("WhatIf: %v", err)
+	}
+	// Response scales with 1/MaxWorkers, so 9 instead of 20 workers costs 20·log10(20/9) dB.
+	if want := 20 * math.Log10(20.0/9); math.Abs(got.LossDB-want) > 1e-9 {
+		t.Errorf("LossDB: got %v, want %v", got.LossDB, want)
+	}
+	if want := 100.0 / 9; math.Abs(got.Response-want) > 1e-9 {
+		t.Errorf("Response: got %v, want %v", got.Response, want)
+	}
+	if got.Change != "~122% higher response" {
+		t.Errorf("Change: got %q", got.Change)
+	}
+
+	if _, err := result.WhatIf("MaxWorkers", 10); err == nil {
+		t.Error("expected an error for a level not in the design")
+	}
+	if _, err := result.WhatIf("Threads", 4); err == nil {
+		t.Error("expected an error for an unknown factor")
+	}
+}
+
+func TestDesignTables(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{10, 20}},
+		{Name: "B", Levels: []float64{100, 200, 300}},
+	}
+	exp, err := NewFullFactorialExperiment(SmallerTheBetter{}, factors, nil)
+	if err != nil {
+		t.Fatalf("NewFullFactorialExperiment: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		if trial.Control["A"] == 20 && trial.Control["B"] == 300 {
+			continue
+		}
+		exp.AddResult(trial, []float64{1, 3})
+	}
+	tables := exp.Analyze().DesignTables()
+	if got := fmt.Sprint(tables.Columns); got != "[A B Mean SNR]" {
+		t.Errorf("Columns: got %s", got)
+	}
+	if len(tables.Levels) != 6 {
+		t.Fatalf("got %d rows, want 6", len(tables.Levels))
+	}
+	if got := fmt.Sprint(tables.Levels[1][:3], tables.Coded[1][:3], tables.Actual[1][:3]); got != "[1 2 2] [-1 0 2] [10 200 2]" {
+		t.Errorf("row 2: got %s", got)
+	}
+	if snr := tables.Actual[1][3]; math.Abs(snr-SmallerTheBetter{}.CalculateSNR([]float64{1, 3})) > 1e-12 {
+		t.Errorf("row 2 SNR: got %v", snr)
+	}
+	if last := tables.Coded[5]; last[0] != 1 || last[1] != 1 || !math.IsNaN(last[2]) || !math.IsNaN(last[3]) {
+		t.Errorf("row 6: got %v, want coded 1, 1 and NaN responses", last)
+	}
+}
+
+func TestGroupNoiseReplicates(t *testing.T) {
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}}
+	noise := []NoiseFactor{{Name: "Load", Levels: []float64{1, 2}}, {Name: "Skew", Levels: []float64{0, 1}}}
+	plain, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	grouped, _ := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	grouped.GroupNoiseReplicates(true)
+	for _, trial := range plain.GenerateTrials() {
+		y := trial.Control["A"]*trial.Noise["Load"] + trial.Control["B"] + trial.Noise["Skew"]
+		plain.AddResult(trial, []float64{y, y + 1})
+		grouped.AddTrialResult(TrialResult{Trial: trial, Observations: []float64{y, y + 1}, Cost: 1})
+	}
+
+	if len(grouped.Results) != 4 {
+		t.Fatalf("got %d grouped results, want one per row", len(grouped.Results))
+	}
+	for _, r := range grouped.Results {
+		if len(r.Outer) != 4 || len(r.Observations) != 8 || r.Cost != 4 || r.Trial.Noise != nil {
+			t.Errorf("grouped result: got %d outer runs, %d observations, cost %v, noise %v", len(r.Outer), len(r.Observations), r.Cost, r.Trial.Noise)
+		}
+	}
+	want, got := plain.Analyze(), grouped.Analyze()
+	for _, f := range factors {
+		for l := range f.Levels {
+			if math.Abs(want.MainEffects[f.Name][l]-got.MainEffects[f.Name][l]) > 1e-12 {
+				t.Errorf("%s level %d: grouped effect %v, want %v", f.Name, l, got.MainEffects[f.Name][l], want.MainEffects[f.Name][l])
+			}
+		}
+	}
+
+	plain.GroupNoiseReplicates(true)
+	if len(plain.Results) != 4 || len(plain.Results[0].Outer) != 4 {
+		t.Errorf("regrouping: got %d results", len(plain.Results))
+	}
+}
+
+func TestSnapOptimalLevels(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "MaxWorkers", Levels: []float64{4, 9, 20}},
+		{Name: "Cache", Levels: []float64{1, 2, 3}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L9, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := 100 / trial.Control["MaxWorkers"] * trial.Control["Cache"]
+		exp.AddResult(trial, []float64{y})
+	}
+	result := exp.Analyze()
+
+	snapped, err := result.SnapOptimalLevels(map[string]Snap{"MaxWorkers": {Allowed: []float64{2, 16}}})
+	if err != nil {
+		t.Fatalf("SnapOptimalLevels: %v", err)
+	}
+	effects := result.MainEffects["MaxWorkers"]
+	wantEffect := effects[1] + (16.0-9)/(20-9)*(effects[2]-effects[1])
+	if snapped.Levels["MaxWorkers"] != 16 || snapped.Levels["Cache"] != 1 {
+		t.Errorf("Levels: got %v", snapped.Levels)
+	}
+	if want := snapped.OptimalSNR - (effects[2] - wantEffect); math.Abs(snapped.SNR-want) > 1e-9 {
+		t.Errorf("SNR: got %v, want %v", snapped.SNR, want)
+	}
+
+	snapped, err = result.SnapOptimalLevels(map[string]Snap{"MaxWorkers": {Step: 8}})
+	if err != nil {
+		t.Fatalf("SnapOptimalLevels: %v", err)
+	}
+	if snapped.Levels["MaxWorkers"] != 24 || snapped.SNR != snapped.OptimalSNR {
+		t.Errorf("step snap beyond the last level: got %+v", snapped)
+	}
+
+	if _, err := result.SnapOptimalLevels(map[string]Snap{"MaxWorkers": {}}); err == nil {
+		t.Error("expected an error for an empty snap")
+	}
+	if _, err := result.SnapOptimalLevels(map[string]Snap{"Threads": {Step: 1}}); err == nil {
+		t.Error("expected an error for an unknown factor")
+	}
+}
+
+func TestPoolIdleColumns(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2, 3}},
+		{Name: "C", Levels: []float64{1, 2, 3}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L18, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for i, trial := range exp.GenerateTrials() {
+		y := trial.Control["A"] + trial.Control["B"] + float64(i%5)
+		exp.AddResult(trial, []float64{y})
+	}
+	plain := exp.Analyze()
+	exp.PoolIdleColumns(true)
+	pooled := exp.Analyze()
+
+	if plain.ANOVA.ErrorDF != 12 || pooled.ANOVA.ErrorDF != 10 {
+		t.Errorf("ErrorDF: got %d unpooled and %d pooled, want 12 and 10", plain.ANOVA.ErrorDF, pooled.ANOVA.ErrorDF)
+	}
+	if len(pooled.ANOVA.PooledFactors) != 5 || pooled.ANOVA.PooledFactors[0] != "column 4" {
+		t.Errorf("PooledFactors: got %v", pooled.ANOVA.PooledFactors)
+	}
+	if pooled.ANOVA.ErrorSS > plain.ANOVA.ErrorSS+1e-9 {
+		t.Errorf("idle-column SS %v exceeds the residual SS %v", pooled.ANOVA.ErrorSS, plain.ANOVA.ErrorSS)
+	}
+	if want := pooled.ANOVA.FactorMS["A"] / pooled.ANOVA.ErrorMS; math.Abs(pooled.ANOVA.FactorF["A"]-want) > 1e-9 {
+		t.Errorf("F-ratio of A: got %v, want %v", pooled.ANOVA.FactorF["A"], want)
+	}
+}
+
+func FuzzAnalyze(f *testing.F) {
+	f.Add(int64(1), 3)
+	f.Add(int64(2), 1)
+	f.Fuzz(func(t *testing.T, seed int64, replicates int) {
+		replicates = 1 + (replicates%4+4)%4
+		oa, err := RandomOrthogonalArray(L9, seed)
+		if err != nil {
+			t.Fatalf("RandomOrthogonalArray: %v", err)
+		}
+		factors := []ControlFactor{
+			{Name: "A", Levels: []float64{1, 2, 3}},
+			{Name: "B", Levels: []float64{1, 2, 3}},
+			{Name: "C", Levels: []float64{1, 2, 3}},
+		}
+		exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, oa, nil)
+		if err != nil {
+			t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+		}
+		rng := rand.New(rand.NewSource(seed))
+		for _, trial := range exp.GenerateTrials() {
+			values := make([]float64, replicates)
+			for k := range values {
+				values[k] = trial.Control["A"] + rng.ExpFloat64()
+			}
+			exp.AddResult(trial, values)
+		}
+		if err := VerifyInvariants(exp); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestQuery(t *testing.T) {
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{10, 20}}}
+	noise := []NoiseFactor{{Name: "Load", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := trial.Control["A"] * trial.Noise["Load"]
+		exp.AddResult(trial, []float64{y, y + 2})
+	}
+
+	got := exp.Query().GroupBy("A").Mean()
+	want := Table{Columns: []string{"A", "Mean"}, Rows: [][]float64{{1, 2.5}, {2, 4}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupBy(A).Mean() = %v, want %v", got, want)
+	}
+	got = exp.Query().Where("Noise.Load", 2).GroupBy("Control.A", "B").Count()
+	if len(got.Rows) != 4 || got.Rows[0][2] != 2 || !reflect.DeepEqual(got.Columns, []string{"Control.A", "B", "Count"}) {
+		t.Errorf("Where(Load=2).GroupBy(A, B).Count() = %v", got)
+	}
+	if n := len(exp.Query().Where("Missing", 1).Results()); n != 0 {
+		t.Errorf("Where on a missing column kept %d results", n)
+	}
+
+	exp.GroupNoiseReplicates(true)
+	got = exp.Query().Where("Load", 1).GroupBy("A").Mean()
+	want = Table{Columns: []string{"A", "Mean"}, Rows: [][]float64{{1, 2}, {2, 3}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("grouped results: got %v, want %v", got, want)
+	}
+}
+
+func TestPercentileGoal(t *testing.T) {
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}}
+	goal := PercentileGoal{Goal: SmallerTheBetter{}, Percentile: 99}
+	exp, err := NewExperimentFromFactors(goal, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	rng := rand.New(rand.NewSource(1))
+	for _, trial := range exp.GenerateTrials() {
+		var sketches []QuantileSketch
+		for r := 0; r < 2; r++ {
+			d := NewTDigest(0)
+			for i := 0; i < 10000; i++ {
+				d.Add(trial.Control["A"] * rng.ExpFloat64())
+			}
+			sketches = append(sketches, d)
+		}
+		if err := exp.AddSketchResult(trial, sketches...); err != nil {
+			t.Fatalf("AddSketchResult: %v", err)
+		}
+	}
+	// p99 of A·Exp(1) is A·ln(100).
+	if p99 := exp.Results[0].Observations[0]; math.Abs(p99-math.Log(100))/math.Log(100) > 0.05 {
+		t.Errorf("p99 of row 1: got %.3f, want about %.3f", p99, math.Log(100))
+	}
+	result := exp.Analyze()
+	if result.OptimalLevels["A"] != 1 {
+		t.Errorf("optimal A: got %v, want 1", result.OptimalLevels["A"])
+	}
+	if goal.String() != "Smaller-the-Better (p99)" {
+		t.Errorf("String: got %q", goal.String())
+	}
+	if got := DescribeChange(goal, 6); got != "~50% lower response" {
+		t.Errorf("DescribeChange: got %q", got)
+	}
+
+	var buf strings.Builder
+	if err := exp.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadExperiment[struct{}](strings.NewReader(buf.String()))
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	if loaded.Goal != goal {
+		t.Errorf("loaded goal: got %v, want %v", loaded.Goal, goal)
+	}
+
+	plain, _ := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err := plain.AddSketchResult(plain.GenerateTrials()[0], NewTDigest(0)); err == nil {
+		t.Error("AddSketchResult accepted a non-percentile goal")
+	}
+}
+
+func TestSupersaturated(t *testing.T) {
+	design, err := GenerateSupersaturated(10)
+	if err != nil {
+		t.Fatalf("GenerateSupersaturated: %v", err)
+	}
+	if len(design) != 6 || len(design[0]) != 10 {
+		t.Fatalf("got %dx%d design, want 6x10", len(design), len(design[0]))
+	}
+	for j, levels := range ColumnLevels(design) {
+		count := 0
+		for _, row := range design {
+			if row[j] == 2 {
+				count++
+			}
+		}
+		if levels != 2 || count != 3 {
+			t.Errorf("column %d is unbalanced: %d of 6 rows at level 2", j+1, count)
+		}
+	}
+
+	var factors []ControlFactor
+	for i := 0; i < 10; i++ {
+		factors = append(factors, ControlFactor{Name: fmt.Sprintf("F%d", i+1), Levels: []float64{1, 2}})
+	}
+	exp, err := NewSupersaturatedExperiment(SmallerTheBetter{}, factors, nil)
+	if err != nil {
+		t.Fatalf("NewSupersaturatedExperiment: %v", err)
+	}
+	if result := exp.Analyze(); len(result.Selected) != 0 || len(result.MainEffects["F1"]) != 2 {
+		t.Errorf("without results: got Selected %v, F1 effects %v", result.Selected, result.MainEffects["F1"])
+	}
+	offDesign := Trial{Control: map[string]float64{"F1": 3}}
+	exp.Results = []TrialResult{{Trial: offDesign, Observations: []float64{1}}}
+	if result := exp.Analyze(); len(result.Selected) != 0 {
+		t.Errorf("without matching results: got Selected %v", result.Selected)
+	}
+	exp.Results = nil
+	for _, trial := range exp.GenerateTrials() {
+		y := 1 + 9*(trial.Control["F3"]-1) + 2*(trial.Control["F7"]-1)
+		exp.AddResult(trial, []float64{y})
+	}
+	result := exp.Analyze()
+	if result.Method != MethodForwardSelection {
+		t.Errorf("Method: got %q", result.Method)
+	}
+	if len(result.Selected) == 0 || result.Selected[0] != "F3" {
+		t.Errorf("Selected: got %v, want F3 first", result.Selected)
+	}
+	if result.OptimalLevels["F3"] != 1 {
+		t.Errorf("optimal F3: got %v, want 1", result.OptimalLevels["F3"])
+	}
+	for _, f := range factors {
+		if !slices.Contains(result.Selected, f.Name) && result.ANOVA.FactorSS[f.Name] != 0 {
+			t.Errorf("unselected %s has SS %v", f.Name, result.ANOVA.FactorSS[f.Name])
+		}
+	}
+	if _, err := GenerateSupersaturated(1); err == nil {
+		t.Error("GenerateSupersaturated accepted 1 factor")
+	}
+}
+
+func TestDefinitiveScreening(t *testing.T) {
+	for _, k := range []int{4, 5, 7, 10} {
+		design, err := GenerateDefinitiveScreening(k)
+		if err != nil {
+			t.Fatalf("GenerateDefinitiveScreening(%d): %v", k, err)
+		}
+		// Main effects are orthogonal to each other, to the quadratic effects and to the
+		// two-factor interactions.
+		for a := 0; a < k; a++ {
+			for b := 0; b < k; b++ {
+				for c := b; c < k; c++ {
+					lin, second := 0, 0
+					for _, row := range design {
+						xa, xb, xc := row[a]-2, row[b]-2, row[c]-2
+						lin += xa * xb
+						second += xa * xb * xc
+					}
+					if a != b && lin != 0 || second != 0 {
+						t.Fatalf("%d factors: columns %d, %d, %d are not orthogonal", k, a, b, c)
+					}
+				}
+			}
+		}
+	}
+	if design, _ := GenerateDefinitiveScreening(6); len(design) != 13 {
+		t.Errorf("6 factors: got %d runs, want 13", len(design))
+	}
+
+	var factors []ControlFactor
+	for _, name := range []string{"A", "B", "C", "D", "E", "F"} {
+		factors = append(factors, ControlFactor{Name: name, Levels: []float64{10, 20, 30}})
+	}
+	exp, err := NewDefinitiveScreeningExperiment(SmallerTheBetter{}, factors, nil)
+	if err != nil {
+		t.Fatalf("NewDefinitiveScreeningExperiment: %v", err)
+	}
+	checkQuadraticWithoutResults(t, exp, MethodDefinitiveScreening)
+	for _, trial := range exp.GenerateTrials() {
+		a, c := trial.Control["A"]/10-2, trial.Control["C"]/10-2
+		// Smallest response at the middle level of C: pure curvature.
+		y := 10 + 4*a + 6*c*c
+		exp.AddResult(trial, []float64{y})
+	}
+	result := exp.Analyze()
+	if result.Method != MethodDefinitiveScreening {
+		t.Errorf("Method: got %q", result.Method)
+	}
+	if !slices.Contains(result.Selected, "A") || !slices.Contains(result.Selected, "C²") {
+		t.Errorf("Selected: got %v, want A and C²", result.Selected)
+	}
+	if result.OptimalLevels["A"] != 10 || result.OptimalLevels["C"] != 20 {
+		t.Errorf("OptimalLevels: got A=%v C=%v, want A=10 C=20", result.OptimalLevels["A"], result.OptimalLevels["C"])
+	}
+	if _, err := GenerateDefinitiveScreening(1); err == nil {
+		t.Error("GenerateDefinitiveScreening accepted 1 factor")
+	}
+}
+
+// checkQuadraticWithoutResults verifies that a quadratic design analyzes without
+// panicking before any results are recorded and with results for only a few runs; exp is
+// left without results.
+func checkQuadraticWithoutResults[P any](t *testing.T, exp *Experiment[P], method string) {
+	t.Helper()
+	if result := exp.Analyze(); result.Method != method || len(result.Selected) != 0 {
+		t.Errorf("%s without results: got Method %q, Selected %v", method, result.Method, result.Selected)
+	}
+	for _, trial := range exp.GenerateTrials()[:3] {
+		exp.AddResult(trial, []float64{float64(trial.ID)})
+	}
+	if result := exp.Analyze(); result.Method != method || len(result.MainEffects) != len(exp.ControlFactors) {
+		t.Errorf("%s with partial results: got Method %q, %d main effects", method, result.Method, len(result.MainEffects))
+	}
+	exp.Results = nil
+}
+
+func TestAddHistogramResult(t *testing.T) {
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}}
+	for _, goal := range []OptimizationGoal{SmallerTheBetter{}, PercentileGoal{Goal: SmallerTheBetter{}, Percentile: 99}} {
+		exp, err := NewExperimentFromFactors(goal, factors, L4, nil)
+		if err != nil {
+			t.Fatalf("NewExperimentFromFactors: %v", err)
+		}
+		for _, trial := range exp.GenerateTrials() {
+			h, _ := NewHdrHistogram(1, 1000000, 3)
+			for v := int64(1); v <= 1000; v++ {
+				h.RecordValues(v*int64(trial.Control["A"]), 1)
+			}
+			if err := exp.AddHistogramResult(trial, h); err != nil {
+				t.Fatalf("AddHistogramResult: %v", err)
+			}
+		}
+		want := histogramObservations
+		if _, ok := goal.(PercentileGoal); ok {
+			want = 1
+		}
+		if n := len(exp.Results[0].Observations); n != want {
+			t.Errorf("%v: got %d observations per histogram, want %d", goal, n, want)
+		}
+		if got := exp.Analyze().OptimalLevels["A"]; got != 1 {
+			t.Errorf("%v: optimal A = %v, want 1", goal, got)
+		}
+	}
+}
+
+func TestFollowUpBoxBehnken(t *testing.T) {
+	for k, runs := range map[int]int{3: 13, 4: 25, 5: 41, 6: 49, 7: 57} {
+		design, err := GenerateBoxBehnken(k)
+		if err != nil || len(design) != runs {
+			t.Errorf("GenerateBoxBehnken(%d): got %d runs (%v), want %d", k, len(design), err, runs)
+		}
+	}
+
+	factors := []ControlFactor{
+		{Name: "Workers", Levels: []float64{2, 4, 8}, Rules: &LevelRules{PowerOfTwo: true}},
+		{Name: "Batch", Levels: []float64{10, 20, 30}},
+		{Name: "Ratio", Levels: []float64{0.2, 0.4, 0.6}},
+		{Name: "Flag", Levels: []float64{0, 1, 2}},
+	}
+	screen, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L9, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range screen.GenerateTrials() {
+		c := trial.Control
+		screen.AddResult(trial, []float64{100/c["Workers"] + math.Abs(c["Batch"]-20) + 20*c["Ratio"] + 0.1*c["Flag"]})
+	}
+	follow, err := screen.FollowUpBoxBehnken(3)
+	if err != nil {
+		t.Fatalf("FollowUpBoxBehnken: %v", err)
+	}
+	if len(follow.OrthogonalArray) != 13 {
+		t.Errorf("got %d runs, want 13", len(follow.OrthogonalArray))
+	}
+	want := map[string][]float64{
+		"Workers": {4, 8, 16},
+		"Batch":   {10, 20, 30},
+		"Ratio":   {0, 0.2, 0.4},
+	}
+	for _, f := range follow.ControlFactors {
+		if f.Name == "Flag" {
+			if len(f.Levels) != 1 {
+				t.Errorf("Flag is not held fixed: levels %v", f.Levels)
+			}
+			continue
+		}
+		if !slices.EqualFunc(f.Levels, want[f.Name], func(a, b float64) bool { return math.Abs(a-b) < 1e-12 }) {
+			t.Errorf("%s levels: got %v, want %v", f.Name, f.Levels, want[f.Name])
+		}
+	}
+
+	checkQuadraticWithoutResults(t, follow, MethodBoxBehnken)
+	for _, trial := range follow.GenerateTrials() {
+		c := trial.Control
+		follow.AddResult(trial, []float64{100/c["Workers"] + math.Abs(c["Batch"]-20) + 20*c["Ratio"] + 1})
+	}
+	result := follow.Analyze()
+	if result.Method != MethodBoxBehnken {
+		t.Errorf("Method: got %q", result.Method)
+	}
+	if result.OptimalLevels["Batch"] != 20 || result.OptimalLevels["Workers"] != 16 {
+		t.Errorf("OptimalLevels: got %v", result.OptimalLevels)
+	}
+	if _, err := screen.FollowUpBoxBehnken(5); err == nil {
+		t.Error("FollowUpBoxBehnken accepted more factors than the experiment has")
+	}
+}
+
+func TestCentralComposite(t *testing.T) {
+	for _, tc := range []struct {
+		k     int
+		kind  CentralCompositeType
+		runs  int
+		alpha float64
+	}{
+		{3, CentralCompositeFaceCentered, 15, 1},
+		{3, CentralCompositeRotatable, 15, math.Pow(8, 0.25)},
+		{5, CentralCompositeRotatable, 27, 2},
+	} {
+		design, alpha, err := GenerateCentralComposite(tc.k, tc.kind)
+		if err != nil {
+			t.Fatalf("GenerateCentralComposite(%d, %s): %v", tc.k, tc.kind, err)
+		}
+		if len(design) != tc.runs || math.Abs(alpha-tc.alpha) > 1e-12 {
+			t.Errorf("GenerateCentralComposite(%d, %s): got %d runs, α = %v; want %d, %v", tc.k, tc.kind, len(design), alpha, tc.runs, tc.alpha)
+		}
+	}
+
+	factors := []ContinuousFactor{{Name: "X", Min: 0, Max: 10}, {Name: "Y", Min: 100, Max: 200}}
+	exp, err := NewCentralCompositeExperiment(SmallerTheBetter{}, factors, CentralCompositeRotatable, nil)
+	if err != nil {
+		t.Fatalf("NewCentralCompositeExperiment: %v", err)
+	}
+	x := exp.ControlFactors[0].Levels
+	if len(x) != 5 || x[0] != 0 || x[2] != 5 || x[4] != 10 || math.Abs(x[3]-(5+5/math.Sqrt2)) > 1e-9 {
+		t.Errorf("X levels: got %v", x)
+	}
+	checkQuadraticWithoutResults(t, exp, MethodCentralComposite)
+	for _, trial := range exp.GenerateTrials() {
+		c := trial.Control
+		// Smallest response at X = 5 and Y = 200.
+		y := 10 + (c["X"]-5)*(c["X"]-5) + (200-c["Y"])/10
+		exp.AddResult(trial, []float64{y})
+	}
+	result := exp.Analyze()
+	if result.Method != MethodCentralComposite {
+		t.Errorf("Method: got %q", result.Method)
+	}
+	if result.OptimalLevels["X"] != 5 || result.OptimalLevels["Y"] != 200 {
+		t.Errorf("OptimalLevels: got %v, want X=5 Y=200", result.OptimalLevels)
+	}
+	if _, _, err := GenerateCentralComposite(3, "spherical"); err == nil {
+		t.Error("GenerateCentralComposite accepted an unknown type")
+	}
+}
+
+func TestFoldover(t *testing.T) {
+	ff, err := GenerateFractionalFactorial(7, 3)
+	if err != nil {
+		t.Fatalf("GenerateFractionalFactorial: %v", err)
+	}
+	var factors []ControlFactor
+	for _, name := range []string{"A", "B", "C", "D", "E", "F", "G"} {
+		factors = append(factors, ControlFactor{Name: name, Levels: []float64{0, 1}})
+	}
+	exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, ff.Array, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{10 + trial.Control["A"]})
+	}
+
+	full, report, err := exp.Foldover()
+	if err != nil {
+		t.Fatalf("Foldover: %v", err)
+	}
+	if len(full.OrthogonalArray) != 16 || len(report.NewTrials) != 8 || report.NewTrials[0].ID != 9 || len(full.Results) != 8 {
+		t.Fatalf("got %d runs, %d new trials from ID %d, %d results", len(full.OrthogonalArray), len(report.NewTrials), report.NewTrials[0].ID, len(full.Results))
+	}
+	if len(report.AliasesBefore) != 7 {
+		t.Errorf("AliasesBefore: got %v, want 7 groups", report.AliasesBefore)
+	}
+	for _, group := range report.AliasesAfter {
+		for _, effect := range group {
+			if !strings.Contains(effect, "×") {
+				t.Errorf("main effect %s is still aliased: %v", effect, group)
+			}
+		}
+	}
+	if len(report.DeAliased) != 7 || report.DeAliased[0] != "A" {
+		t.Errorf("DeAliased: got %v, want the 7 main effects", report.DeAliased)
+	}
+	for _, trial := range report.NewTrials {
+		full.AddResult(trial, []float64{10 + trial.Control["A"]})
+	}
+	if got := full.Analyze().OptimalLevels["A"]; got != 0 {
+		t.Errorf("optimal A: got %v, want 0", got)
+	}
+
+	_, single, err := exp.Foldover("A")
+	if err != nil {
+		t.Fatalf("Foldover(A): %v", err)
+	}
+	for _, effect := range []string{"A", "A×B", "A×G"} {
+		if !slices.Contains(single.DeAliased, effect) {
+			t.Errorf("single-factor foldover: %s not de-aliased in %v", effect, single.DeAliased)
+		}
+	}
+	if slices.Contains(single.DeAliased, "B") {
+		t.Errorf("single-factor foldover de-aliased B: %v", single.DeAliased)
+	}
+	if _, _, err := exp.Foldover("Z"); err == nil {
+		t.Error("Foldover accepted an unknown factor")
+	}
+}
+
+func TestDynamicCharacteristic(t *testing.T) {
+	goal := DynamicCharacteristic{Signals: []float64{1, 2, 3}}
+	// y = 2M exactly, then with residuals of ±1 around β = 2.
+	if snr := goal.CalculateSNR([]float64{2, 4, 6}); !math.IsInf(snr, 1) {
+		t.Errorf("exact fit: got SNR %v, want +Inf", snr)
+	}
+	obs := []float64{3, 4, 6, 1, 4, 6}
+	if beta := goal.Slope(obs); !almostEqual(beta, 2) {
+		t.Errorf("Slope: got %v, want 2", beta)
+	}
+	if snr, want := goal.CalculateSNR(obs), 10*math.Log10(4/(2.0/5)); !almostEqual(snr, want) {
+		t.Errorf("SNR: got %v, want %v", snr, want)
+	}
+	if snr := goal.CalculateSNR([]float64{1, 2}); !math.IsNaN(snr) {
+		t.Errorf("partial replicate: got SNR %v, want NaN", snr)
+	}
+
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{-1, 1}}}
+	exp, err := NewExperimentFromFactors(goal, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		// A = 1 halves the noise's effect on the response; B scales the slope.
+		c, n := trial.Control, trial.Noise["N"]
+		response := make([]float64, len(goal.Signals))
+		for k, m := range goal.Signals {
+			response[k] = c["B"]*m + n*c["A"]*float64(k%2)
+		}
+		if err := exp.AddDynamicResult(trial, response); err != nil {
+			t.Fatalf("AddDynamicResult: %v", err)
+		}
+	}
+	if got := exp.Analyze().OptimalLevels; got["A"] != 1 || got["B"] != 2 {
+		t.Errorf("OptimalLevels: got %v, want A=1 B=2", got)
+	}
+	if err := exp.AddDynamicResult(exp.GenerateTrials()[0], []float64{1, 2}); err == nil {
+		t.Error("AddDynamicResult accepted a response per signal level too few")
+	}
+
+	var saved strings.Builder
+	if err := exp.Save(&saved); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadExperiment[struct{}](strings.NewReader(saved.String()))
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	if g, ok := loaded.Goal.(DynamicCharacteristic); !ok || !slices.Equal(g.Signals, goal.Signals) {
+		t.Errorf("loaded goal: got %#v", loaded.Goal)
+	}
+}
+
+func TestOperatingWindow(t *testing.T) {
+	// Lower thresholds 1 and 2, upper thresholds 10 and 20.
+	obs := []float64{1, 10, 2, 20}
+	want := -10 * math.Log10((1.0+4)/2*(1.0/100+1.0/400)/2)
+	if snr := (OperatingWindow{}).CalculateSNR(obs); !almostEqual(snr, want) {
+		t.Errorf("SNR: got %v, want %v", snr, want)
+	}
+
+	factors := []ControlFactor{{Name: "Pressure", Levels: []float64{1, 2}}, {Name: "Friction", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(OperatingWindow{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		// Friction 2 lowers the misfeed threshold; pressure 1 raises the multifeed one.
+		c := trial.Control
+		lower := []float64{4 - c["Friction"], 4.5 - c["Friction"]}
+		upper := []float64{12 - 3*c["Pressure"], 13 - 3*c["Pressure"]}
+		if err := exp.AddOperatingWindowResult(trial, lower, upper); err != nil {
+			t.Fatalf("AddOperatingWindowResult: %v", err)
+		}
+	}
+	if got := exp.Analyze().OptimalLevels; got["Pressure"] != 1 || got["Friction"] != 2 {
+		t.Errorf("OptimalLevels: got %v, want Pressure=1 Friction=2", got)
+	}
+	if err := exp.AddOperatingWindowResult(exp.GenerateTrials()[0], []float64{5}, []float64{3}); err == nil {
+		t.Error("AddOperatingWindowResult accepted a lower threshold above the upper one")
+	}
+}
+
+func TestFractionDefective(t *testing.T) {
+	if snr, want := (FractionDefective{}).CalculateSNR([]float64{0.1, 0.3}), -10*math.Log10(0.2/0.8); !almostEqual(snr, want) {
+		t.Errorf("SNR: got %v, want %v", snr, want)
+	}
+	if snr := (FractionDefective{}).CalculateSNR([]float64{0, 1, 0, 0}); !almostEqual(snr, -10*math.Log10(0.25/0.75)) {
+		t.Errorf("pass/fail outcomes: got SNR %v", snr)
+	}
+	if snr := (FractionDefective{}).CalculateSNR([]float64{0, 0}); !math.IsInf(snr, 1) {
+		t.Errorf("no defects: got SNR %v, want +Inf", snr)
+	}
+
+	factors := []ControlFactor{{Name: "Retries", Levels: []float64{1, 3}}, {Name: "Timeout", Levels: []float64{1, 2}}}
+	noise := []NoiseFactor{{Name: "Load", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(FractionDefective{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		// More retries cut failures; load raises them.
+		defects := int(40 * trial.Noise["Load"] / trial.Control["Retries"])
+		if err := exp.AddPassFailResult(trial, defects, 1000); err != nil {
+			t.Fatalf("AddPassFailResult: %v", err)
+		}
+	}
+	if got := exp.Analyze().OptimalLevels["Retries"]; got != 3 {
+		t.Errorf("optimal Retries: got %v, want 3", got)
+	}
+	if err := exp.AddPassFailResult(exp.GenerateTrials()[0], 5, 4); err == nil {
+		t.Error("AddPassFailResult accepted more defects than outcomes")
+	}
+}
+
+// TestGoalFunc verifies that a custom SNR formula drives the analysis like a built-in
+// goal, and that experiments using it refuse to be saved.
+func TestGoalFunc(t *testing.T) {
+	// Energy-weighted latency: observations alternate latency and joules per request.
+	goal := GoalFunc("Energy-weighted latency", func(obs []float64) float64 {
+		cost := 0.0
+		for i := 0; i+1 < len(obs); i += 2 {
+			cost += obs[i] * obs[i+1]
+		}
+		return -10 * math.Log10(cost/float64(len(obs)/2))
+	})
+	if goal.String() != "Energy-weighted latency" {
+		t.Errorf("String: got %q", goal.String())
+	}
+
+	factors := []ControlFactor{{Name: "Cores", Levels: []float64{2, 8}}, {Name: "Turbo", Levels: []float64{0, 1}}}
+	exp, err := NewExperimentFromFactors(goal, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		// More cores cut latency more than they add energy; turbo costs more energy than
+		// it saves in latency.
+		cores, turbo := trial.Control["Cores"], trial.Control["Turbo"]
+		latency, joules := 100/cores/(1+0.2*turbo), (cores/4+1)*(1+turbo)
+		exp.AddResult(trial, []float64{latency, joules})
+	}
+	if got := exp.Analyze().OptimalLevels; got["Cores"] != 8 || got["Turbo"] != 0 {
+		t.Errorf("OptimalLevels: got %v, want Cores=8, Turbo=0", got)
+	}
+	if err := exp.Save(&strings.Builder{}); err == nil {
+		t.Error("Save accepted a GoalFunc goal")
+	}
+}
+
+// TestPairedDifference verifies the standardized mean difference SNR and that it ranks
+// a consistent improvement above a larger but erratic one.
+func TestPairedDifference(t *testing.T) {
+	if snr, want := (PairedDifference{}).CalculateSNR([]float64{-3, -5}), 4/math.Sqrt(2); !almostEqual(snr, want) {
+		t.Errorf("SNR: got %v, want %v", snr, want)
+	}
+	if snr := (PairedDifference{Larger: true}).CalculateSNR([]float64{-3, -5}); !almostEqual(snr, -4/math.Sqrt(2)) {
+		t.Errorf("larger-the-better SNR: got %v", snr)
+	}
+	if snr := (PairedDifference{}).CalculateSNR([]float64{2, 2}); !math.IsInf(snr, -1) {
+		t.Errorf("constant regression: got SNR %v, want -Inf", snr)
+	}
+
+	factors := []ControlFactor{{Name: "Pool", Levels: []float64{1, 2}}, {Name: "Batch", Levels: []float64{1, 2}}}
+	noise := []NoiseFactor{{Name: "Traffic", Levels: []float64{1, 2, 3}}}
+	exp, err := NewExperimentFromFactors(PairedDifference{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		// Pool 2 cuts latency by 5 ms at any traffic; Batch 2 cuts it by 8 ms on average
+		// but swings with traffic.
+		diff := -5*(trial.Control["Pool"]-1) - 8*(trial.Control["Batch"]-1)*(trial.Noise["Traffic"]-1) + 0.1*trial.Noise["Traffic"]
+		exp.AddResult(trial, []float64{diff})
+	}
+	if got := exp.Analyze().OptimalLevels; got["Pool"] != 2 || got["Batch"] != 1 {
+		t.Errorf("OptimalLevels: got %v, want Pool=2, Batch=1", got)
+	}
+
+	exp.Goal = PairedDifference{Larger: true}
+	var saved strings.Builder
+	if err := exp.Save(&saved); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadExperiment[struct{}](strings.NewReader(saved.String()))
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	if loaded.Goal != exp.Goal {
+		t.Errorf("loaded goal: got %v, want %v", loaded.Goal, exp.Goal)
+	}
+}
+
+// TestInterleaveBaseline verifies the ABBA ordering and that linear drift cancels from
+// the recorded differences.
+func TestInterleaveBaseline(t *testing.T) {
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(PairedDifference{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	baseline := map[string]float64{"A": 2, "B": 2}
+	blocks, err := exp.InterleaveBaseline(baseline)
+	if err != nil {
+		t.Fatalf("InterleaveBaseline: %v", err)
+	}
+	if len(blocks) != 8 {
+		t.Fatalf("got %d blocks, want 8", len(blocks))
+	}
+	for i, b := range blocks {
+		if want := float64(i/4 + 1); b.Candidate.Noise["N"] != want || b.Baseline.Noise["N"] != want {
+			t.Errorf("block %d: noise N = %v, want %v", i, b.Candidate.Noise["N"], want)
+		}
+	}
+
+	// The system slows down steadily, by 3 per run, which would favor whatever runs first.
+	clock := 0.0
+	latency := func(tr Trial) float64 {
+		clock++
+		return 10*tr.Control["A"] + tr.Control["B"] + tr.Noise["N"] + 3*clock
+	}
+	for _, b := range blocks {
+		var runs [4][]float64
+		for r, tr := range b.Runs() {
+			runs[r] = []float64{latency(tr), latency(tr)}
+		}
+		if err := exp.AddABBAResult(b, runs); err != nil {
+			t.Fatalf("AddABBAResult: %v", err)
+		}
+	}
+	for _, r := range exp.Results {
+		want := 10*(r.Trial.Control["A"]-2) + r.Trial.Control["B"] - 2
+		if !slices.Equal(r.Observations, []float64{want, want}) {
+			t.Errorf("trial %d: got %v, want drift-free differences %v", r.Trial.ID, r.Observations, want)
+		}
+	}
+
+	if _, err := exp.InterleaveBaseline(map[string]float64{"A": 1}); err == nil {
+		t.Error("InterleaveBaseline accepted a baseline missing factor B")
+	}
+}
+
+// TestWeightedObservations verifies that weights act like repeated observations in all
+// three mean-square SNRs and that they decide the analysis.
+func TestWeightedObservations(t *testing.T) {
+	for _, goal := range []WeightedGoal{SmallerTheBetter{}, LargerTheBetter{}, NominalTheBest{Target: 2}} {
+		got := goal.CalculateWeightedSNR([]float64{1, 4}, []float64{2, 1})
+		if want := goal.CalculateSNR([]float64{1, 1, 4}); !almostEqual(got, want) {
+			t.Errorf("%s: weighted SNR %v, want %v", goal, got, want)
+		}
+	}
+
+	factors := []ControlFactor{{Name: "Cache", Levels: []float64{1, 2}}, {Name: "Pool", Levels: []float64{1, 2}}}
+	noise := []NoiseFactor{{Name: "Region", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	exp.GroupNoiseReplicates(true)
+	for _, trial := range exp.GenerateTrials() {
+		// Cache 2 is slower in the small region 1 and faster in region 2, which carries
+		// 90% of the traffic.
+		latency, share := 10.0, 0.9
+		if trial.Noise["Region"] == 1 {
+			latency, share = latency+8*(trial.Control["Cache"]-1), 0.1
+		} else {
+			latency -= 3 * (trial.Control["Cache"] - 1)
+		}
+		if err := exp.AddWeightedResult(trial, []float64{latency}, []float64{share}); err != nil {
+			t.Fatalf("AddWeightedResult: %v", err)
+		}
+	}
+	if got := exp.Analyze().OptimalLevels["Cache"]; got != 2 {
+		t.Errorf("optimal Cache: got %v, want 2 for the heavy region", got)
+	}
+	if w := exp.Results[0].Weights; !slices.Equal(w, []float64{0.1, 0.9}) {
+		t.Errorf("grouped weights: got %v, want [0.1 0.9]", w)
+	}
+
+	if err := exp.AddWeightedResult(exp.GenerateTrials()[0], []float64{1, 2}, []float64{1}); err == nil {
+		t.Error("AddWeightedResult accepted a weight count different from the observations")
+	}
+}
@@ -0,0 +1,145 @@
+package taguchi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportAsGo writes a self-contained Go source file (package design) with a NewDesign
+// function that reconstructs the experiment's goal, control factors, orthogonal array
+// and noise factors, for freezing a reviewed design into a repository after interactive
+// planning. Recorded results are not exported; use Save for those. Only the built-in
+// goals can be exported.
+func ExportAsGo[P any](w io.Writer, e *Experiment[P]) error {
+	saved, err := encodeGoal(e.Goal)
+	if err != nil {
+		return err
+	}
+	goal, err := decodeGoal(saved)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	b.WriteString("package design\n\n")
+	b.WriteString("import \"github.com/marijaaleksic/taguchi\"\n\n")
+	b.WriteString("// NewDesign reconstructs the exported experiment design.\n")
+	b.WriteString("func NewDesign() (*taguchi.Experiment[struct{}], error) {\n")
+	b.WriteString("exp, err := taguchi.NewExperimentFromFactorsUsingArray(\n")
+	b.WriteString(goGoal(goal) + ",\n")
+
+	needPtr := false
+	b.WriteString("[]taguchi.ControlFactor{\n")
+	for _, f := range e.ControlFactors {
+		fmt.Fprintf(&b, "{Name: %q, Levels: %s", f.Name, goFloats(f.Levels))
+		if f.Duration {
+			b.WriteString(", Duration: true")
+		}
+		if f.Labels != nil {
+			fmt.Fprintf(&b, ", Labels: %#v", f.Labels)
+		}
+		if r := f.Rules; r != nil {
+			var fields []string
+			if r.Min != nil {
+				fields = append(fields, "Min: float64Ptr("+goFloat(*r.Min)+")")
+				needPtr = true
+			}
+			if r.Max != nil {
+				fields = append(fields, "Max: float64Ptr("+goFloat(*r.Max)+")")
+				needPtr = true
+			}
+			if r.Integer {
+				fields = append(fields, "Integer: true")
+			}
+			if r.PowerOfTwo {
+				fields = append(fields, "PowerOfTwo: true")
+			}
+			fmt.Fprintf(&b, ", Rules: &taguchi.LevelRules{%s}", strings.Join(fields, ", "))
+		}
+		if f.Components != nil {
+			b.WriteString(", Components: map[string][]float64{")
+			for _, name := range sortedKeys(f.Components) {
+				fmt.Fprintf(&b, "%q: %s, ", name, goFloats(f.Components[name]))
+			}
+			b.WriteString("}")
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString("},\n")
+
+	b.WriteString("[][]int{\n")
+	oa := e.array()
+	for i := 0; i < oa.Rows(); i++ {
+		row := oa.Row(i)
+		parts := make([]string, len(row))
+		for j, v := range row {
+			parts[j] = strconv.Itoa(v)
+		}
+		fmt.Fprintf(&b, "{%s},\n", strings.Join(parts, ", "))
+	}
+	b.WriteString("},\n")
+
+	if len(e.NoiseFactors) == 0 {
+		b.WriteString("nil,\n")
+	} else {
+		b.WriteString("[]taguchi.NoiseFactor{\n")
+		for _, f := range e.NoiseFactors {
+			fmt.Fprintf(&b, "{Name: %q, Levels: %s},\n", f.Name, goFloats(f.Levels))
+		}
+		b.WriteString("},\n")
+	}
+	b.WriteString(")\n")
+	b.WriteString("if err != nil {\nreturn nil, err\n}\n")
+	if e.noiseLimit > 0 {
+		fmt.Fprintf(&b, "exp.LimitNoiseCombinations(%d, %d)\n", e.noiseLimit, e.noiseSeed)
+	}
+	b.WriteString("return exp, nil\n}\n")
+	if needPtr {
+		b.WriteString("\nfunc float64Ptr(v float64) *float64 { return &v }\n")
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("format exported design: %w", err)
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+// goFloat formats v as a Go float64 literal.
+func goFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// goGoal formats a built-in goal as a Go composite literal.
+func goGoal(goal OptimizationGoal) string {
+	switch g := goal.(type) {
+	case NominalTheBest:
+		return "taguchi.NominalTheBest{Target: " + goFloat(g.Target) + "}"
+	case DynamicCharacteristic:
+		return "taguchi.DynamicCharacteristic{Signals: " + goFloats(g.Signals) + "}"
+	case PercentileGoal:
+		return "taguchi.PercentileGoal{Goal: " + goGoal(g.Goal) + ", Percentile: " + goFloat(g.Percentile) + "}"
+	case PairedDifference:
+		if g.Larger {
+			return "taguchi.PairedDifference{Larger: true}"
+		}
+	case *goalFunc:
+		// The formula itself cannot be exported; leave a placeholder to fill in.
+		return fmt.Sprintf("taguchi.GoalFunc(%q, func(obs []float64) float64 { panic(\"supply the SNR formula\") })", g.name)
+	}
+	return fmt.Sprintf("%T{}", goal)
+}
+
+// goFloats formats levels as a Go []float64 composite literal.
+func goFloats(levels []float64) string {
+	parts := make([]string, len(levels))
+	for i, v := range levels {
+		parts[i] = goFloat(v)
+	}
+	return "[]float64{" + strings.Join(parts, ", ") + "}"
+}
@@ -0,0 +1,29 @@
+package taguchi
+
+// Design is the stable view of an experiment layout: the trials that have to be run.
+type Design interface {
+	GenerateTrials() []Trial
+}
+
+// Runner is the stable view used while executing trials and recording their outcomes.
+type Runner interface {
+	AddResult(trial Trial, observations []float64)
+}
+
+// Analyzer is the stable view used to analyze recorded results. AnalysisResult is part
+// of the stable surface: new analyses add fields to it, but existing fields keep their
+// names and meaning, so code reading them is not broken by later releases. Construct it
+// with keyed fields only.
+type Analyzer interface {
+	Analyze() AnalysisResult
+}
+
+// Study combines Design, Runner and Analyzer. Downstream code that depends on Study
+// rather than *Experiment[P] is insulated from changes to the experiment internals.
+type Study interface {
+	Design
+	Runner
+	Analyzer
+}
+
+var _ Study = (*Experiment[struct{}])(nil)
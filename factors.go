@@ -0,0 +1,8 @@
+package taguchi
+
+// BoolFactor builds an on/off control factor, such as a feature flag, with levels 0
+// (off) and 1 (on). A bool field of the same name in the params struct receives the
+// level as false or true.
+func BoolFactor(name string) ControlFactor {
+	return ControlFactor{Name: name, Levels: []float64{0, 1}}
+}
@@ -0,0 +1,62 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// FlagdFile is a FlagProvider writing the flag definition file of flagd, the OpenFeature
+// flag daemon, which reloads it on change; applications evaluate the flags through any
+// OpenFeature SDK with the flagd provider. Each flag is written with a single variant,
+// "taguchi", holding its value. Flags in the file that are not set are kept.
+// Path: The flag definition file flagd watches.
+type FlagdFile struct {
+	Path string
+}
+
+// SetFlags rewrites the file with the given flags.
+func (f FlagdFile) SetFlags(flags map[string]any) error {
+	doc := map[string]json.RawMessage{}
+	data, err := os.ReadFile(f.Path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return fmt.Errorf("decode flagd file %s: %w", f.Path, err)
+		}
+	}
+	defined := map[string]json.RawMessage{}
+	if raw, ok := doc["flags"]; ok {
+		if err := json.Unmarshal(raw, &defined); err != nil {
+			return fmt.Errorf("decode flagd file %s: %w", f.Path, err)
+		}
+	}
+	for key, value := range flags {
+		flag, err := json.Marshal(map[string]any{
+			"state":          "ENABLED",
+			"variants":       map[string]any{"taguchi": value},
+			"defaultVariant": "taguchi",
+		})
+		if err != nil {
+			return fmt.Errorf("encode flag %s: %w", key, err)
+		}
+		defined[key] = flag
+	}
+	if doc["flags"], err = json.Marshal(defined); err != nil {
+		return err
+	}
+	if _, ok := doc["$schema"]; !ok {
+		doc["$schema"] = json.RawMessage(`"https://flagd.dev/schema/v0/flags.json"`)
+	}
+	data, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(f.Path, append(data, '\n'), 0o644)
+}
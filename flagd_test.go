@@ -0,0 +1,33 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestFlagdFile verifies that SetFlags writes each flag with a single default variant
+// and keeps the flags already defined in the file.
+func TestFlagdFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.json")
+	os.WriteFile(path, []byte(`{"flags": {"other": {"state": "ENABLED", "variants": {"on": true}, "defaultVariant": "on"}}}`), 0o644)
+	if err := (FlagdFile{Path: path}).SetFlags(map[string]any{"cache-size": 64.0}); err != nil {
+		t.Fatalf("FlagdFile.SetFlags: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	var doc struct {
+		Flags map[string]struct {
+			Variants       map[string]any
+			DefaultVariant string
+		}
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("flagd file: %v", err)
+	}
+	if f := doc.Flags["cache-size"]; f.Variants[f.DefaultVariant] != 64.0 || len(doc.Flags) != 2 {
+		t.Errorf("flagd file: got %s", data)
+	}
+}
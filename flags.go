@@ -0,0 +1,122 @@
+package taguchi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"time"
+)
+
+// FlagProvider applies feature flag values, so that a trial's factor levels reach a
+// running system without a redeploy.
+type FlagProvider interface {
+	SetFlags(flags map[string]any) error
+}
+
+// ObservationSource pulls a trial's observations from a metrics backend, for the window
+// in which the trial's configuration was live.
+type ObservationSource interface {
+	Observations(st ScheduledTrial, start, end time.Time) ([]float64, error)
+}
+
+// FlagRunner runs trials on a live system through feature flags: it pushes each trial's
+// levels to Provider, lets the system settle, keeps the configuration live for Window
+// and pulls the observations for that window from Source. Baseline flag values are
+// restored after every trial, also when it fails, so the system returns to its known
+// good configuration between trials.
+// Provider: Where flag values are pushed.
+// Source: Where observations are pulled from.
+// Flags: The flag values for a trial; nil sets one flag per control factor, named after
+// it, to its level.
+// Baseline: The flag values restored after each trial.
+// Settle: How long to wait after pushing flags before the measurement window opens,
+// e.g. for flag propagation and cache warm-up.
+// Window: How long the configuration stays live while it is measured.
+// Control: For canary experiments, where Provider targets only a canary slice, the
+// source of the baseline slice's observations for the same window; the trial's
+// observations become the paired differences Source minus Control (see CanaryMeasure).
+type FlagRunner struct {
+	Provider FlagProvider
+	Source   ObservationSource
+	Control  ObservationSource
+	Flags    func(ScheduledTrial) map[string]any
+	Baseline map[string]any
+	Settle   time.Duration
+	Window   time.Duration
+
+	sleep func(time.Duration)
+	now   func() time.Time
+}
+
+// Measure is a Scheduler.Run measurement function running the trial through flags.
+func (r *FlagRunner) Measure(st ScheduledTrial) (observations []float64, err error) {
+	sleep, now := r.sleep, r.now
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	if now == nil {
+		now = time.Now
+	}
+	flags := map[string]any{}
+	if r.Flags != nil {
+		flags = r.Flags(st)
+	} else {
+		for name, level := range st.Trial.Control {
+			flags[name] = level
+		}
+	}
+
+	defer func() {
+		if r.Baseline == nil {
+			return
+		}
+		if restoreErr := r.Provider.SetFlags(r.Baseline); restoreErr != nil {
+			err = errors.Join(err, fmt.Errorf("restore baseline flags: %w", restoreErr))
+		}
+	}()
+	if err := r.Provider.SetFlags(flags); err != nil {
+		return nil, fmt.Errorf("set flags: %w", err)
+	}
+	sleep(r.Settle)
+	start := now()
+	sleep(r.Window)
+	end := now()
+	if r.Control == nil {
+		return r.Source.Observations(st, start, end)
+	}
+	return CanaryMeasure(func(st ScheduledTrial) ([]float64, error) {
+		return r.Source.Observations(st, start, end)
+	}, func(st ScheduledTrial) ([]float64, error) {
+		return r.Control.Observations(st, start, end)
+	})(st)
+}
+
+// StaticFlags is an in-process FlagProvider: the application reads the values with
+// Value, e.g. from an OpenFeature provider or its own configuration hooks. It is safe
+// for concurrent use.
+type StaticFlags struct {
+	mu    sync.RWMutex
+	flags map[string]any
+}
+
+// SetFlags sets the given flags, leaving the others unchanged.
+func (s *StaticFlags) SetFlags(flags map[string]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.flags == nil {
+		s.flags = make(map[string]any)
+	}
+	maps.Copy(s.flags, flags)
+	return nil
+}
+
+// Value returns a flag's value, or def if it is not set.
+func (s *StaticFlags) Value(key string, def any) any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if v, ok := s.flags[key]; ok {
+		return v
+	}
+	return def
+}
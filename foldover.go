@@ -0,0 +1,177 @@
+package taguchi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// FoldoverReport describes a foldover built by Foldover.
+// Folded: The factors whose levels the mirror-image runs reverse.
+// NewTrials: The trials of the mirror-image runs, which still have to be run; trials
+// of the original runs keep their IDs in the combined experiment.
+// AliasesBefore: Groups of fully aliased main effects and two-factor interactions of the
+// two-level factors in the original design, e.g. ["A", "B×C"].
+// AliasesAfter: The same groups for the combined design.
+// DeAliased: Effects that were aliased before and are clear of every other effect after.
+type FoldoverReport struct {
+	Folded        []string
+	NewTrials     []Trial
+	AliasesBefore [][]string
+	AliasesAfter  [][]string
+	DeAliased     []string
+}
+
+// Foldover returns the experiment combined with the mirror image of its design, for
+// rescuing a resolution III screening experiment whose main effects are aliased with
+// two-factor interactions. Without factors it is a full foldover, reversing every
+// two-level column, which clears all main effects of two-factor interactions; with
+// factors it reverses only their columns, which in a single-factor foldover clears that
+// factor's main effect and its two-factor interactions. The combined experiment keeps
+// the goal, noise factors, params type and recorded results, and its first trials are
+// the original ones. The report lists the new trials and the aliases before and after.
+func (e *Experiment[P]) Foldover(factors ...string) (*Experiment[P], FoldoverReport, error) {
+	var report FoldoverReport
+	oa := e.array()
+	width := len(oa.Row(0))
+	fold := make([]bool, width)
+	if len(factors) == 0 {
+		// Fold every two-level column, including columns not assigned to a factor.
+		levels := make([]int, width)
+		for i := 0; i < oa.Rows(); i++ {
+			for j, l := range oa.Row(i) {
+				levels[j] = max(levels[j], l)
+			}
+		}
+		for j, l := range levels {
+			fold[j] = l == 2
+			if fold[j] && j < len(e.ControlFactors) {
+				report.Folded = append(report.Folded, e.ControlFactors[j].Name)
+			}
+		}
+		if !slices.Contains(fold, true) {
+			return nil, FoldoverReport{}, fmt.Errorf("foldover needs two-level columns; the design has none")
+		}
+	}
+	for _, name := range factors {
+		j := slices.IndexFunc(e.ControlFactors, func(f ControlFactor) bool { return f.Name == name })
+		if j < 0 {
+			return nil, FoldoverReport{}, fmt.Errorf("unknown control factor %q", name)
+		}
+		if len(e.ControlFactors[j].Levels) != 2 {
+			return nil, FoldoverReport{}, fmt.Errorf("factor %s has %d levels; only two-level factors can be folded over", name, len(e.ControlFactors[j].Levels))
+		}
+		fold[j] = true
+		report.Folded = append(report.Folded, name)
+	}
+
+	design := make([][]int, 0, 2*oa.Rows())
+	for i := 0; i < oa.Rows(); i++ {
+		design = append(design, slices.Clone(oa.Row(i)))
+	}
+	for i := 0; i < oa.Rows(); i++ {
+		row := slices.Clone(oa.Row(i))
+		for j := range row {
+			if fold[j] {
+				row[j] = 3 - row[j]
+			}
+		}
+		design = append(design, row)
+	}
+
+	combined := &Experiment[P]{
+		ControlFactors:  e.ControlFactors,
+		NoiseFactors:    e.NoiseFactors,
+		Goal:            e.Goal,
+		OrthogonalArray: design,
+		Results:         slices.Clone(e.Results),
+		AdHocResults:    slices.Clone(e.AdHocResults),
+		controlAs:       e.controlAs,
+		noiseLimit:      e.noiseLimit,
+		noiseSeed:       e.noiseSeed,
+		interactions:    e.interactions,
+		design:          e.design,
+		groupNoise:      e.groupNoise,
+		poolIdle:        e.poolIdle,
+	}
+	trials := combined.GenerateTrials()
+	report.NewTrials = trials[len(trials)/2:]
+	report.AliasesBefore = aliasGroups(e.ControlFactors, design[:oa.Rows()])
+	report.AliasesAfter = aliasGroups(e.ControlFactors, design)
+	aliasedAfter := make(map[string]bool)
+	for _, group := range report.AliasesAfter {
+		for _, effect := range group {
+			aliasedAfter[effect] = true
+		}
+	}
+	for _, group := range report.AliasesBefore {
+		for _, effect := range group {
+			if !aliasedAfter[effect] {
+				report.DeAliased = append(report.DeAliased, effect)
+			}
+		}
+	}
+	return combined, report, nil
+}
+
+// aliasGroups returns the groups of main effects and two-factor interactions of the
+// two-level factors whose ±1 contrasts over design are identical up to sign, in the
+// order of their first effect: main effects first, then interactions.
+func aliasGroups(factors []ControlFactor, design [][]int) [][]string {
+	var twoLevel []int
+	for j, f := range factors {
+		if len(f.Levels) == 2 {
+			twoLevel = append(twoLevel, j)
+		}
+	}
+	type effect struct {
+		name     string
+		contrast []int
+	}
+	sign := func(l int) int { return 2*l - 3 }
+	var effects []effect
+	for _, j := range twoLevel {
+		c := make([]int, len(design))
+		for i, row := range design {
+			c[i] = sign(row[j])
+		}
+		effects = append(effects, effect{factors[j].Name, c})
+	}
+	for a, ja := range twoLevel {
+		for _, jb := range twoLevel[a+1:] {
+			c := make([]int, len(design))
+			for i, row := range design {
+				c[i] = sign(row[ja]) * sign(row[jb])
+			}
+			effects = append(effects, effect{factors[ja].Name + "×" + factors[jb].Name, c})
+		}
+	}
+
+	// Key contrasts by their values with the sign normalized so the first run is +1.
+	index := make(map[string]int)
+	var groups [][]string
+	for _, eff := range effects {
+		var key strings.Builder
+		for _, v := range eff.contrast {
+			if v*eff.contrast[0] > 0 {
+				key.WriteByte('+')
+			} else {
+				key.WriteByte('-')
+			}
+		}
+		g, ok := index[key.String()]
+		if !ok {
+			g = len(groups)
+			index[key.String()] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], eff.name)
+	}
+	var aliased [][]string
+	for _, group := range groups {
+		if len(group) > 1 {
+			aliased = append(aliased, group)
+		}
+	}
+	return aliased
+}
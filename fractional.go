@@ -0,0 +1,224 @@
+package taguchi
+
+import (
+	"cmp"
+	"fmt"
+	"math/bits"
+	"slices"
+	"strings"
+)
+
+// factorLetters names the factors of a fractional factorial in the usual way, skipping
+// I, which denotes the identity in defining relations.
+const factorLetters = "ABCDEFGHJKLMNOPQRSTUVWXYZ"
+
+// fractionalSearchBudget bounds the generator search for one run size; when it is
+// exhausted the next larger run size is tried.
+const fractionalSearchBudget = 100000
+
+// FractionalFactorial is a two-level 2^(k-p) fractional factorial design. Factors are
+// named A, B, C, ... (skipping I) in column order.
+// Factors: Number of factors k.
+// Runs: Number of runs 2^(k-p).
+// Resolution: Length of the shortest word in the defining relation; 0 for a full factorial.
+// Generators: How each added factor is built from the base factors, e.g. "E = ABCD".
+// DefiningRelation: The words equal to the identity I, shortest first.
+// Aliases: Groups of confounded main effects and two-factor interactions, e.g. ["AB", "CD"];
+// effects of higher order are assumed negligible and left out.
+// Array: The design, one column per factor, with levels 1 (low) and 2 (high).
+type FractionalFactorial struct {
+	Factors          int
+	Runs             int
+	Resolution       int
+	Generators       []string
+	DefiningRelation []string
+	Aliases          [][]string
+	Array            [][]int
+}
+
+// GenerateFractionalFactorial returns a 2^(k-p) fractional factorial for k two-level
+// factors with the fewest runs whose resolution is at least resolution (3, 4 or 5):
+// resolution III keeps main effects clear of each other, IV also keeps them clear of
+// two-factor interactions, and V keeps two-factor interactions clear of each other.
+// Generators favor the highest-order interactions of the base factors. Pass Array to
+// NewExperimentFromFactorsUsingArray.
+func GenerateFractionalFactorial(k, resolution int) (FractionalFactorial, error) {
+	if k < 2 || k > len(factorLetters) {
+		return FractionalFactorial{}, fmt.Errorf("fractional factorial needs 2 to %d factors, got %d", len(factorLetters), k)
+	}
+	if resolution < 3 || resolution > 5 {
+		return FractionalFactorial{}, fmt.Errorf("resolution must be 3 (III), 4 (IV) or 5 (V), got %d", resolution)
+	}
+	for m := 2; m < k; m++ {
+		if generators, ok := searchGenerators(m, k-m, resolution); ok {
+			return newFractionalFactorial(m, generators), nil
+		}
+	}
+	return newFractionalFactorial(k, nil), nil
+}
+
+// searchGenerators looks for p generators over m base factors, each a set of at least
+// two base factors as a bit mask, such that every word of the defining relation has at
+// least resolution letters.
+func searchGenerators(m, p, resolution int) ([]uint32, bool) {
+	var candidates []uint32
+	for mask := uint32(1); mask < 1<<m; mask++ {
+		if bits.OnesCount32(mask) >= max(2, resolution-1) {
+			candidates = append(candidates, mask)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b uint32) int {
+		return cmp.Compare(bits.OnesCount32(b), bits.OnesCount32(a))
+	})
+
+	budget := fractionalSearchBudget
+	chosen := make([]uint32, 0, p)
+	words := []uint32{0}
+	var search func(start int) bool
+	search = func(start int) bool {
+		if len(chosen) == p {
+			return true
+		}
+		for c := start; c < len(candidates); c++ {
+			if budget--; budget < 0 {
+				return false
+			}
+			// Added factor m+len(chosen) is aliased with the candidate interaction.
+			generator := candidates[c] | 1<<(m+len(chosen))
+			ok := true
+			for _, w := range words {
+				if bits.OnesCount32(w^generator) < resolution {
+					ok = false
+					break
+				}
+			}
+			if !ok {
+				continue
+			}
+			n := len(words)
+			for _, w := range words[:n] {
+				words = append(words, w^generator)
+			}
+			chosen = append(chosen, candidates[c])
+			if search(c + 1) {
+				return true
+			}
+			chosen, words = chosen[:len(chosen)-1], words[:n]
+		}
+		return false
+	}
+	if !search(0) {
+		return nil, false
+	}
+	return chosen, true
+}
+
+// newFractionalFactorial builds the design with m base factors varied as a full
+// factorial (first factor slowest) and one added factor per generator.
+func newFractionalFactorial(m int, generators []uint32) FractionalFactorial {
+	k := m + len(generators)
+	d := FractionalFactorial{Factors: k, Runs: 1 << m}
+
+	words := []uint32{0}
+	for g, base := range generators {
+		d.Generators = append(d.Generators, fmt.Sprintf("%c = %s", factorLetters[m+g], effectName(base)))
+		generator := base | 1<<(m+g)
+		for _, w := range words[:len(words)] {
+			words = append(words, w^generator)
+		}
+	}
+	words = words[1:]
+	sortEffects(words)
+	for _, w := range words {
+		d.DefiningRelation = append(d.DefiningRelation, effectName(w))
+		if d.Resolution == 0 {
+			d.Resolution = bits.OnesCount32(w)
+		}
+	}
+
+	seen := make(map[uint32]bool)
+	for _, effect := range lowOrderEffects(k) {
+		if seen[effect] {
+			continue
+		}
+		group := []uint32{effect}
+		for _, w := range words {
+			if alias := effect ^ w; bits.OnesCount32(alias) <= 2 {
+				group = append(group, alias)
+			}
+		}
+		for _, e := range group {
+			seen[e] = true
+		}
+		if len(group) > 1 {
+			sortEffects(group)
+			names := make([]string, len(group))
+			for i, e := range group {
+				names[i] = effectName(e)
+			}
+			d.Aliases = append(d.Aliases, names)
+		}
+	}
+
+	d.Array = make([][]int, d.Runs)
+	for i := range d.Array {
+		row := make([]int, k)
+		// sign is +1 (high) or -1 (low) for each factor in this run.
+		sign := func(mask uint32) int {
+			s := 1
+			for j := 0; j < m; j++ {
+				if mask&(1<<j) != 0 && i>>(m-1-j)&1 == 0 {
+					s = -s
+				}
+			}
+			return s
+		}
+		for j := 0; j < m; j++ {
+			row[j] = 1 + i>>(m-1-j)&1
+		}
+		for g, base := range generators {
+			row[m+g] = 1
+			if sign(base) > 0 {
+				row[m+g] = 2
+			}
+		}
+		d.Array[i] = row
+	}
+	return d
+}
+
+// lowOrderEffects returns the main effects and two-factor interactions of k factors
+// as bit masks, in the order used for alias groups.
+func lowOrderEffects(k int) []uint32 {
+	var effects []uint32
+	for a := 0; a < k; a++ {
+		effects = append(effects, 1<<a)
+	}
+	for a := 0; a < k; a++ {
+		for b := a + 1; b < k; b++ {
+			effects = append(effects, 1<<a|1<<b)
+		}
+	}
+	return effects
+}
+
+// sortEffects orders effects by their order, then alphabetically.
+func sortEffects(effects []uint32) {
+	slices.SortFunc(effects, func(a, b uint32) int {
+		if c := cmp.Compare(bits.OnesCount32(a), bits.OnesCount32(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(effectName(a), effectName(b))
+	})
+}
+
+// effectName spells an effect bit mask with the factor letters, e.g. 0b1011 is "ABD".
+func effectName(mask uint32) string {
+	var b strings.Builder
+	for j := 0; mask>>j != 0; j++ {
+		if mask&(1<<j) != 0 {
+			b.WriteByte(factorLetters[j])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,238 @@
+package taguchi
+
+import "fmt"
+
+// GenerateTwoLevelArray constructs a balanced two-level orthogonal array with nRuns
+// rows and nRuns-1 columns from a normalized Hadamard matrix. nRuns must be a multiple
+// of 4 for which a Sylvester (power of two), Paley I (prime q ≡ 3 mod 4, nRuns = q+1)
+// or Paley II (prime q ≡ 1 mod 4, nRuns = 2(q+1)) construction exists, possibly
+// doubled; this covers every multiple of 4 below 100 except 52 and 92.
+func GenerateTwoLevelArray(nRuns int) ([][]int, error) {
+	if nRuns < 4 || nRuns%4 != 0 {
+		return nil, fmt.Errorf("two-level orthogonal arrays need a multiple of 4 runs, got %d", nRuns)
+	}
+	h := hadamard(nRuns)
+	if h == nil {
+		return nil, fmt.Errorf("no Hadamard construction available for %d runs", nRuns)
+	}
+
+	// Normalize so that the first row and column are all +1; the remaining columns are
+	// then balanced and pairwise orthogonal.
+	for i := range h {
+		if h[i][0] < 0 {
+			for j := range h[i] {
+				h[i][j] = -h[i][j]
+			}
+		}
+	}
+	for j := range h[0] {
+		if h[0][j] < 0 {
+			for i := range h {
+				h[i][j] = -h[i][j]
+			}
+		}
+	}
+
+	oa := make([][]int, nRuns)
+	for i, row := range h {
+		oa[i] = make([]int, nRuns-1)
+		for j, v := range row[1:] {
+			if v > 0 {
+				oa[i][j] = 1
+			} else {
+				oa[i][j] = 2
+			}
+		}
+	}
+	return oa, nil
+}
+
+// hadamard returns a Hadamard matrix of order n, or nil if no construction applies.
+func hadamard(n int) [][]int {
+	switch {
+	case n == 1:
+		return [][]int{{1}}
+	case isPrime(n-1) && (n-1)%4 == 3:
+		return paleyI(n - 1)
+	case n%2 == 0 && isPrime(n/2-1) && (n/2-1)%4 == 1:
+		return paleyII(n/2 - 1)
+	case n%2 == 0:
+		if h := hadamard(n / 2); h != nil {
+			return doubleHadamard(h)
+		}
+	}
+	return nil
+}
+
+// doubleHadamard returns the Sylvester doubling [[H, H], [H, -H]].
+func doubleHadamard(h [][]int) [][]int {
+	n := len(h)
+	out := make([][]int, 2*n)
+	for i := range out {
+		out[i] = make([]int, 2*n)
+		for j := range out[i] {
+			v := h[i%n][j%n]
+			if i >= n && j >= n {
+				v = -v
+			}
+			out[i][j] = v
+		}
+	}
+	return out
+}
+
+// jacobsthal returns the q×q matrix Q[i][j] = χ(j-i), where χ is the quadratic
+// character modulo the prime q.
+func jacobsthal(q int) [][]int {
+	chi := make([]int, q)
+	for x := 1; x < q; x++ {
+		chi[x] = -1
+	}
+	for x := 1; x < q; x++ {
+		chi[x*x%q] = 1
+	}
+	m := make([][]int, q)
+	for i := range m {
+		m[i] = make([]int, q)
+		for j := range m[i] {
+			m[i][j] = chi[((j-i)%q+q)%q]
+		}
+	}
+	return m
+}
+
+// paleyI builds a Hadamard matrix of order q+1 for a prime q ≡ 3 (mod 4).
+func paleyI(q int) [][]int {
+	jq := jacobsthal(q)
+	n := q + 1
+	h := make([][]int, n)
+	for i := range h {
+		h[i] = make([]int, n)
+	}
+	// H = I + S with S = [[0, 1ᵀ], [-1, Q]].
+	for j := 1; j < n; j++ {
+		h[0][j] = 1
+		h[j][0] = -1
+	}
+	for i := 0; i < q; i++ {
+		for j := 0; j < q; j++ {
+			h[i+1][j+1] = jq[i][j]
+		}
+	}
+	for i := range h {
+		h[i][i] = 1
+	}
+	return h
+}
+
+// paleyII builds a Hadamard matrix of order 2(q+1) for a prime q ≡ 1 (mod 4).
+func paleyII(q int) [][]int {
+	jq := jacobsthal(q)
+	n := q + 1
+	// C = [[0, 1ᵀ], [1, Q]] is symmetric with zero diagonal.
+	c := make([][]int, n)
+	for i := range c {
+		c[i] = make([]int, n)
+	}
+	for j := 1; j < n; j++ {
+		c[0][j] = 1
+		c[j][0] = 1
+	}
+	for i := 0; i < q; i++ {
+		for j := 0; j < q; j++ {
+			c[i+1][j+1] = jq[i][j]
+		}
+	}
+
+	// Replace 0 by [[1, -1], [-1, -1]] and ±1 by ±[[1, 1], [1, -1]].
+	h := make([][]int, 2*n)
+	for i := range h {
+		h[i] = make([]int, 2*n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			block := [2][2]int{{1, -1}, {-1, -1}}
+			if v := c[i][j]; v != 0 {
+				block = [2][2]int{{v, v}, {v, -v}}
+			}
+			for a := 0; a < 2; a++ {
+				for b := 0; b < 2; b++ {
+					h[2*i+a][2*j+b] = block[a][b]
+				}
+			}
+		}
+	}
+	return h
+}
+
+func isPrime(n int) bool {
+	if n < 2 {
+		return false
+	}
+	for d := 2; d*d <= n; d++ {
+		if n%d == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// plackettBurmanGenerators are the first rows of the cyclic Plackett–Burman designs.
+var plackettBurmanGenerators = map[int]string{
+	12: "++-+++---+-",
+	20: "++--++++-+-+----++-",
+	24: "+++++-+-++--++--+-+----",
+}
+
+// PlackettBurman returns a Plackett–Burman screening design with nRuns rows and
+// nRuns-1 two-level columns. The 12-, 20- and 24-run designs use Plackett and Burman's
+// cyclic generators (level 1 is "-", level 2 is "+"); other multiples of 4, including
+// 28, are built from a Hadamard matrix as in GenerateTwoLevelArray.
+func PlackettBurman(nRuns int) ([][]int, error) {
+	gen, ok := plackettBurmanGenerators[nRuns]
+	if !ok {
+		return GenerateTwoLevelArray(nRuns)
+	}
+	k := nRuns - 1
+	oa := make([][]int, nRuns)
+	for i := 0; i < k; i++ {
+		oa[i] = make([]int, k)
+		for j := 0; j < k; j++ {
+			// Each row shifts the previous one right by one position.
+			if gen[(j-i+k)%k] == '+' {
+				oa[i][j] = 2
+			} else {
+				oa[i][j] = 1
+			}
+		}
+	}
+	oa[k] = make([]int, k)
+	for j := range oa[k] {
+		oa[k][j] = 1
+	}
+	return oa, nil
+}
+
+// GenerateFullFactorial returns every combination of the factors' levels as an array,
+// one column per factor, with the first factor varying slowest. It is the fallback for
+// designs with few factors, where running every combination is affordable.
+func GenerateFullFactorial(factors []ControlFactor) [][]int {
+	if len(factors) == 0 {
+		return nil
+	}
+	runs := 1
+	for _, f := range factors {
+		runs *= len(f.Levels)
+	}
+	oa := make([][]int, runs)
+	for i := range oa {
+		oa[i] = make([]int, len(factors))
+		rest := i
+		for j := len(factors) - 1; j >= 0; j-- {
+			n := len(factors[j].Levels)
+			oa[i][j] = rest%n + 1
+			rest /= n
+		}
+	}
+	return oa
+}
@@ -1,13 +1,71 @@
+//go:build !tinygo
+
 package taguchi
 
 import (
 	"fmt"
 	"reflect"
+	"time"
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
 )
 
-// factorsFrom extracts a []Factor from the exported []float64 fields of a
+// isLevelKind reports whether values of kind k can be used as factor levels: floats,
+// (possibly enum-typed) integers, and bools (stored as 0 and 1).
+func isLevelKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// toFloat converts a level reflect.Value to float64.
+func toFloat(v reflect.Value) float64 {
+	switch {
+	case v.Kind() == reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+		return 0
+	case v.CanFloat():
+		return v.Float()
+	case v.CanInt():
+		return float64(v.Int())
+	default:
+		return float64(v.Uint())
+	}
+}
+
+// setFromFloat stores f into the level reflect.Value v, converting to its kind. Bools
+// are true for any non-zero value.
+func setFromFloat(v reflect.Value, f float64) {
+	switch {
+	case v.Kind() == reflect.Bool:
+		v.SetBool(f != 0)
+	case v.CanFloat():
+		v.SetFloat(f)
+	case v.CanInt():
+		v.SetInt(int64(f))
+	default:
+		v.SetUint(uint64(f))
+	}
+}
+
+// factorsFrom extracts a []Factor from the exported level slice fields of a
 // struct value. Each field becomes a factor with Name = field name and
-// Levels = the slice value.
+// Levels = the slice value. Slices of integer types, such as enums
+// (e.g. []SortAlgorithm), are converted to float64 levels and labeled with their String
+// method if they have one; []time.Duration fields are stored as nanoseconds and marked
+// Duration, and []bool fields as 0 and 1, labeled false and true. A
+// `taguchi:"min=0,max=1,integer,pow2"` tag sets the factor's LevelRules. A [][]T field
+// tagged `taguchi:"compound=Temperature+Time"` becomes a compound factor whose levels
+// are the inner slices, one value per component.
 func factorsFrom[T any](v T) ([]ControlFactor, error) {
 	rv := reflect.ValueOf(v)
 	if rv.Kind() != reflect.Struct {
@@ -21,24 +79,58 @@ func factorsFrom[T any](v T) ([]ControlFactor, error) {
 		if !field.IsExported() {
 			continue
 		}
-		if field.Type != reflect.TypeOf([]float64{}) {
+		if field.Type.Kind() != reflect.Slice {
+			continue
+		}
+		if elem := field.Type.Elem(); elem.Kind() == reflect.Slice && isLevelKind(elem.Elem().Kind()) {
+			factor, err := compoundFrom(field, rv.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			factors = append(factors, factor)
+			continue
+		}
+		if !isLevelKind(field.Type.Elem().Kind()) {
 			continue
 		}
-		levels := rv.Field(i).Interface().([]float64)
-		if len(levels) < 2 {
-			return nil, fmt.Errorf("field %s: at least 2 levels required, got %d", field.Name, len(levels))
+		slice := rv.Field(i)
+		if slice.Len() < 2 {
+			return nil, fmt.Errorf("field %s: at least 2 levels required, got %d", field.Name, slice.Len())
+		}
+		levels := make([]float64, slice.Len())
+		for l := range levels {
+			levels[l] = toFloat(slice.Index(l))
 		}
-		factors = append(factors, ControlFactor{Name: field.Name, Levels: levels})
+		var labels []string
+		if elem := field.Type.Elem(); elem != durationType && (elem.Kind() == reflect.Bool || elem.Implements(stringerType)) {
+			labels = make([]string, slice.Len())
+			for l := range labels {
+				labels[l] = fmt.Sprint(slice.Index(l).Interface())
+			}
+		}
+		rules, err := parseLevelRules(field.Tag.Get("taguchi"))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		factors = append(factors, ControlFactor{
+			Name:     field.Name,
+			Levels:   levels,
+			Duration: field.Type.Elem() == durationType,
+			Rules:    rules,
+			Labels:   labels,
+		})
 	}
 
 	if len(factors) == 0 {
-		return nil, fmt.Errorf("no exported []float64 fields found in %s", t.Name())
+		return nil, fmt.Errorf("no exported numeric or bool slice fields found in %s", t.Name())
 	}
-	return factors, nil
+	return factors, checkLevelRules(factors)
 }
 
 // buildControlAs pre-computes field indices for type P and returns a closure
-// that converts a Trial's Control map into a value of P.
+// that converts a Trial's Control map into a value of P. Numeric fields of any
+// kind are filled in, so enum-typed fields receive their typed value, and bool fields
+// are set for non-zero levels.
 func buildControlAs[P any]() func(Trial) P {
 	var zero P
 	t := reflect.TypeOf(zero)
@@ -58,7 +150,7 @@ func buildControlAs[P any]() func(Trial) P {
 	var fields []fieldInfo
 	for i := 0; i < t.NumField(); i++ {
 		field := t.Field(i)
-		if !field.IsExported() || field.Type.Kind() != reflect.Float64 {
+		if !field.IsExported() || !isLevelKind(field.Type.Kind()) {
 			continue
 		}
 		fields = append(fields, fieldInfo{index: i, name: field.Name})
@@ -69,9 +161,36 @@ func buildControlAs[P any]() func(Trial) P {
 		v := reflect.ValueOf(&result).Elem()
 		for _, f := range fields {
 			if val, ok := trial.Control[f.name]; ok {
-				v.Field(f.index).SetFloat(val)
+				setFromFloat(v.Field(f.index), val)
 			}
 		}
 		return result
 	}
 }
+
+// compoundFrom builds a compound factor from a [][]T field whose tag names the
+// components, e.g. `taguchi:"compound=Temperature+Time"`.
+func compoundFrom(field reflect.StructField, value reflect.Value) (ControlFactor, error) {
+	names, rest := splitCompoundTag(field.Tag.Get("taguchi"))
+	if len(names) == 0 {
+		return ControlFactor{}, fmt.Errorf("field %s: a compound factor needs a `taguchi:\"compound=A+B\"` tag", field.Name)
+	}
+	if rest != "" {
+		return ControlFactor{}, fmt.Errorf("field %s: level rules do not apply to compound factors", field.Name)
+	}
+	components := make(map[string][]float64, len(names))
+	for l := 0; l < value.Len(); l++ {
+		level := value.Index(l)
+		if level.Len() != len(names) {
+			return ControlFactor{}, fmt.Errorf("field %s: level %d has %d values for %d components", field.Name, l+1, level.Len(), len(names))
+		}
+		for c, name := range names {
+			components[name] = append(components[name], toFloat(level.Index(c)))
+		}
+	}
+	factor, err := CompoundFactor(field.Name, components)
+	if err != nil {
+		return ControlFactor{}, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+	return factor, nil
+}
@@ -0,0 +1,17 @@
+//go:build tinygo
+
+package taguchi
+
+import "errors"
+
+// factorsFrom is not supported under TinyGo, which keeps the core free of direct reflection;
+// use the NewExperimentFromFactors constructors instead.
+func factorsFrom[T any](v T) ([]ControlFactor, error) {
+	return nil, errors.New("factors structs are not supported under TinyGo; use NewExperimentFromFactors")
+}
+
+// buildControlAs returns nil under TinyGo, so Params returns the zero P; use Settings
+// to read a trial's levels.
+func buildControlAs[P any]() func(Trial) P {
+	return nil
+}
@@ -0,0 +1,115 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// maxActionsMatrixJobs is the number of jobs GitHub Actions allows a matrix to generate.
+const maxActionsMatrixJobs = 256
+
+// ActionsMatrix returns the experiment's trials as a GitHub Actions matrix, for
+// strategy.matrix: ${{ fromJSON(...) }}. Each include entry is one Trial in JSON, so a
+// job reads its levels as matrix.Control.<factor> and matrix.Noise.<factor>, or passes
+// ${{ toJSON(matrix) }} to ParseActionsTrial. GitHub limits a matrix to 256 jobs.
+func (e *Experiment[P]) ActionsMatrix() ([]byte, error) {
+	trials := e.GenerateTrials()
+	if len(trials) > maxActionsMatrixJobs {
+		return nil, fmt.Errorf("%d trials exceed the GitHub Actions limit of %d jobs per matrix", len(trials), maxActionsMatrixJobs)
+	}
+	return json.Marshal(struct {
+		Include []Trial `json:"include"`
+	}{trials})
+}
+
+// ParseActionsTrial reads the trial of a matrix job from its matrix context in JSON, as
+// produced by ${{ toJSON(matrix) }}.
+func ParseActionsTrial(matrix string) (Trial, error) {
+	var trial Trial
+	if err := json.Unmarshal([]byte(matrix), &trial); err != nil {
+		return Trial{}, fmt.Errorf("parse matrix trial: %w", err)
+	}
+	if trial.ID == 0 || trial.Control == nil {
+		return Trial{}, fmt.Errorf("parse matrix trial: not a trial from ActionsMatrix")
+	}
+	return trial, nil
+}
+
+// WriteActionsResult writes a matrix job's result as JSON, to be uploaded as an artifact
+// and read back by CollectActionsResults, or POSTed to a NewResultHandler.
+func WriteActionsResult(w io.Writer, result TrialResult) error {
+	return json.NewEncoder(w).Encode(result)
+}
+
+// CollectActionsResults records the results written by WriteActionsResult in the .json
+// files under dir, e.g. the artifacts of a matrix run fetched with actions/download-artifact
+// or gh run download, and returns the number of results recorded.
+func (e *Experiment[P]) CollectActionsResults(dir string) (int, error) {
+	n := 0
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		result, err := decodeTrialResult(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		e.AddTrialResult(result)
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// NewResultHandler returns an http.Handler through which CI jobs report results: it
+// accepts a POST whose body is a result written by WriteActionsResult and records it in
+// e. Results are recorded while holding mu, which must also be held while reading e,
+// e.g. to Analyze while jobs are still reporting.
+func NewResultHandler[P any](e *Experiment[P], mu *sync.Mutex) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		result, err := decodeTrialResult(data)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		e.AddTrialResult(result)
+		mu.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// decodeTrialResult decodes a result written by WriteActionsResult.
+func decodeTrialResult(data []byte) (TrialResult, error) {
+	var result TrialResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return TrialResult{}, fmt.Errorf("decode trial result: %w", err)
+	}
+	if result.Trial.ID == 0 || len(result.Observations) == 0 {
+		return TrialResult{}, fmt.Errorf("decode trial result: missing trial or observations")
+	}
+	return result, nil
+}
@@ -0,0 +1,76 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestActionsMatrix(t *testing.T) {
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{10, 20}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	data, err := exp.ActionsMatrix()
+	if err != nil {
+		t.Fatalf("ActionsMatrix: %v", err)
+	}
+	var matrix struct{ Include []json.RawMessage }
+	if err := json.Unmarshal(data, &matrix); err != nil || len(matrix.Include) != 4 {
+		t.Fatalf("matrix: got %s (%v), want 4 include entries", data, err)
+	}
+
+	// Half the jobs upload artifacts, the other half report over HTTP.
+	dir := t.TempDir()
+	var mu sync.Mutex
+	srv := httptest.NewServer(NewResultHandler(exp, &mu))
+	defer srv.Close()
+	for i, entry := range matrix.Include {
+		trial, err := ParseActionsTrial(string(entry))
+		if err != nil {
+			t.Fatalf("ParseActionsTrial: %v", err)
+		}
+		var buf bytes.Buffer
+		result := TrialResult{Trial: trial, Observations: []float64{trial.Control["A"] + trial.Control["B"]}}
+		if err := WriteActionsResult(&buf, result); err != nil {
+			t.Fatalf("WriteActionsResult: %v", err)
+		}
+		if i%2 == 0 {
+			os.MkdirAll(filepath.Join(dir, fmt.Sprint("trial-", trial.ID)), 0o755)
+			os.WriteFile(filepath.Join(dir, fmt.Sprint("trial-", trial.ID), "result.json"), buf.Bytes(), 0o644)
+			continue
+		}
+		resp, err := http.Post(srv.URL, "application/json", &buf)
+		if err != nil || resp.StatusCode != http.StatusNoContent {
+			t.Fatalf("POST result: %v %v", resp, err)
+		}
+		resp.Body.Close()
+	}
+	if n, err := exp.CollectActionsResults(dir); err != nil || n != 2 {
+		t.Fatalf("CollectActionsResults: got %d (%v), want 2", n, err)
+	}
+	if len(exp.Results) != 4 {
+		t.Fatalf("got %d results, want 4", len(exp.Results))
+	}
+	if got := exp.Analyze().OptimalLevels; got["A"] != 1 || got["B"] != 10 {
+		t.Errorf("OptimalLevels: got %v", got)
+	}
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"Trial":{"ID":1}}`))
+	if err != nil || resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("POST without observations: got %v %v, want 400", resp, err)
+	}
+	if _, err := ParseActionsTrial(`{"os":"ubuntu-latest"}`); err == nil {
+		t.Error("ParseActionsTrial accepted a matrix without a trial")
+	}
+}
@@ -0,0 +1,27 @@
+package taguchi
+
+// GoalFunc adapts a custom SNR formula into an OptimizationGoal named name, for
+// domain-specific quality metrics, e.g. energy-weighted latency, that do not warrant a
+// type of their own. fn receives a row's observations and returns its SNR: larger must
+// be better, and like the built-in goals it should be in decibels so effects add up.
+// Experiments with a GoalFunc goal cannot be saved, since fn cannot be serialized, and
+// DiffDesigns treats each GoalFunc as a goal of its own, whatever its name.
+func GoalFunc(name string, fn func([]float64) float64) OptimizationGoal {
+	return &goalFunc{name: name, fn: fn}
+}
+
+// goalFunc is the OptimizationGoal returned by GoalFunc.
+type goalFunc struct {
+	name string
+	fn   func([]float64) float64
+}
+
+// CalculateSNR calls the custom formula.
+func (g *goalFunc) CalculateSNR(obs []float64) float64 {
+	return g.fn(obs)
+}
+
+// String returns the goal's name.
+func (g *goalFunc) String() string {
+	return g.name
+}
@@ -0,0 +1,48 @@
+package taguchi
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CheckGoldenReport renders the report for result and compares it with the golden
+// file at path. When update is true the golden file is (re)written instead.
+// It returns an error naming the first differing line if the report has changed,
+// so downstream users can pin analysis behavior in CI:
+//
+//	if err := taguchi.CheckGoldenReport("testdata/report.golden", exp.Analyze(), *update); err != nil {
+//		t.Fatal(err)
+//	}
+func CheckGoldenReport(path string, result AnalysisResult, update bool) error {
+	var buf bytes.Buffer
+	FprintAnalysisReport(&buf, result)
+	if update {
+		return os.WriteFile(path, buf.Bytes(), 0o644)
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read golden report: %w", err)
+	}
+	if bytes.Equal(buf.Bytes(), want) {
+		return nil
+	}
+
+	gotLines := strings.Split(buf.String(), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var got, exp string
+		if i < len(gotLines) {
+			got = gotLines[i]
+		}
+		if i < len(wantLines) {
+			exp = wantLines[i]
+		}
+		if got != exp {
+			return fmt.Errorf("report differs from %s at line %d:\n  got:  %q\n  want: %q", path, i+1, got, exp)
+		}
+	}
+	return fmt.Errorf("report differs from %s", path)
+}
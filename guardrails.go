@@ -0,0 +1,225 @@
+package taguchi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Guardrail is a safety ceiling on a metric watched while a trial runs, e.g. an error
+// rate or a latency percentile of the live system under test.
+// Metric: The name of the metric, as reported by the guardrail sampler.
+// Max: The highest acceptable value; a sample above it breaches the guardrail.
+type Guardrail struct {
+	Metric string
+	Max    float64
+}
+
+// SetGuardrails makes Run sample m every interval while each trial is measured and
+// abort the trial as soon as a sample breaches one of guards: the trial's Abort channel
+// is closed, measure should return promptly, and the result is recorded as Unsafe with
+// whatever observations measure returned. Trials of the same configuration still
+// queued are not run, and analysis never recommends an unsafe configuration. The partial
+// observations of an aborted trial are kept in its row's SNR: leaving the row without
+// data would bias the other factors' main effects more than a truncated measurement.
+// Recording the breach needs a runner with AddTrialResult, such as *Experiment, so Run
+// fails up front for any other runner. A nil m disables the guardrails.
+func (s *Scheduler) SetGuardrails(m MetricSampler, interval time.Duration, guards ...Guardrail) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.guardSampler = m
+	s.guardInterval = interval
+	s.guards = guards
+}
+
+// guardTrial runs measure while watching the guardrails, and returns the breach that
+// aborted it, if any. A measurement error after a breach is expected and dropped.
+func guardTrial(m MetricSampler, interval time.Duration, guards []Guardrail, abort chan struct{}, measure func() error) (breach string, err error) {
+	check := func() bool {
+		values, err := m.Sample()
+		if err != nil {
+			return false
+		}
+		for _, g := range guards {
+			if v, ok := values[g.Metric]; ok && v > g.Max {
+				breach = fmt.Sprintf("guardrail %s breached: %g > %g", g.Metric, v, g.Max)
+				return true
+			}
+		}
+		return false
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if check() {
+					close(abort)
+					return
+				}
+			}
+		}
+	}()
+	err = measure()
+	close(done)
+	<-stopped
+	if breach == "" && check() {
+		close(abort)
+	}
+	if breach != "" {
+		return breach, nil
+	}
+	return "", err
+}
+
+// skipUnsafe records the queued trials sharing the control configuration of an unsafe
+// trial as unsafe too, without running them.
+func (s *Scheduler) skipUnsafe(st ScheduledTrial, breach string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.entry(st.Experiment)
+	if e == nil {
+		return
+	}
+	// Run has checked that the runner keeps the Unsafe marker (see checkGuardedRunners).
+	r, ok := e.runner.(trialResultRunner)
+	if !ok {
+		return
+	}
+	kept := e.pending[:0]
+	for _, t := range e.pending {
+		if !maps.Equal(t.Control, st.Trial.Control) {
+			kept = append(kept, t)
+			continue
+		}
+		r.AddTrialResult(TrialResult{Trial: t, Unsafe: fmt.Sprintf("skipped after trial %d: %s", st.Trial.ID, breach)})
+	}
+	e.pending = kept
+}
+
+// checkGuardedRunners returns an error if guardrails are set and a scheduled runner
+// cannot record unsafe results, which AddResult would store as ordinary ones.
+func (s *Scheduler) checkGuardedRunners() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.guardSampler == nil {
+		return nil
+	}
+	for _, e := range s.entries {
+		if _, ok := e.runner.(trialResultRunner); !ok {
+			return fmt.Errorf("experiment %s: guardrails need a runner with AddTrialResult to record unsafe trials", e.name)
+		}
+	}
+	return nil
+}
+
+// unsafeConfigurations returns the distinct control configurations of results aborted
+// by a guardrail.
+func (e *Experiment[P]) unsafeConfigurations() []map[string]float64 {
+	var out []map[string]float64
+	for _, results := range [][]TrialResult{e.Results, e.AdHocResults} {
+	results:
+		for _, r := range results {
+			if r.Unsafe == "" {
+				continue
+			}
+			for _, seen := range out {
+				if maps.Equal(seen, r.Trial.Control) {
+					continue results
+				}
+			}
+			out = append(out, r.Trial.Control)
+		}
+	}
+	return out
+}
+
+// avoidUnsafe moves the optimal levels off unsafe configurations. While the
+// recommendation is unsafe, the factor whose next-best level loses the least main effect
+// is switched to it, each factor at most once.
+func (e *Experiment[P]) avoidUnsafe(result *AnalysisResult) {
+	unsafe := e.unsafeConfigurations()
+	if len(unsafe) == 0 {
+		return
+	}
+	result.Unsafe = unsafe
+	isUnsafe := func(levels map[string]float64) bool {
+		for _, u := range unsafe {
+			if matchesControl(levels, u) {
+				return true
+			}
+		}
+		return false
+	}
+	if !isUnsafe(result.OptimalLevels) {
+		return
+	}
+
+	optimal := maps.Clone(result.OptimalLevels)
+	switched := map[string]bool{}
+	for isUnsafe(optimal) {
+		// A switch landing on a safe configuration wins over any that does not.
+		type move struct {
+			factor string
+			level  float64
+			loss   float64
+			safe   bool
+		}
+		var best *move
+		for _, f := range e.ControlFactors {
+			effects := result.MainEffects[f.Name]
+			current := slices.Index(f.Levels, optimal[f.Name])
+			if switched[f.Name] || current < 0 || len(effects) != len(f.Levels) {
+				continue
+			}
+			for l, level := range f.Levels {
+				if l == current {
+					continue
+				}
+				candidate := maps.Clone(optimal)
+				candidate[f.Name] = level
+				m := move{f.Name, level, effects[current] - effects[l], !isUnsafe(candidate)}
+				if best == nil || m.safe && !best.safe || m.safe == best.safe && m.loss < best.loss {
+					best = &m
+				}
+			}
+		}
+		if best == nil {
+			result.Diagnostics = append(result.Diagnostics, "every configuration near the optimal levels was aborted by a guardrail; the recommendation is unsafe")
+			return
+		}
+		optimal[best.factor] = best.level
+		switched[best.factor] = true
+	}
+
+	var changed []string
+	for _, f := range e.ControlFactors {
+		if optimal[f.Name] != result.OptimalLevels[f.Name] {
+			changed = append(changed, fmt.Sprintf("%s=%s", f.Name, formatLevel(optimal[f.Name], f.Duration)))
+		}
+	}
+	result.Diagnostics = append(result.Diagnostics, fmt.Sprintf("the optimal levels were aborted by a guardrail; recommending the next best safe configuration (%s)", strings.Join(changed, ", ")))
+	result.OptimalLevels = optimal
+}
+
+// matchesControl reports whether levels set every factor of control to its value.
+func matchesControl(levels, control map[string]float64) bool {
+	for name, v := range control {
+		if l, ok := levels[name]; !ok || l != v {
+			return false
+		}
+	}
+	return len(control) > 0
+}
@@ -0,0 +1,119 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// maxRequestBytes bounds the size of a design+results payload accepted by the analysis handler.
+const maxRequestBytes = 32 << 20
+
+// NewAnalysisHandler returns an http.Handler that analyzes experiments over HTTP.
+// It accepts a POST whose body is an experiment in the format written by Save and
+// responds with the AnalysisResult as JSON.
+// Non-finite values, such as the F-ratios of a saturated design or the SNR of a perfect
+// NominalTheBest row, are encoded as null. Malformed experiments are answered with 400,
+// experiments without results matching a row of their design with 422.
+func NewAnalysisHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		exp, err := LoadExperiment[struct{}](http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !slices.ContainsFunc(exp.rowResults(), func(results []int) bool { return len(results) > 0 }) {
+			http.Error(w, "experiment has no results matching its design to analyze", http.StatusUnprocessableEntity)
+			return
+		}
+
+		body, err := json.Marshal(finiteJSON(reflect.ValueOf(exp.Analyze())))
+		if err != nil {
+			http.Error(w, "analysis cannot be encoded: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+}
+
+// finiteJSON converts v into plain maps, slices and values that encoding/json encodes
+// like v itself, except that NaN and ±Inf, which JSON cannot represent, become null.
+func finiteJSON(v reflect.Value) any {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil
+		}
+		return v.Interface()
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return finiteJSON(v.Elem())
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		fallthrough
+	case reflect.Array:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = finiteJSON(v.Index(i))
+		}
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+		out := make(map[string]any, v.Len())
+		for it := v.MapRange(); it.Next(); {
+			out[fmt.Sprint(it.Key().Interface())] = finiteJSON(it.Value())
+		}
+		return out
+	case reflect.Struct:
+		out := make(map[string]any, v.NumField())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" && opts == "" {
+				continue
+			}
+			if name == "" {
+				name = field.Name
+			}
+			if strings.Contains(opts, "omitempty") && emptyJSON(v.Field(i)) {
+				continue
+			}
+			out[name] = finiteJSON(v.Field(i))
+		}
+		return out
+	}
+	return v.Interface()
+}
+
+// emptyJSON reports whether encoding/json's omitempty would omit v.
+func emptyJSON(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
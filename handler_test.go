@@ -0,0 +1,125 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAnalysisHandler verifies that the handler analyzes a saved experiment, encodes
+// the non-finite values of a saturated design as null, and rejects malformed experiments
+// with 400 and those without matching results with 422.
+func TestAnalysisHandler(t *testing.T) {
+	handler := NewAnalysisHandler()
+	post := func(body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(body)))
+		return rec
+	}
+	save := func(exp *Experiment[struct{}]) string {
+		var buf bytes.Buffer
+		if err := exp.Save(&buf); err != nil {
+			t.Fatalf("Save: %v", err)
+		}
+		return buf.String()
+	}
+
+	// The Ina tile dataset saturates L8, so its ANOVA has no error term.
+	d, err := LoadDataset("ina-tile")
+	if err != nil {
+		t.Fatalf("LoadDataset: %v", err)
+	}
+	exp, err := d.Experiment()
+	if err != nil {
+		t.Fatalf("Experiment: %v", err)
+	}
+	rec := post(save(exp))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status: got %d, want 200: %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type: got %q", ct)
+	}
+	var result struct {
+		OptimalLevels map[string]float64
+		ANOVA         struct{ FactorF map[string]*float64 }
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if f, ok := result.ANOVA.FactorF["LimeContent"]; ok && f != nil {
+		t.Errorf("F-ratio without an error term: got %v, want null", *f)
+	}
+	for name, want := range d.ExpectedOptimal {
+		if got := result.OptimalLevels[name]; got != want {
+			t.Errorf("OptimalLevels[%s]: got %v, want %v", name, got, want)
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		body string
+		code int
+	}{
+		"malformed JSON": {`{"version": 1,`, http.StatusBadRequest},
+		"unknown goal":   {`{"version": 1, "goal": {"type": "Bogus"}}`, http.StatusBadRequest},
+		"newer version":  {`{"version": 99}`, http.StatusBadRequest},
+	} {
+		if rec := post(tc.body); rec.Code != tc.code {
+			t.Errorf("%s: got status %d, want %d", name, rec.Code, tc.code)
+		}
+	}
+
+	exp.Results = nil
+	if rec := post(save(exp)); rec.Code != http.StatusUnprocessableEntity {
+		t.Errorf("no results: got status %d, want 422", rec.Code)
+	}
+	// Regression-analyzed designs must not reach Analyze without a fit to make.
+	for _, design := range []string{designSupersaturated, designDefinitiveScreening, designBoxBehnken, designCentralComposite} {
+		exp.design = design
+		exp.Results = []TrialResult{{Trial: Trial{Control: map[string]float64{"LimeContent": -1}}, Observations: []float64{1}}}
+		if rec := post(save(exp)); rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("%s without matching results: got status %d, want 422", design, rec.Code)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/analyze", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != http.MethodPost {
+		t.Errorf("GET: got status %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
+
+// TestFiniteJSON verifies that non-finite values become null while struct tags,
+// omitempty and nil collections are encoded as encoding/json would.
+func TestFiniteJSON(t *testing.T) {
+	type inner struct {
+		Skipped string `json:"-"`
+		Renamed int    `json:"renamed,omitempty"`
+	}
+	v := struct {
+		Values  map[string]float64
+		Slice   []float64
+		Nil     []float64
+		Inner   inner
+		Pointer *inner
+	}{
+		Values: map[string]float64{"a": 1, "b": math.Inf(1)},
+		Slice:  []float64{math.NaN(), 2},
+		Inner:  inner{Skipped: "x"},
+	}
+	got, err := json.Marshal(finiteJSON(reflect.ValueOf(v)))
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	want := `{"Inner":{},"Nil":null,"Pointer":null,"Slice":[null,2],"Values":{"a":1,"b":null}}`
+	if string(got) != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
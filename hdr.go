@@ -0,0 +1,315 @@
+package taguchi
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"math/bits"
+	"strings"
+)
+
+// HdrHistogram V2 encoding cookies, as written by the Java and Go implementations; bits
+// 4-7 carry the word size and are ignored on decoding.
+const (
+	hdrEncodingCookie           = 0x1c849313
+	hdrCompressedEncodingCookie = 0x1c849314
+	hdrCookieMask               = ^0xf0
+	hdrHeaderSize               = 40
+)
+
+// histogramObservations is the number of quantile-spaced observations
+// AddHistogramResult records per histogram for goals other than PercentileGoal.
+const histogramObservations = 100
+
+// HdrHistogram is a High Dynamic Range histogram (Gil Tene's HdrHistogram) of integer
+// values, e.g. latencies in microseconds as reported by wrk2, Gatling or hey: counts in
+// buckets whose width keeps every value within the configured number of significant
+// digits. It implements QuantileSketch, and reads and writes the standard compressed
+// V2 encoding (base64 text starting "HISTFAAA") used by histogram logs.
+type HdrHistogram struct {
+	lowest, highest int64
+	digits          int
+	unitMagnitude   int
+	subBucketHalf   int // subBucketCount / 2
+	subBucketHalfM  int // log2(subBucketHalf)
+	subBucketMask   int64
+	counts          []int64
+	total           int64
+}
+
+// NewHdrHistogram returns an empty histogram tracking values from lowest (at least 1)
+// to highest with digits (1 to 5) significant decimal digits.
+func NewHdrHistogram(lowest, highest int64, digits int) (*HdrHistogram, error) {
+	if lowest < 1 {
+		return nil, fmt.Errorf("histogram lowest discernible value must be at least 1, got %d", lowest)
+	}
+	if highest < 2*lowest {
+		return nil, fmt.Errorf("histogram highest trackable value %d must be at least twice the lowest %d", highest, lowest)
+	}
+	if digits < 1 || digits > 5 {
+		return nil, fmt.Errorf("histogram significant digits must be between 1 and 5, got %d", digits)
+	}
+	largest := 2 * int64(math.Pow10(digits))
+	subBucketCountM := bits.Len64(uint64(largest - 1))
+	h := &HdrHistogram{
+		lowest:         lowest,
+		highest:        highest,
+		digits:         digits,
+		unitMagnitude:  bits.Len64(uint64(lowest)) - 1,
+		subBucketHalfM: subBucketCountM - 1,
+	}
+	h.subBucketHalf = 1 << h.subBucketHalfM
+	h.subBucketMask = int64(2*h.subBucketHalf-1) << h.unitMagnitude
+
+	buckets := 1
+	for limit := int64(2*h.subBucketHalf) << h.unitMagnitude; limit <= highest; limit <<= 1 {
+		buckets++
+		if limit > math.MaxInt64/2 {
+			break
+		}
+	}
+	h.counts = make([]int64, (buckets+1)*h.subBucketHalf)
+	return h, nil
+}
+
+// RecordValues records n occurrences of value.
+func (h *HdrHistogram) RecordValues(value, n int64) error {
+	if value < 0 || value > h.highest {
+		return fmt.Errorf("value %d outside histogram range [0, %d]", value, h.highest)
+	}
+	h.counts[h.index(value)] += n
+	h.total += n
+	return nil
+}
+
+// Add records x rounded to the nearest integer, clamped to the trackable range.
+func (h *HdrHistogram) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	v := int64(math.Round(math.Max(0, math.Min(x, float64(h.highest)))))
+	h.RecordValues(v, 1)
+}
+
+// Count returns the number of recorded values.
+func (h *HdrHistogram) Count() int64 {
+	return h.total
+}
+
+// Quantile returns the q-quantile (0 <= q <= 1) of the recorded values, as the highest
+// value equivalent to the bucket holding it, or NaN if the histogram is empty.
+func (h *HdrHistogram) Quantile(q float64) float64 {
+	if h.total == 0 {
+		return math.NaN()
+	}
+	target := max(int64(math.Min(q, 1)*float64(h.total)+0.5), 1)
+	cumulative := int64(0)
+	for i, n := range h.counts {
+		cumulative += n
+		if cumulative >= target {
+			lo := h.valueFromIndex(i)
+			return float64(lo + h.bucketWidth(lo) - 1)
+		}
+	}
+	return 0
+}
+
+// Mean returns the mean of the recorded values, each taken at the middle of its bucket.
+func (h *HdrHistogram) Mean() float64 {
+	if h.total == 0 {
+		return math.NaN()
+	}
+	sum := 0.0
+	for i, n := range h.counts {
+		if n > 0 {
+			lo := h.valueFromIndex(i)
+			sum += float64(n) * float64(lo+h.bucketWidth(lo)/2)
+		}
+	}
+	return sum / float64(h.total)
+}
+
+// Observations returns n values at evenly spaced quantiles, (i+0.5)/n for i < n, each
+// taken at the middle of its bucket: a compact stand-in for the raw samples whose mean
+// and mean square match the histogram's up to bucket resolution.
+func (h *HdrHistogram) Observations(n int) []float64 {
+	if h.total == 0 || n <= 0 {
+		return nil
+	}
+	obs := make([]float64, 0, n)
+	cumulative := int64(0)
+	for i, c := range h.counts {
+		cumulative += c
+		lo := h.valueFromIndex(i)
+		for len(obs) < n && (float64(len(obs))+0.5)/float64(n)*float64(h.total) <= float64(cumulative) {
+			obs = append(obs, float64(lo+h.bucketWidth(lo)/2))
+		}
+	}
+	return obs
+}
+
+// index returns the counts index of a value.
+func (h *HdrHistogram) index(v int64) int {
+	pow2Ceiling := 64 - bits.LeadingZeros64(uint64(v|h.subBucketMask))
+	bucket := pow2Ceiling - h.unitMagnitude - (h.subBucketHalfM + 1)
+	subBucket := int(v >> (bucket + h.unitMagnitude))
+	return (bucket+1)<<h.subBucketHalfM + subBucket - h.subBucketHalf
+}
+
+// valueFromIndex returns the lowest value of the bucket at a counts index.
+func (h *HdrHistogram) valueFromIndex(i int) int64 {
+	bucket := i>>h.subBucketHalfM - 1
+	subBucket := i&(h.subBucketHalf-1) + h.subBucketHalf
+	if bucket < 0 {
+		subBucket -= h.subBucketHalf
+		bucket = 0
+	}
+	return int64(subBucket) << (bucket + h.unitMagnitude)
+}
+
+// bucketWidth returns the number of values equivalent to v, which is the lowest value of
+// its bucket.
+func (h *HdrHistogram) bucketWidth(v int64) int64 {
+	i := h.index(v)
+	bucket := max(i>>h.subBucketHalfM-1, 0)
+	return 1 << (bucket + h.unitMagnitude)
+}
+
+// Encode returns the histogram in the compressed V2 encoding, base64-encoded.
+func (h *HdrHistogram) Encode() (string, error) {
+	last := -1
+	for i, n := range h.counts {
+		if n != 0 {
+			last = i
+		}
+	}
+	var payload []byte
+	for i := 0; i <= last; i++ {
+		value := h.counts[i]
+		if value == 0 {
+			zeros := int64(1)
+			for i+1 <= last && h.counts[i+1] == 0 {
+				zeros++
+				i++
+			}
+			if zeros > 1 {
+				value = -zeros
+			}
+		}
+		payload = binary.AppendUvarint(payload, uint64(value<<1^value>>63))
+	}
+
+	var raw bytes.Buffer
+	for _, v := range []any{
+		int32(hdrEncodingCookie), int32(len(payload)), int32(0), int32(h.digits),
+		h.lowest, h.highest, float64(1),
+	} {
+		binary.Write(&raw, binary.BigEndian, v)
+	}
+	raw.Write(payload)
+
+	var compressed bytes.Buffer
+	w := zlib.NewWriter(&compressed)
+	if _, err := w.Write(raw.Bytes()); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	var out bytes.Buffer
+	binary.Write(&out, binary.BigEndian, int32(hdrCompressedEncodingCookie))
+	binary.Write(&out, binary.BigEndian, int32(compressed.Len()))
+	out.Write(compressed.Bytes())
+	return base64.StdEncoding.EncodeToString(out.Bytes()), nil
+}
+
+// DecodeHdrHistogram reads a histogram in the compressed V2 encoding, base64-encoded,
+// as found in the last field of HdrHistogram log lines.
+func DecodeHdrHistogram(encoded string) (*HdrHistogram, error) {
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decode histogram: %w", err)
+	}
+	if len(data) < 8 {
+		return nil, fmt.Errorf("decode histogram: %d bytes is too short", len(data))
+	}
+	if cookie := int32(binary.BigEndian.Uint32(data)); cookie&hdrCookieMask != hdrCompressedEncodingCookie&hdrCookieMask {
+		return nil, fmt.Errorf("decode histogram: unsupported encoding cookie %#x", cookie)
+	}
+	length := int(binary.BigEndian.Uint32(data[4:]))
+	if length > len(data)-8 {
+		return nil, fmt.Errorf("decode histogram: compressed length %d exceeds %d bytes", length, len(data)-8)
+	}
+	r, err := zlib.NewReader(bytes.NewReader(data[8 : 8+length]))
+	if err != nil {
+		return nil, fmt.Errorf("decode histogram: %w", err)
+	}
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("decode histogram: %w", err)
+	}
+	if len(raw) < hdrHeaderSize {
+		return nil, fmt.Errorf("decode histogram: header is truncated")
+	}
+	if cookie := int32(binary.BigEndian.Uint32(raw)); cookie&hdrCookieMask != hdrEncodingCookie&hdrCookieMask {
+		return nil, fmt.Errorf("decode histogram: unsupported payload cookie %#x", cookie)
+	}
+	payloadLen := int(binary.BigEndian.Uint32(raw[4:]))
+	digits := int(binary.BigEndian.Uint32(raw[12:]))
+	lowest := int64(binary.BigEndian.Uint64(raw[16:]))
+	highest := int64(binary.BigEndian.Uint64(raw[24:]))
+	h, err := NewHdrHistogram(lowest, highest, digits)
+	if err != nil {
+		return nil, fmt.Errorf("decode histogram: %w", err)
+	}
+	if payloadLen > len(raw)-hdrHeaderSize {
+		return nil, fmt.Errorf("decode histogram: payload is truncated")
+	}
+
+	payload := bytes.NewReader(raw[hdrHeaderSize : hdrHeaderSize+payloadLen])
+	for i := 0; payload.Len() > 0; {
+		u, err := binary.ReadUvarint(payload)
+		if err != nil {
+			return nil, fmt.Errorf("decode histogram: %w", err)
+		}
+		value := int64(u>>1) ^ -int64(u&1)
+		if value < 0 {
+			i += int(-value)
+			continue
+		}
+		if i >= len(h.counts) {
+			return nil, fmt.Errorf("decode histogram: counts exceed the histogram's %d buckets", len(h.counts))
+		}
+		h.counts[i] = value
+		h.total += value
+		i++
+	}
+	return h, nil
+}
+
+// AddHistogramResult records a trial whose replicates were summarized as histograms.
+// With a PercentileGoal each histogram contributes its percentile, as in
+// AddSketchResult; otherwise it contributes quantile-spaced observations (see
+// Observations), so that the SNR reflects its whole distribution.
+func (e *Experiment[P]) AddHistogramResult(trial Trial, histograms ...*HdrHistogram) error {
+	if _, ok := e.Goal.(PercentileGoal); ok {
+		sketches := make([]QuantileSketch, len(histograms))
+		for i, h := range histograms {
+			sketches[i] = h
+		}
+		return e.AddSketchResult(trial, sketches...)
+	}
+	var observations []float64
+	for i, h := range histograms {
+		if h.Count() == 0 {
+			return fmt.Errorf("histogram %d of trial %d has no observations", i+1, trial.ID)
+		}
+		observations = append(observations, h.Observations(histogramObservations)...)
+	}
+	e.AddResult(trial, observations)
+	return nil
+}
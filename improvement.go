@@ -0,0 +1,62 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+)
+
+// PercentChange translates an SNR difference of deltaDB into the expected percent change
+// of the raw response for the built-in goals: of the response for SmallerTheBetter and
+// LargerTheBetter, of its deviation from the target for NominalTheBest, of its
+// coefficient of variation for NominalTheBestI, of the odds of a defect for
+// FractionDefective, of the ratio of the lower to the upper threshold for
+// OperatingWindow and of the error relative to the slope for DynamicCharacteristic.
+// A gain is negative for all of them but LargerTheBetter, whose response grows.
+// A PercentileGoal is treated as its underlying goal. ok is false for PairedDifference,
+// whose SNR is a standardized mean difference rather than a dB value, and for custom
+// goals, whose SNR scale is unknown.
+func PercentChange(goal OptimizationGoal, deltaDB float64) (percent float64, ok bool) {
+	// Most built-in SNRs are -10·log10 of a mean square (of y, 1/y or y-Target, of the
+	// thresholds' ratio or of the error relative to the slope) or of the squared
+	// coefficient of variation, so a difference of d dB scales the corresponding root
+	// mean square or coefficient of variation by 10^(-d/20).
+	scale := math.Pow(10, -deltaDB/20)
+	switch baseGoal(goal).(type) {
+	case SmallerTheBetter, *SmallerTheBetter, NominalTheBest, *NominalTheBest, NominalTheBestI, *NominalTheBestI,
+		OperatingWindow, *OperatingWindow, DynamicCharacteristic, *DynamicCharacteristic:
+		return (scale - 1) * 100, true
+	case LargerTheBetter, *LargerTheBetter:
+		return (1/scale - 1) * 100, true
+	case FractionDefective, *FractionDefective:
+		// The omega transform is -10·log10 of the odds p/(1-p) itself, not of a square.
+		return (scale*scale - 1) * 100, true
+	}
+	return 0, false
+}
+
+// DescribeChange phrases PercentChange for reports, e.g. "~38% lower response" or
+// "~12% larger deviation from the target". It returns "" for goals PercentChange does
+// not translate.
+func DescribeChange(goal OptimizationGoal, deltaDB float64) string {
+	percent, ok := PercentChange(goal, deltaDB)
+	if !ok {
+		return ""
+	}
+	subject, lower, higher := "response", "lower", "higher"
+	switch baseGoal(goal).(type) {
+	case NominalTheBest, *NominalTheBest:
+		subject, lower, higher = "deviation from the target", "smaller", "larger"
+	case NominalTheBestI, *NominalTheBestI:
+		subject, lower, higher = "coefficient of variation", "smaller", "larger"
+	case FractionDefective, *FractionDefective:
+		subject = "odds of a defect"
+	case OperatingWindow, *OperatingWindow:
+		subject, lower, higher = "ratio of the lower to the upper threshold", "smaller", "larger"
+	case DynamicCharacteristic, *DynamicCharacteristic:
+		subject, lower, higher = "error relative to the slope", "smaller", "larger"
+	}
+	if percent <= 0 {
+		return fmt.Sprintf("~%.0f%% %s %s", math.Abs(percent), lower, subject)
+	}
+	return fmt.Sprintf("~%.0f%% %s %s", percent, higher, subject)
+}
@@ -0,0 +1,163 @@
+package taguchi
+
+import (
+	"fmt"
+	"slices"
+)
+
+// InteractionTable lists, for each pair of columns of an orthogonal array, the columns
+// confounded with their interaction: columns whose level is fully determined by the
+// levels of the pair. Read as a linear graph, the columns are the nodes and each pair
+// with a non-empty entry is an edge labelled with its interaction columns. Columns are
+// zero-based.
+type InteractionTable map[[2]int][]int
+
+// Interaction returns the columns that carry the interaction of columns a and b, or nil
+// if the interaction is not confined to any column (as in L12, L18 and L36, where it is
+// spread over many columns).
+func (t InteractionTable) Interaction(a, b int) []int {
+	if a > b {
+		a, b = b, a
+	}
+	return t[[2]int{a, b}]
+}
+
+// ArrayInteractions returns the interaction table of a standard array. In L8, for
+// example, the interaction of columns 0 and 1 falls on column 2; in L9 and L27 the
+// interaction of two three-level columns occupies two columns.
+func ArrayInteractions(name ArrayType) (InteractionTable, error) {
+	oa, ok := StandardArrays[name]
+	if !ok {
+		return nil, fmt.Errorf("orthogonal array %s not defined", name)
+	}
+	return Interactions(oa), nil
+}
+
+// Interactions computes the interaction table of an orthogonal array.
+func Interactions(oa [][]int) InteractionTable {
+	table := make(InteractionTable)
+	if len(oa) == 0 {
+		return table
+	}
+	cols := len(oa[0])
+	for a := 0; a < cols; a++ {
+		for b := a + 1; b < cols; b++ {
+			if columns := interactionColumns(oa, a, b); len(columns) > 0 {
+				table[[2]int{a, b}] = columns
+			}
+		}
+	}
+	return table
+}
+
+// interactionColumns returns the columns of oa that carry the interaction of columns
+// a and b.
+func interactionColumns(oa [][]int, a, b int) []int {
+	var columns []int
+	for c := range oa[0] {
+		if c != a && c != b && determinedBy(oa, c, a, b) {
+			columns = append(columns, c)
+		}
+	}
+	return columns
+}
+
+// determinedBy reports whether the level of column c is a function of the levels of
+// columns a and b.
+func determinedBy(oa [][]int, c, a, b int) bool {
+	seen := make(map[[2]int]int)
+	for _, row := range oa {
+		key := [2]int{row[a], row[b]}
+		if level, ok := seen[key]; ok && level != row[c] {
+			return false
+		}
+		seen[key] = row[c]
+	}
+	return true
+}
+
+// AssignInteraction reserves the columns that carry the interaction of control factors
+// a and b, so that no other factor is assigned to them and their effects stay
+// unconfounded with it. The orthogonal array's columns are rearranged: factors are
+// placed on the first free columns in order, skipping columns reserved for the
+// interactions assigned so far. It returns the columns of OrthogonalArray that carry
+// the interaction, and must be called before any results are recorded.
+func (e *Experiment[P]) AssignInteraction(a, b string) ([]int, error) {
+	if e.source != nil {
+		return nil, fmt.Errorf("interaction assignment requires an in-memory orthogonal array")
+	}
+	if len(e.Results) > 0 {
+		return nil, fmt.Errorf("cannot assign interactions after results are recorded")
+	}
+	x := slices.IndexFunc(e.ControlFactors, func(f ControlFactor) bool { return f.Name == a })
+	y := slices.IndexFunc(e.ControlFactors, func(f ControlFactor) bool { return f.Name == b })
+	switch {
+	case x < 0:
+		return nil, fmt.Errorf("control factor %s not found", a)
+	case y < 0:
+		return nil, fmt.Errorf("control factor %s not found", b)
+	case x == y:
+		return nil, fmt.Errorf("cannot assign the interaction of factor %s with itself", a)
+	}
+	pair := [2]int{min(x, y), max(x, y)}
+	if slices.Contains(e.interactions, pair) {
+		return interactionColumns(e.OrthogonalArray, x, y), nil
+	}
+	pairs := append(slices.Clone(e.interactions), pair)
+
+	width := len(e.OrthogonalArray[0])
+	columns := make([]int, len(e.ControlFactors))
+	owner := make(map[int]string)
+	next := 0
+	for f, factor := range e.ControlFactors {
+		for next < width && owner[next] != "" {
+			next++
+		}
+		if next == width {
+			return nil, fmt.Errorf("orthogonal array has too few columns for %d factors and the assigned interactions", len(e.ControlFactors))
+		}
+		columns[f] = next
+		owner[next] = factor.Name
+		for _, p := range pairs {
+			if p[1] != f {
+				continue
+			}
+			first, second := e.ControlFactors[p[0]].Name, factor.Name
+			reserved := interactionColumns(e.OrthogonalArray, columns[p[0]], columns[f])
+			if len(reserved) == 0 {
+				return nil, fmt.Errorf("the interaction of %s and %s is not confined to any column of the orthogonal array", first, second)
+			}
+			for _, c := range reserved {
+				if owner[c] != "" {
+					return nil, fmt.Errorf("the interaction of %s and %s falls on the column of %s; reorder the factors", first, second, owner[c])
+				}
+				owner[c] = first + "x" + second
+			}
+		}
+	}
+
+	// Factor columns first, then the remaining columns in their original order.
+	order := slices.Clone(columns)
+	for c := 0; c < width; c++ {
+		if !slices.Contains(columns, c) {
+			order = append(order, c)
+		}
+	}
+	oa := make([][]int, len(e.OrthogonalArray))
+	for i, row := range e.OrthogonalArray {
+		oa[i] = make([]int, width)
+		for j, c := range order {
+			oa[i][j] = row[c]
+		}
+	}
+	spec, err := NewArraySpec(oa)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(e.ControlFactors); err != nil {
+		return nil, err
+	}
+	e.OrthogonalArray = oa
+	e.interactions = pairs
+	return interactionColumns(oa, x, y), nil
+}
@@ -0,0 +1,71 @@
+package taguchi
+
+import "fmt"
+
+// ABBABlock is one candidate trial interleaved with a baseline configuration under the
+// same noise levels, to be run in the order baseline, candidate, candidate, baseline.
+// Any drift that is linear over the block, e.g. a warming machine or rising traffic,
+// then affects both configurations equally and cancels from their difference.
+// Baseline: The baseline configuration under the candidate's noise levels; its ID is 0,
+// as it is not part of the design.
+// Candidate: The trial, with the same ID as in GenerateTrials.
+type ABBABlock struct {
+	Baseline  Trial
+	Candidate Trial
+}
+
+// Runs returns the block's four runs in order: baseline, candidate, candidate, baseline.
+func (b ABBABlock) Runs() [4]Trial {
+	return [4]Trial{b.Baseline, b.Candidate, b.Candidate, b.Baseline}
+}
+
+// InterleaveBaseline generates the trials as ABBA blocks against baseline, a setting of
+// every control factor such as the current production configuration. Blocks are grouped
+// by noise window: all candidates under the first noise combination, then the second,
+// and so on, so each noise condition is set up once. This removes drift bias at the
+// cost of running the baseline twice per trial, without randomizing the run order.
+// Record each block with AddABBAResult.
+func (e *Experiment[P]) InterleaveBaseline(baseline map[string]float64) ([]ABBABlock, error) {
+	for _, factor := range e.ControlFactors {
+		if _, ok := baseline[factor.Name]; !ok {
+			return nil, fmt.Errorf("baseline has no level for control factor %s", factor.Name)
+		}
+	}
+	if len(baseline) != len(e.ControlFactors) {
+		return nil, fmt.Errorf("baseline sets %d factors, the experiment has %d control factors", len(baseline), len(e.ControlFactors))
+	}
+	trials := e.GenerateTrials()
+	windows := len(e.generateNoiseCombinations())
+	blocks := make([]ABBABlock, 0, len(trials))
+	for k := 0; k < windows; k++ {
+		for i := k; i < len(trials); i += windows {
+			blocks = append(blocks, ABBABlock{
+				Baseline:  Trial{Control: baseline, Noise: trials[i].Noise},
+				Candidate: trials[i],
+			})
+		}
+	}
+	return blocks, nil
+}
+
+// AddABBAResult records an ABBA block from the observations of its four runs, in the
+// order of Runs. Each replicate's observation is the drift-free difference
+// (b₁ + b₂ - a₁ - a₂) / 2, candidate minus baseline, so the experiment's goal should be
+// a PairedDifference.
+func (e *Experiment[P]) AddABBAResult(block ABBABlock, runs [4][]float64) error {
+	n := len(runs[0])
+	for r, obs := range runs {
+		if len(obs) != n {
+			return fmt.Errorf("trial %d: run %d has %d observations, run 1 has %d", block.Candidate.ID, r+1, len(obs), n)
+		}
+	}
+	if n == 0 {
+		return fmt.Errorf("trial %d has no observations", block.Candidate.ID)
+	}
+	diffs := make([]float64, n)
+	for i := range diffs {
+		diffs[i] = (runs[1][i] + runs[2][i] - runs[0][i] - runs[3][i]) / 2
+	}
+	e.AddResult(block.Candidate, diffs)
+	return nil
+}
@@ -0,0 +1,59 @@
+package taguchi
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// ContinuousFactor is a control parameter that can take any value in a range.
+// Name: Name of the factor.
+// Min: Lower end of the range.
+// Max: Upper end of the range.
+type ContinuousFactor struct {
+	Name string
+	Min  float64
+	Max  float64
+}
+
+// NewLatinHypercubeExperiment builds an exploratory experiment with n runs over
+// continuous factors. Each factor's range is cut into n equal strata and every stratum
+// is sampled exactly once, at a random point within it; the strata are paired across
+// factors by random permutations drawn from seed. Factor i gets the n sampled values
+// as its levels, so Trials, AddResult and Params work as usual. Analyze fits a linear
+// regression of the run SNRs on the factor values instead of averaging per level, so n
+// must exceed the number of factors plus one.
+func NewLatinHypercubeExperiment(goal OptimizationGoal, factors []ContinuousFactor, n int, seed int64, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	if len(factors) == 0 {
+		return nil, fmt.Errorf("latin hypercube needs at least 1 factor")
+	}
+	if n < len(factors)+2 {
+		return nil, fmt.Errorf("latin hypercube with %d factors needs at least %d runs, got %d", len(factors), len(factors)+2, n)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	controlFactors := make([]ControlFactor, len(factors))
+	rows := make([][]int, n)
+	for i := range rows {
+		rows[i] = make([]int, len(factors))
+	}
+	for j, f := range factors {
+		if !(f.Min < f.Max) {
+			return nil, fmt.Errorf("factor %s: min %v must be less than max %v", f.Name, f.Min, f.Max)
+		}
+		width := (f.Max - f.Min) / float64(n)
+		levels := make([]float64, n)
+		for l := range levels {
+			levels[l] = f.Min + (float64(l)+rng.Float64())*width
+		}
+		controlFactors[j] = ControlFactor{Name: f.Name, Levels: levels}
+		for i, l := range rng.Perm(n) {
+			rows[i][j] = l + 1
+		}
+	}
+	return &Experiment[struct{}]{
+		ControlFactors:  controlFactors,
+		NoiseFactors:    noiseFactors,
+		Goal:            goal,
+		OrthogonalArray: rows,
+		design:          designLatinHypercube,
+	}, nil
+}
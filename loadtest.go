@@ -0,0 +1,218 @@
+package taguchi
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LoadTestResult is the summary of one load-testing run, as parsed from k6, vegeta or
+// wrk output, from which AddLoadTestResult takes a trial's observations.
+// Tool: The tool that produced the summary ("k6", "vegeta" or "wrk").
+// Requests: Number of requests sent.
+// Errors: Number of failed requests (k6 http_req_failed, vegeta non-success, wrk socket
+// errors and non-2xx/3xx responses).
+// Throughput: Requests per second.
+// Latency: Latency statistics by name: "mean", "max" and percentiles such as "p50" and
+// "p99", as far as the tool reports them.
+type LoadTestResult struct {
+	Tool       string
+	Requests   int64
+	Errors     int64
+	Throughput float64
+	Latency    map[string]time.Duration
+}
+
+// Metric returns a named metric of the run: a latency statistic (see Latency) in
+// milliseconds, "throughput" in requests per second, "errors", or "error_rate" as the
+// fraction of failed requests.
+func (r LoadTestResult) Metric(name string) (float64, error) {
+	switch name {
+	case "throughput":
+		return r.Throughput, nil
+	case "errors":
+		return float64(r.Errors), nil
+	case "error_rate":
+		if r.Requests == 0 {
+			return 0, fmt.Errorf("%s result has no requests", r.Tool)
+		}
+		return float64(r.Errors) / float64(r.Requests), nil
+	}
+	d, ok := r.Latency[name]
+	if !ok {
+		return 0, fmt.Errorf("%s result has no metric %q", r.Tool, name)
+	}
+	return float64(d) / float64(time.Millisecond), nil
+}
+
+// AddLoadTestResult records a trial measured with a load-testing tool: each result is
+// one replicate and contributes its metric (see Metric) as an observation, e.g. "p99"
+// with SmallerTheBetter or "throughput" with LargerTheBetter.
+func (e *Experiment[P]) AddLoadTestResult(trial Trial, metric string, results ...LoadTestResult) error {
+	observations := make([]float64, len(results))
+	for i, r := range results {
+		v, err := r.Metric(metric)
+		if err != nil {
+			return fmt.Errorf("trial %d: %w", trial.ID, err)
+		}
+		observations[i] = v
+	}
+	e.AddResult(trial, observations)
+	return nil
+}
+
+// ParseK6Summary reads a k6 end-of-test summary in JSON, as written by
+// --summary-export or by handleSummary's data argument. Trend values are in
+// milliseconds; http_req_duration provides the latencies.
+func ParseK6Summary(r io.Reader) (LoadTestResult, error) {
+	var doc struct {
+		Metrics map[string]json.RawMessage `json:"metrics"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return LoadTestResult{}, fmt.Errorf("parse k6 summary: %w", err)
+	}
+	// metric returns a metric's values, which handleSummary nests under "values".
+	metric := func(name string) (map[string]float64, bool) {
+		raw, ok := doc.Metrics[name]
+		if !ok {
+			return nil, false
+		}
+		var nested struct {
+			Values map[string]float64 `json:"values"`
+		}
+		if json.Unmarshal(raw, &nested) == nil && nested.Values != nil {
+			return nested.Values, true
+		}
+		flat := map[string]float64{}
+		var fields map[string]json.RawMessage
+		if json.Unmarshal(raw, &fields) != nil {
+			return nil, false
+		}
+		for k, v := range fields {
+			var f float64
+			if json.Unmarshal(v, &f) == nil {
+				flat[k] = f
+			}
+		}
+		return flat, true
+	}
+
+	duration, ok := metric("http_req_duration")
+	if !ok {
+		return LoadTestResult{}, fmt.Errorf("parse k6 summary: no http_req_duration metric")
+	}
+	result := LoadTestResult{Tool: "k6", Latency: map[string]time.Duration{}}
+	ms := func(v float64) time.Duration { return time.Duration(v * float64(time.Millisecond)) }
+	for key, v := range duration {
+		switch {
+		case key == "avg":
+			result.Latency["mean"] = ms(v)
+		case key == "max", key == "min":
+			result.Latency[key] = ms(v)
+		case key == "med":
+			result.Latency["p50"] = ms(v)
+		case strings.HasPrefix(key, "p(") && strings.HasSuffix(key, ")"):
+			result.Latency["p"+key[2:len(key)-1]] = ms(v)
+		}
+	}
+	if reqs, ok := metric("http_reqs"); ok {
+		result.Requests = int64(reqs["count"])
+		result.Throughput = reqs["rate"]
+	}
+	if failed, ok := metric("http_req_failed"); ok {
+		result.Errors = int64(failed["passes"])
+	}
+	return result, nil
+}
+
+// ParseVegetaReport reads a vegeta report in JSON (vegeta report -type=json), whose
+// latencies are in nanoseconds. Throughput is vegeta's rate of successful requests.
+func ParseVegetaReport(r io.Reader) (LoadTestResult, error) {
+	var doc struct {
+		Latencies  map[string]int64 `json:"latencies"`
+		Requests   int64            `json:"requests"`
+		Throughput float64          `json:"throughput"`
+		Success    float64          `json:"success"`
+	}
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return LoadTestResult{}, fmt.Errorf("parse vegeta report: %w", err)
+	}
+	if doc.Latencies == nil {
+		return LoadTestResult{}, fmt.Errorf("parse vegeta report: no latencies")
+	}
+	result := LoadTestResult{
+		Tool:       "vegeta",
+		Requests:   doc.Requests,
+		Errors:     int64(math.Round(float64(doc.Requests) * (1 - doc.Success))),
+		Throughput: doc.Throughput,
+		Latency:    map[string]time.Duration{},
+	}
+	for key, v := range doc.Latencies {
+		switch {
+		case key == "mean", key == "max", key == "min":
+			result.Latency[key] = time.Duration(v)
+		case strings.HasSuffix(key, "th"):
+			result.Latency["p"+strings.TrimSuffix(key, "th")] = time.Duration(v)
+		}
+	}
+	return result, nil
+}
+
+var (
+	wrkLatency      = regexp.MustCompile(`^Latency\s+(\d\S*)\s+\S+\s+(\d\S*)`)
+	wrkDistribution = regexp.MustCompile(`^(\d+(?:\.\d+)?)%\s+(\S+)$`)
+	wrkRequests     = regexp.MustCompile(`^(\d+) requests in`)
+	wrkSocketErrors = regexp.MustCompile(`^Socket errors: connect (\d+), read (\d+), write (\d+), timeout (\d+)`)
+	wrkNon2xx       = regexp.MustCompile(`^Non-2xx or 3xx responses: (\d+)`)
+	wrkRate         = regexp.MustCompile(`^Requests/sec:\s+(\S+)`)
+)
+
+// ParseWrkOutput reads wrk's text output. The latency percentiles are only present
+// when wrk ran with --latency.
+func ParseWrkOutput(r io.Reader) (LoadTestResult, error) {
+	result := LoadTestResult{Tool: "wrk", Latency: map[string]time.Duration{}}
+	sawLatency := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		var err error
+		if m := wrkLatency.FindStringSubmatch(line); m != nil {
+			sawLatency = true
+			if result.Latency["mean"], err = time.ParseDuration(m[1]); err == nil {
+				result.Latency["max"], err = time.ParseDuration(m[2])
+			}
+		} else if m := wrkDistribution.FindStringSubmatch(line); m != nil {
+			// wrk2 prints percentiles as 99.900%; name them like k6 and vegeta, p99.9.
+			percent, _ := strconv.ParseFloat(m[1], 64)
+			result.Latency["p"+strconv.FormatFloat(percent, 'f', -1, 64)], err = time.ParseDuration(m[2])
+		} else if m := wrkRequests.FindStringSubmatch(line); m != nil {
+			result.Requests, err = strconv.ParseInt(m[1], 10, 64)
+		} else if m := wrkSocketErrors.FindStringSubmatch(line); m != nil {
+			for _, s := range m[1:] {
+				n, _ := strconv.ParseInt(s, 10, 64)
+				result.Errors += n
+			}
+		} else if m := wrkNon2xx.FindStringSubmatch(line); m != nil {
+			n, _ := strconv.ParseInt(m[1], 10, 64)
+			result.Errors += n
+		} else if m := wrkRate.FindStringSubmatch(line); m != nil {
+			result.Throughput, err = strconv.ParseFloat(m[1], 64)
+		}
+		if err != nil {
+			return LoadTestResult{}, fmt.Errorf("parse wrk output: %q: %w", line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return LoadTestResult{}, fmt.Errorf("parse wrk output: %w", err)
+	}
+	if !sawLatency {
+		return LoadTestResult{}, fmt.Errorf("parse wrk output: no latency statistics")
+	}
+	return result, nil
+}
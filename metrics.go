@@ -0,0 +1,77 @@
+package taguchi
+
+import (
+	"errors"
+	"time"
+)
+
+// MetricSampler reads a snapshot of uncontrolled environment metrics, keyed by name.
+type MetricSampler interface {
+	Sample() (map[string]float64, error)
+}
+
+// SystemMetrics samples host metrics that commonly act as noise in performance trials:
+// "cpu_load" is the 1-minute load average divided by the number of CPUs, and
+// "memory_pressure" is the share of memory that is not available (0 to 1).
+// Sampling is supported on Linux; elsewhere Sample returns an error.
+type SystemMetrics struct{}
+
+// SampleDuring runs fn while sampling m every interval, and returns the mean of each
+// metric over all samples, including one taken before and one after fn. A metric
+// missing from some samples is averaged over the samples that report it. If fn fails
+// its error is returned; sampling errors are returned only if no sample succeeded.
+func SampleDuring(m MetricSampler, interval time.Duration, fn func() error) (map[string]float64, error) {
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	var sampleErr error
+	sample := func() {
+		values, err := m.Sample()
+		if err != nil {
+			sampleErr = err
+			return
+		}
+		for k, v := range values {
+			sums[k] += v
+			counts[k]++
+		}
+	}
+
+	sample()
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		if interval <= 0 {
+			return
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				sample()
+			}
+		}
+	}()
+	err := fn()
+	close(done)
+	<-stopped
+	sample()
+
+	if err != nil {
+		return nil, err
+	}
+	if len(sums) == 0 {
+		if sampleErr == nil {
+			sampleErr = errors.New("metric sampler returned no values")
+		}
+		return nil, sampleErr
+	}
+	means := make(map[string]float64, len(sums))
+	for k, sum := range sums {
+		means[k] = sum / float64(counts[k])
+	}
+	return means, nil
+}
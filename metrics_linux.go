@@ -0,0 +1,58 @@
+//go:build linux
+
+package taguchi
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Sample reads the load average from /proc/loadavg and memory usage from /proc/meminfo.
+func (SystemMetrics) Sample() (map[string]float64, error) {
+	loadavg, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Fields(string(loadavg))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("parse /proc/loadavg: empty file")
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return nil, fmt.Errorf("parse /proc/loadavg: %w", err)
+	}
+
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	mem := map[string]float64{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// Lines look like "MemAvailable:   12345678 kB".
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		if v, err := strconv.ParseFloat(fields[1], 64); err == nil {
+			mem[strings.TrimSuffix(fields[0], ":")] = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read /proc/meminfo: %w", err)
+	}
+	total, available := mem["MemTotal"], mem["MemAvailable"]
+	if total <= 0 {
+		return nil, fmt.Errorf("parse /proc/meminfo: no MemTotal")
+	}
+
+	return map[string]float64{
+		"cpu_load":        load / float64(runtime.NumCPU()),
+		"memory_pressure": 1 - available/total,
+	}, nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package taguchi
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Sample is not supported on this platform.
+func (SystemMetrics) Sample() (map[string]float64, error) {
+	return nil, fmt.Errorf("system metrics are not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,116 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// Correction selects how CorrectPValues adjusts factor p-values for multiple comparisons.
+type Correction int
+
+const (
+	// HolmCorrection controls the family-wise error rate (the chance of any false
+	// claim) with Holm's step-down procedure.
+	HolmCorrection Correction = iota + 1
+	// BenjaminiHochbergCorrection controls the false discovery rate (the expected share
+	// of false claims among the significant factors). It is less conservative than Holm.
+	BenjaminiHochbergCorrection
+)
+
+// String returns the human-readable name of the correction method.
+func (c Correction) String() string {
+	switch c {
+	case HolmCorrection:
+		return "Holm"
+	case BenjaminiHochbergCorrection:
+		return "Benjamini-Hochberg"
+	default:
+		return "unknown"
+	}
+}
+
+// CorrectedPValues stores factor p-values adjusted for multiple comparisons.
+// Method: The correction that was applied.
+// Alpha: Significance level the adjusted p-values are compared against.
+// Raw: The unadjusted p-values.
+// Adjusted: The adjusted p-values.
+// Significant: Whether each factor's adjusted p-value is at most Alpha.
+type CorrectedPValues struct {
+	Method      Correction
+	Alpha       float64
+	Raw         map[string]float64
+	Adjusted    map[string]float64
+	Significant map[string]bool
+}
+
+// PValues returns the F-test p-value of each factor's effect. Factors get NaN when the
+// design leaves no error degrees of freedom.
+func (a ANOVAResult) PValues() map[string]float64 {
+	pValues := make(map[string]float64, len(a.FactorF))
+	for factor, f := range a.FactorF {
+		if a.ErrorDF < 1 || a.ErrorMS <= 0 {
+			pValues[factor] = math.NaN()
+			continue
+		}
+		pValues[factor] = fSurvival(f, float64(a.FactorDF[factor]), float64(a.ErrorDF))
+	}
+	return pValues
+}
+
+// CorrectPValues adjusts per-factor p-values (from ANOVAResult.PValues, PermutationTest
+// or the Kruskal-Wallis tests) for the number of factors tested, so that screening many
+// factors in a saturated array does not over-claim significance. Factors with a NaN
+// p-value are left out of the family and reported as not significant.
+func CorrectPValues(pValues map[string]float64, method Correction, alpha float64) (CorrectedPValues, error) {
+	if alpha <= 0 || alpha >= 1 {
+		return CorrectedPValues{}, fmt.Errorf("alpha must be in (0, 1), got %v", alpha)
+	}
+	if method != HolmCorrection && method != BenjaminiHochbergCorrection {
+		return CorrectedPValues{}, fmt.Errorf("unknown correction method %d", method)
+	}
+
+	result := CorrectedPValues{
+		Method:      method,
+		Alpha:       alpha,
+		Raw:         make(map[string]float64, len(pValues)),
+		Adjusted:    make(map[string]float64, len(pValues)),
+		Significant: make(map[string]bool, len(pValues)),
+	}
+	var names []string
+	for _, name := range sortedKeys(pValues) {
+		p := pValues[name]
+		result.Raw[name] = p
+		if math.IsNaN(p) {
+			result.Adjusted[name] = math.NaN()
+			result.Significant[name] = false
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.SliceStable(names, func(i, j int) bool { return pValues[names[i]] < pValues[names[j]] })
+
+	m := float64(len(names))
+	switch method {
+	case HolmCorrection:
+		// Step down: multiply the i-th smallest p-value by (m-i) and keep the running maximum.
+		running := 0.0
+		for i, name := range names {
+			running = math.Max(running, math.Min(1, (m-float64(i))*pValues[name]))
+			result.Adjusted[name] = running
+		}
+	case BenjaminiHochbergCorrection:
+		// Step up: multiply the i-th smallest p-value by m/i and keep the running minimum
+		// from the largest p-value down.
+		running := 1.0
+		for i := len(names) - 1; i >= 0; i-- {
+			name := names[i]
+			running = math.Min(running, m/float64(i+1)*pValues[name])
+			result.Adjusted[name] = running
+		}
+	}
+	for _, name := range names {
+		result.Significant[name] = result.Adjusted[name] <= alpha
+	}
+	return result, nil
+}
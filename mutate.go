@@ -0,0 +1,119 @@
+package taguchi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// RenameFactor renames a control or noise factor in the design and in every recorded
+// result, e.g. after the corresponding field of the params struct was renamed, so that
+// results loaded from an older checkpoint keep matching the design.
+func (e *Experiment[P]) RenameFactor(oldName, newName string) error {
+	if oldName == newName {
+		return nil
+	}
+	if e.hasFactor(newName) {
+		return fmt.Errorf("factor %s already exists", newName)
+	}
+	control := slices.IndexFunc(e.ControlFactors, func(f ControlFactor) bool { return f.Name == oldName })
+	noise := slices.IndexFunc(e.NoiseFactors, func(f NoiseFactor) bool { return f.Name == oldName })
+	switch {
+	case control >= 0:
+		e.ControlFactors = slices.Clone(e.ControlFactors)
+		e.ControlFactors[control].Name = newName
+	case noise >= 0:
+		e.NoiseFactors = slices.Clone(e.NoiseFactors)
+		e.NoiseFactors[noise].Name = newName
+	default:
+		return fmt.Errorf("factor %s not found", oldName)
+	}
+
+	e.rewriteResults(func(t *Trial) {
+		if control >= 0 {
+			t.Control = renameKey(t.Control, oldName, newName)
+		} else {
+			t.Noise = renameKey(t.Noise, oldName, newName)
+		}
+	})
+	return nil
+}
+
+// RelabelLevel changes the value of one level of a control or noise factor in the
+// design and in every recorded result, e.g. to correct a mistyped setting.
+func (e *Experiment[P]) RelabelLevel(factor string, oldLevel, newLevel float64) error {
+	var levels []float64
+	control := slices.IndexFunc(e.ControlFactors, func(f ControlFactor) bool { return f.Name == factor })
+	noise := slices.IndexFunc(e.NoiseFactors, func(f NoiseFactor) bool { return f.Name == factor })
+	switch {
+	case control >= 0:
+		levels = e.ControlFactors[control].Levels
+	case noise >= 0:
+		levels = e.NoiseFactors[noise].Levels
+	default:
+		return fmt.Errorf("factor %s not found", factor)
+	}
+	l := slices.Index(levels, oldLevel)
+	if l < 0 {
+		return fmt.Errorf("factor %s has no level %v", factor, oldLevel)
+	}
+	if slices.Contains(levels, newLevel) {
+		return fmt.Errorf("factor %s already has level %v", factor, newLevel)
+	}
+	if control >= 0 && e.ControlFactors[control].Rules != nil {
+		if err := e.ControlFactors[control].Rules.Check(newLevel); err != nil {
+			return fmt.Errorf("factor %s: %w", factor, err)
+		}
+	}
+
+	levels = slices.Clone(levels)
+	levels[l] = newLevel
+	if control >= 0 {
+		e.ControlFactors = slices.Clone(e.ControlFactors)
+		e.ControlFactors[control].Levels = levels
+	} else {
+		e.NoiseFactors = slices.Clone(e.NoiseFactors)
+		e.NoiseFactors[noise].Levels = levels
+	}
+
+	e.rewriteResults(func(t *Trial) {
+		m := &t.Noise
+		if control >= 0 {
+			m = &t.Control
+		}
+		if v, ok := (*m)[factor]; ok && v == oldLevel {
+			*m = maps.Clone(*m)
+			(*m)[factor] = newLevel
+		}
+	})
+	return nil
+}
+
+// hasFactor reports whether a control or noise factor with the given name exists.
+func (e *Experiment[P]) hasFactor(name string) bool {
+	return slices.ContainsFunc(e.ControlFactors, func(f ControlFactor) bool { return f.Name == name }) ||
+		slices.ContainsFunc(e.NoiseFactors, func(f NoiseFactor) bool { return f.Name == name })
+}
+
+// rewriteResults applies fn to the trial of every result, including ad-hoc results.
+func (e *Experiment[P]) rewriteResults(fn func(t *Trial)) {
+	for _, results := range []*[]TrialResult{&e.Results, &e.AdHocResults} {
+		*results = slices.Clone(*results)
+		for i := range *results {
+			fn(&(*results)[i].Trial)
+		}
+	}
+}
+
+// renameKey returns a copy of m with oldKey renamed to newKey. Trials generated for the
+// same array row share their control map, so maps are never modified in place.
+func renameKey(m map[string]float64, oldKey, newKey string) map[string]float64 {
+	v, ok := m[oldKey]
+	if !ok {
+		return m
+	}
+	m = maps.Clone(m)
+	delete(m, oldKey)
+	m[newKey] = v
+	return m
+}
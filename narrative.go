@@ -0,0 +1,97 @@
+package taguchi
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// minorContribution is the contribution (percent) below which the narrative groups
+// factors together as having little effect.
+const minorContribution = 5.0
+
+// narrative explains the analysis in plain language for readers who are not
+// statisticians: how much of the variation each factor explains, which level change
+// helps most and what that means for the raw response. Rank-based results have no dB
+// scale, so their narrative names the best and worst levels without predicting a gain.
+func (e *Experiment[P]) narrative(result AnalysisResult, grandMean float64) []string {
+	if len(result.MainEffects) == 0 {
+		return nil
+	}
+	factors := slices.Clone(e.ControlFactors)
+	slices.SortStableFunc(factors, func(a, b ControlFactor) int {
+		return cmp.Compare(result.Contributions[b.Name], result.Contributions[a.Name])
+	})
+
+	var lines, minor []string
+	total := 0.0
+	for _, factor := range factors {
+		effects := result.MainEffects[factor.Name]
+		if len(effects) == 0 || math.IsNaN(result.Contributions[factor.Name]) {
+			continue
+		}
+		worst, best := 0, 0
+		for l, v := range effects {
+			if v < effects[worst] {
+				worst = l
+			}
+			if v > effects[best] {
+				best = l
+			}
+		}
+		total += effects[best] - grandMean
+		contribution := result.Contributions[factor.Name]
+		if contribution < minorContribution {
+			minor = append(minor, factor.Name)
+			continue
+		}
+		if !effectsInDB(result.Method) {
+			lines = append(lines, fmt.Sprintf("Factor %s explains %.0f%% of the rank variation; its runs rank best at %s and worst at %s.",
+				factor.Name, contribution, levelLabel(factor, best), levelLabel(factor, worst)))
+			continue
+		}
+		gain := effects[best] - effects[worst]
+		line := fmt.Sprintf("Factor %s explains %.0f%% of the variation; move from %s to %s for an estimated %.1f dB SNR gain",
+			factor.Name, contribution, levelLabel(factor, worst), levelLabel(factor, best), gain)
+		if change := DescribeChange(e.Goal, gain); change != "" {
+			line += ", i.e. " + change
+		}
+		lines = append(lines, line+".")
+	}
+	if len(minor) > 0 {
+		lines = append(lines, fmt.Sprintf("%s each explain less than %.0f%% of the variation; set them by cost or convenience.",
+			strings.Join(minor, ", "), minorContribution))
+	}
+	if total > 0 && effectsInDB(result.Method) {
+		line := fmt.Sprintf("Together, the optimal levels are predicted to improve SNR by %.1f dB over the average run", total)
+		if change := DescribeChange(e.Goal, total); change != "" {
+			line += ", i.e. " + change
+		}
+		lines = append(lines, line+"; confirm with a verification run.")
+	}
+	return lines
+}
+
+// effectsInDB reports whether the main effects of an analysis by method are SNRs in dB,
+// as opposed to the mean ranks of AnalyzeNonparametric.
+func effectsInDB(method string) bool {
+	return method != MethodKruskalWallis
+}
+
+// levelLabel formats level l of a factor for the narrative by its label, if it has one,
+// expanding compound factors into their component values.
+func levelLabel(factor ControlFactor, l int) string {
+	if len(factor.Labels) == len(factor.Levels) {
+		return factor.Labels[l]
+	}
+	if factor.Components == nil {
+		return formatLevel(factor.Levels[l], factor.Duration)
+	}
+	parts := make([]string, 0, len(factor.Components))
+	for _, name := range sortedKeys(factor.Components) {
+		parts = append(parts, fmt.Sprintf("%s=%v", name, factor.Components[name][l]))
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,211 @@
+package taguchi
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// nearlyOrthogonalStarts is the number of random balanced starting arrays
+// GenerateNearlyOrthogonal improves.
+const nearlyOrthogonalStarts = 10
+
+// NearlyOrthogonalArray is a balanced design found by GenerateNearlyOrthogonal, with
+// how far it is from orthogonal.
+// Array: The design, one column per factor in order, levels numbered from 1.
+// J2: Xu's J2 criterion: the sum over pairs of runs of the squared number of columns,
+// weighted by their level counts, in which the two runs coincide.
+// LowerBound: The smallest J2 a balanced array of this size can have; J2 reaches it
+// exactly when the array is orthogonal.
+// NonOrthogonal: The pairs of factors whose level combinations are not all equally
+// frequent.
+type NearlyOrthogonalArray struct {
+	Array         [][]int
+	J2            int
+	LowerBound    int
+	NonOrthogonal [][2]string
+}
+
+// Orthogonal reports whether the array is an orthogonal array of strength 2.
+func (a NearlyOrthogonalArray) Orthogonal() bool {
+	return a.J2 == a.LowerBound
+}
+
+// GenerateNearlyOrthogonal constructs a balanced array for a level structure no standard
+// array fits, e.g. one 6-level and three 3-level factors, keeping the factors' levels
+// as they are. Every level appears equally often in its column, and the array minimizes
+// the J2 criterion, which measures departure from orthogonality, by Xu's columnwise
+// exchange: from random balanced arrays drawn from seed, it swaps two entries of a
+// column whenever that lowers J2, until no swap helps. runs must be a multiple of every
+// factor's level count and at least 1 + Σ(levels - 1); 0 picks the smallest such run count.
+//
+// Pass the array to NewExperimentFromFactorsUsingDesign, which analyzes by regression so
+// the remaining non-orthogonality does not bias the effects; when the result is
+// Orthogonal, NewExperimentUsingArray accepts it as well.
+func GenerateNearlyOrthogonal(factors []ControlFactor, runs int, seed int64) (NearlyOrthogonalArray, error) {
+	if len(factors) == 0 {
+		return NearlyOrthogonalArray{}, fmt.Errorf("nearly-orthogonal array needs at least 1 factor")
+	}
+	params, period := 1, 1
+	for _, f := range factors {
+		if len(f.Levels) < 2 {
+			return NearlyOrthogonalArray{}, fmt.Errorf("factor %s needs at least 2 levels, got %d", f.Name, len(f.Levels))
+		}
+		params += len(f.Levels) - 1
+		period = lcm(period, len(f.Levels))
+	}
+	if runs == 0 {
+		runs = (params + period - 1) / period * period
+	}
+	if runs < params {
+		return NearlyOrthogonalArray{}, fmt.Errorf("nearly-orthogonal array for %d model parameters needs at least %d runs, got %d", params, params, runs)
+	}
+	for _, f := range factors {
+		if runs%len(f.Levels) != 0 {
+			return NearlyOrthogonalArray{}, fmt.Errorf("factor %s: %d levels cannot be balanced over %d runs", f.Name, len(f.Levels), runs)
+		}
+	}
+
+	bound := j2LowerBound(factors, runs)
+	rng := rand.New(rand.NewSource(seed))
+	var best [][]int
+	bestJ2 := -1
+	for start := 0; start < nearlyOrthogonalStarts && bestJ2 != bound; start++ {
+		design := make([][]int, runs)
+		for i := range design {
+			design[i] = make([]int, len(factors))
+		}
+		for j, f := range factors {
+			for i, k := range rng.Perm(runs) {
+				design[i][j] = k%len(f.Levels) + 1
+			}
+		}
+		if j2 := columnwiseExchange(factors, design); bestJ2 < 0 || j2 < bestJ2 {
+			best, bestJ2 = design, j2
+		}
+	}
+
+	result := NearlyOrthogonalArray{Array: best, J2: bestJ2, LowerBound: bound}
+	for a := range factors {
+		for b := a + 1; b < len(factors); b++ {
+			if !pairOrthogonal(best, a, b, len(factors[a].Levels), len(factors[b].Levels)) {
+				result.NonOrthogonal = append(result.NonOrthogonal, [2]string{factors[a].Name, factors[b].Name})
+			}
+		}
+	}
+	return result, nil
+}
+
+// columnwiseExchange lowers the J2 of design in place and returns it. Each pass makes,
+// in every column, the swap of two different entries that lowers J2 the most.
+func columnwiseExchange(factors []ControlFactor, design [][]int) int {
+	n := len(design)
+	// delta[i][j] is the weighted number of columns in which runs i and j coincide.
+	delta := make([][]int, n)
+	for i := range delta {
+		delta[i] = make([]int, n)
+	}
+	j2 := 0
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			for k, f := range factors {
+				if design[i][k] == design[j][k] {
+					delta[i][j] += len(f.Levels)
+				}
+			}
+			delta[j][i] = delta[i][j]
+			j2 += delta[i][j] * delta[i][j]
+		}
+	}
+
+	for improved := true; improved; {
+		improved = false
+		for k, f := range factors {
+			w := len(f.Levels)
+			bestChange, bestA, bestB := 0, -1, -1
+			for a := 0; a < n; a++ {
+				for b := a + 1; b < n; b++ {
+					if design[a][k] == design[b][k] {
+						continue
+					}
+					// Swapping moves run a's coincidences in column k to run b and vice versa.
+					change := 0
+					for j := 0; j < n; j++ {
+						if j == a || j == b {
+							continue
+						}
+						d := 0
+						if design[b][k] == design[j][k] {
+							d += w
+						}
+						if design[a][k] == design[j][k] {
+							d -= w
+						}
+						change += 2*d*(delta[a][j]-delta[b][j]) + 2*d*d
+					}
+					if change < bestChange {
+						bestChange, bestA, bestB = change, a, b
+					}
+				}
+			}
+			if bestA < 0 {
+				continue
+			}
+			a, b := bestA, bestB
+			for j := 0; j < n; j++ {
+				if j == a || j == b {
+					continue
+				}
+				d := 0
+				if design[b][k] == design[j][k] {
+					d += w
+				}
+				if design[a][k] == design[j][k] {
+					d -= w
+				}
+				delta[a][j] += d
+				delta[j][a] = delta[a][j]
+				delta[b][j] -= d
+				delta[j][b] = delta[b][j]
+			}
+			design[a][k], design[b][k] = design[b][k], design[a][k]
+			j2 += bestChange
+			improved = true
+		}
+	}
+	return j2
+}
+
+// j2LowerBound returns Xu's (2002) lower bound on J2 for balanced arrays with runs runs
+// and the factors' level counts as weights.
+func j2LowerBound(factors []ControlFactor, runs int) int {
+	m, sumLevels, sumDF := len(factors), 0, 0
+	for _, f := range factors {
+		sumLevels += len(f.Levels)
+		sumDF += len(f.Levels) - 1
+	}
+	return (m*m*runs*runs + sumDF*runs*runs - runs*sumLevels*sumLevels) / 2
+}
+
+// pairOrthogonal reports whether every level combination of columns a and b, with sa and
+// sb levels, appears equally often in design.
+func pairOrthogonal(design [][]int, a, b, sa, sb int) bool {
+	counts := make([]int, sa*sb)
+	for _, row := range design {
+		counts[(row[a]-1)*sb+row[b]-1]++
+	}
+	for _, c := range counts {
+		if c != counts[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// lcm returns the least common multiple of a and b.
+func lcm(a, b int) int {
+	x, y := a, b
+	for y != 0 {
+		x, y = y, x%y
+	}
+	return a / x * b
+}
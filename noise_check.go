@@ -0,0 +1,101 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// noiseDominanceRatio is the NoiseCheck.Ratio above which within-trial noise is
+// considered to dominate the differences between array rows.
+const noiseDominanceRatio = 0.5
+
+// NoiseCheck compares within-trial and between-trial variation of the raw observations.
+// WithinVariance: Pooled variance of the replicated observations within each trial result.
+// BetweenVariance: Variance of the row means.
+// Replicates: Average number of observations per row.
+// Ratio: WithinVariance/Replicates divided by BetweenVariance, i.e. the share of the spread
+// in row means that measurement noise alone would produce; NaN when it cannot be estimated.
+// NoiseDominates: Whether Ratio exceeds 0.5.
+type NoiseCheck struct {
+	WithinVariance  float64
+	BetweenVariance float64
+	Replicates      float64
+	Ratio           float64
+	NoiseDominates  bool
+}
+
+// CheckNoiseToSignal checks whether measurement noise within trials is large compared
+// to the differences between array rows. When it is, factor rankings mostly reflect
+// noise and more replication is needed. The within-trial variance is pooled over the
+// replicates of each trial result, so every noise condition is its own group and the
+// designed variation of the outer array does not count as noise; results without
+// replicated observations do not contribute to it.
+func (e *Experiment[P]) CheckNoiseToSignal() NoiseCheck {
+	// Accumulate each result into its row in a single pass; rows repeating an earlier
+	// row's configuration then share that row's sums.
+	oa := e.array()
+	sums, ss := make([]float64, oa.Rows()), make([]float64, oa.Rows())
+	counts, dfs := make([]int, oa.Rows()), make([]int, oa.Rows())
+	for k, r := range e.Results {
+		i := e.resultRow(k)
+		if i < 0 {
+			continue
+		}
+		for _, y := range r.Observations {
+			sums[i] += y
+		}
+		counts[i] += len(r.Observations)
+		if n := len(r.Observations); n > 1 {
+			ss[i] += variance(r.Observations) * float64(n-1)
+			dfs[i] += n - 1
+		}
+	}
+
+	var rowMeans []float64
+	pooledSS, pooledDF, total := 0.0, 0, 0
+	for _, first := range e.firstRows(oa) {
+		if counts[first] == 0 {
+			continue
+		}
+		rowMeans = append(rowMeans, sums[first]/float64(counts[first]))
+		pooledSS += ss[first]
+		pooledDF += dfs[first]
+		total += counts[first]
+	}
+
+	check := NoiseCheck{Ratio: math.NaN()}
+	if len(rowMeans) < 2 || pooledDF == 0 {
+		return check
+	}
+	check.WithinVariance = pooledSS / float64(pooledDF)
+	check.BetweenVariance = variance(rowMeans)
+	check.Replicates = float64(total) / float64(len(rowMeans))
+	noise := check.WithinVariance / check.Replicates
+	switch {
+	case check.BetweenVariance > 0:
+		check.Ratio = noise / check.BetweenVariance
+	case noise > 0:
+		check.Ratio = math.Inf(1)
+	default:
+		return check
+	}
+	check.NoiseDominates = check.Ratio > noiseDominanceRatio
+	return check
+}
+
+// diagnostics returns warnings about the collected data that undermine the analysis.
+func (e *Experiment[P]) diagnostics() []string {
+	var out []string
+	if n := len(e.AdHocResults); n > 0 {
+		out = append(out, fmt.Sprintf("%d off-design results were quarantined in AdHocResults and excluded from the analysis", n))
+	}
+	if _, ok := e.Goal.(WeightedGoal); !ok && slices.ContainsFunc(e.Results, func(r TrialResult) bool { return r.Weights != nil }) {
+		out = append(out, fmt.Sprintf("observation weights were ignored: the %s goal does not support them", e.Goal))
+	}
+	if check := e.CheckNoiseToSignal(); check.NoiseDominates {
+		out = append(out, fmt.Sprintf("within-trial noise dominates: it accounts for %.0f%% of the spread between array rows; "+
+			"collect more observations per trial before trusting the factor ranking", math.Min(check.Ratio, 1)*100))
+	}
+	return out
+}
@@ -0,0 +1,109 @@
+package taguchi
+
+import "math/rand"
+
+// EachNoiseCombination calls fn for every combination of noise factor levels, in the
+// same order GenerateTrials uses, without materializing the full cross product.
+// Each map passed to fn is freshly allocated and may be retained. Enumeration stops
+// early if fn returns false.
+func (e *Experiment[P]) EachNoiseCombination(fn func(noise map[string]float64) bool) {
+	idx := make([]int, len(e.NoiseFactors))
+	for _, factor := range e.NoiseFactors {
+		if len(factor.Levels) == 0 {
+			return
+		}
+	}
+
+	for {
+		noise := make(map[string]float64, len(e.NoiseFactors))
+		for i, factor := range e.NoiseFactors {
+			noise[factor.Name] = factor.Levels[idx[i]]
+		}
+		if !fn(noise) {
+			return
+		}
+
+		// Advance the odometer; the last factor varies fastest.
+		i := len(idx) - 1
+		for ; i >= 0; i-- {
+			idx[i]++
+			if idx[i] < len(e.NoiseFactors[i].Levels) {
+				break
+			}
+			idx[i] = 0
+		}
+		if i < 0 {
+			return
+		}
+	}
+}
+
+// NoiseCombinationChunks enumerates noise combinations in chunks of at most size
+// entries, so that very large cross products can be processed with bounded memory.
+// Enumeration stops early if fn returns false.
+func (e *Experiment[P]) NoiseCombinationChunks(size int, fn func(chunk []map[string]float64) bool) {
+	if size < 1 {
+		size = 1
+	}
+	chunk := make([]map[string]float64, 0, size)
+	stopped := false
+	e.EachNoiseCombination(func(noise map[string]float64) bool {
+		chunk = append(chunk, noise)
+		if len(chunk) < size {
+			return true
+		}
+		if !fn(chunk) {
+			stopped = true
+			return false
+		}
+		chunk = make([]map[string]float64, 0, size)
+		return true
+	})
+	if !stopped && len(chunk) > 0 {
+		fn(chunk)
+	}
+}
+
+// LimitNoiseCombinations caps the number of noise combinations GenerateTrials uses per
+// orthogonal array row. When the full cross product exceeds max, a stratified sample of
+// max combinations is drawn instead: every level of every noise factor appears as evenly
+// as possible, with levels paired at random using seed. A max of 0 removes the cap.
+func (e *Experiment[P]) LimitNoiseCombinations(max int, seed int64) {
+	e.noiseLimit = max
+	e.noiseSeed = seed
+}
+
+// sampleNoiseCombinations draws n stratified noise combinations. Each factor's levels are
+// repeated in turn to fill n slots and then shuffled independently, so that every level
+// of every factor is used n/len(levels) times (±1).
+func (e *Experiment[P]) sampleNoiseCombinations(n int) []map[string]float64 {
+	rng := rand.New(rand.NewSource(e.noiseSeed))
+	samples := make([]map[string]float64, n)
+	for i := range samples {
+		samples[i] = make(map[string]float64, len(e.NoiseFactors))
+	}
+	column := make([]float64, n)
+	for _, factor := range e.NoiseFactors {
+		for i := range column {
+			column[i] = factor.Levels[i%len(factor.Levels)]
+		}
+		rng.Shuffle(n, func(i, j int) { column[i], column[j] = column[j], column[i] })
+		for i, level := range column {
+			samples[i][factor.Name] = level
+		}
+	}
+	return samples
+}
+
+// NoiseFromEnum builds a noise factor whose levels are the given enum values, and
+// returns a decoder that reads the factor's value back from a trial as T.
+func NoiseFromEnum[T ~int](name string, values ...T) (NoiseFactor, func(Trial) T) {
+	levels := make([]float64, len(values))
+	for i, v := range values {
+		levels[i] = float64(v)
+	}
+	decode := func(trial Trial) T {
+		return T(trial.Noise[name])
+	}
+	return NoiseFactor{Name: name, Levels: levels}, decode
+}
@@ -0,0 +1,76 @@
+package taguchi
+
+import "sort"
+
+// Analysis methods reported in AnalysisResult.Method.
+const (
+	MethodParametric    = "Parametric (ANOVA on SNR)"
+	MethodKruskalWallis = "Nonparametric (Kruskal-Wallis on SNR ranks)"
+)
+
+// AnalyzeNonparametric is a rank-based alternative to Analyze for when the row SNRs
+// are clearly non-normal (outliers, heavy tails). Row SNRs are replaced by their ranks
+// (ties get the average rank): MainEffects hold the mean rank per level, OptimalLevels
+// pick the highest mean rank, ANOVA is computed on the ranks, and each factor gets a
+// tie-corrected Kruskal-Wallis test. SNR still holds the mean SNR per level.
+func (e *Experiment[P]) AnalyzeNonparametric() AnalysisResult {
+	oaSNR, grandMean := e.computeOASNR()
+	_, _, snrPerFactor := e.computeANOVA(oaSNR, grandMean)
+
+	ranks, tieCorrection := rankValues(oaSNR)
+	n := float64(len(ranks))
+	anova, meanRanks, _ := e.computeANOVA(ranks, (n+1)/2)
+
+	kw := make(map[string]KruskalWallisResult, len(e.ControlFactors))
+	for _, factor := range e.ControlFactors {
+		df := len(factor.Levels) - 1
+		h := 12 / (n * (n + 1)) * anova.FactorSS[factor.Name]
+		if tieCorrection > 0 {
+			h /= tieCorrection
+		}
+		kw[factor.Name] = KruskalWallisResult{H: h, DF: df, PValue: chiSquareSurvival(h, float64(df))}
+	}
+
+	result := AnalysisResult{
+		Method:        MethodKruskalWallis,
+		OptimalLevels: e.findOptimalLevels(meanRanks),
+		SNR:           snrPerFactor,
+		MainEffects:   meanRanks,
+		Contributions: computeContributions(anova),
+		ANOVA:         anova,
+		KruskalWallis: kw,
+	}
+	return e.runPasses(result, oaSNR)
+}
+
+// rankValues returns the 1-based ranks of values, averaging the ranks of ties, and the
+// Kruskal-Wallis tie correction factor 1 - Σ(t³-t)/(N³-N).
+func rankValues(values []float64) ([]float64, float64) {
+	n := len(values)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return values[idx[a]] < values[idx[b]] })
+
+	ranks := make([]float64, n)
+	ties := 0.0
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && values[idx[j]] == values[idx[i]] {
+			j++
+		}
+		avg := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			ranks[idx[k]] = avg
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	nf := float64(n)
+	if n < 2 {
+		return ranks, 1
+	}
+	return ranks, 1 - ties/(nf*nf*nf-nf)
+}
@@ -0,0 +1,60 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+)
+
+// OperatingWindow is the goal of operating-window experiments, where a process fails
+// both below a lower threshold x (under-function, e.g. a sheet that is not fed) and
+// above an upper threshold z (over-function, e.g. two sheets fed at once): the window
+// between them should be as wide as possible. Each replicate records the pair of
+// thresholds, lower first, so a trial's observations are x1, z1, x2, z2, ...;
+// AddOperatingWindowResult records them in that layout.
+type OperatingWindow struct{}
+
+// CalculateSNR computes the operating-window SNR, the sum of the smaller-the-better SNR
+// of the lower thresholds and the larger-the-better SNR of the upper ones.
+// Formula: -10 * log10(mean(x_i^2) * mean(1/z_i^2))
+// It is NaN for an odd number of observations, which cannot be paired.
+func (o OperatingWindow) CalculateSNR(obs []float64) float64 {
+	if len(obs)%2 != 0 {
+		return math.NaN()
+	}
+	if len(obs) == 0 {
+		return 0
+	}
+	lower := make([]float64, 0, len(obs)/2)
+	upper := make([]float64, 0, len(obs)/2)
+	for i := 0; i < len(obs); i += 2 {
+		lower = append(lower, obs[i])
+		upper = append(upper, obs[i+1])
+	}
+	return SmallerTheBetter{}.CalculateSNR(lower) + LargerTheBetter{}.CalculateSNR(upper)
+}
+
+// String returns the human-readable name for the OperatingWindow goal.
+func (o OperatingWindow) String() string {
+	return "Operating Window"
+}
+
+// AddOperatingWindowResult records a trial of an operating-window experiment: the lower
+// and upper thresholds observed in each replicate. The experiment's goal must be
+// OperatingWindow.
+func (e *Experiment[P]) AddOperatingWindowResult(trial Trial, lower, upper []float64) error {
+	if _, ok := e.Goal.(OperatingWindow); !ok {
+		return fmt.Errorf("optimization goal %s is not an operating window", e.Goal)
+	}
+	if len(lower) != len(upper) || len(lower) == 0 {
+		return fmt.Errorf("trial %d has %d lower and %d upper thresholds; want the same positive number", trial.ID, len(lower), len(upper))
+	}
+	observations := make([]float64, 0, 2*len(lower))
+	for i := range lower {
+		if lower[i] > upper[i] {
+			return fmt.Errorf("trial %d replicate %d: lower threshold %v exceeds upper threshold %v", trial.ID, i+1, lower[i], upper[i])
+		}
+		observations = append(observations, lower[i], upper[i])
+	}
+	e.AddResult(trial, observations)
+	return nil
+}
@@ -6,8 +6,19 @@ const (
 	L4  ArrayType = "L4"
 	L8  ArrayType = "L8"
 	L9  ArrayType = "L9"
+	L12 ArrayType = "L12"
 	L16 ArrayType = "L16"
-	L18 ArrayType = "L18"
+	// L16_4 is the modified L16 (L16') with five four-level columns.
+	L16_4 ArrayType = "L16_4"
+	L18   ArrayType = "L18"
+	L25   ArrayType = "L25"
+	L27   ArrayType = "L27"
+	L32   ArrayType = "L32"
+	L36   ArrayType = "L36"
+	L50   ArrayType = "L50"
+	L54   ArrayType = "L54"
+	L64   ArrayType = "L64"
+	L81   ArrayType = "L81"
 )
 
 var StandardArrays = map[ArrayType][][]int{
@@ -38,23 +49,55 @@ var StandardArrays = map[ArrayType][][]int{
 		{3, 2, 1, 3},
 		{3, 3, 2, 1},
 	},
+	L12: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 1},
+		{1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2},
+		{2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 1},
+		{1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 1},
+		{1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 1},
+		{1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 2},
+		{2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 2},
+		{2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 2},
+		{2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 1},
+		{1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 2},
+		{2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 2},
+	},
 	L16: {
 		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
-		{1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
-		{1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2},
-		{1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1},
-		{1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2},
-		{1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1},
-		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1},
-		{1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2},
-		{2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1},
-		{2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2},
-		{2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 1},
-		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2},
-		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1},
-		{2, 2, 1, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2},
-		{2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 1, 2, 2, 1, 2},
-		{2, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 1, 2, 1},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1},
+	},
+	L16_4: {
+		{1, 1, 1, 1, 1},
+		{1, 2, 2, 2, 2},
+		{1, 3, 3, 3, 3},
+		{1, 4, 4, 4, 4},
+		{2, 1, 2, 3, 4},
+		{2, 2, 1, 4, 3},
+		{2, 3, 4, 1, 2},
+		{2, 4, 3, 2, 1},
+		{3, 1, 3, 4, 2},
+		{3, 2, 4, 3, 1},
+		{3, 3, 1, 2, 4},
+		{3, 4, 2, 1, 3},
+		{4, 1, 4, 2, 3},
+		{4, 2, 3, 1, 4},
+		{4, 3, 2, 4, 1},
+		{4, 4, 1, 3, 2},
 	},
 	L18: {
 		{1, 1, 1, 1, 1, 1, 1, 1},
@@ -76,4 +119,389 @@ var StandardArrays = map[ArrayType][][]int{
 		{2, 3, 2, 1, 3, 1, 2, 3},
 		{2, 3, 3, 2, 1, 2, 3, 1},
 	},
+	L25: {
+		{1, 1, 1, 1, 1, 1},
+		{1, 2, 2, 3, 4, 5},
+		{1, 3, 3, 5, 2, 4},
+		{1, 4, 4, 2, 5, 3},
+		{1, 5, 5, 4, 3, 2},
+		{2, 1, 2, 2, 2, 2},
+		{2, 2, 3, 4, 5, 1},
+		{2, 3, 4, 1, 3, 5},
+		{2, 4, 5, 3, 1, 4},
+		{2, 5, 1, 5, 4, 3},
+		{3, 1, 3, 3, 3, 3},
+		{3, 2, 4, 5, 1, 2},
+		{3, 3, 5, 2, 4, 1},
+		{3, 4, 1, 4, 2, 5},
+		{3, 5, 2, 1, 5, 4},
+		{4, 1, 4, 4, 4, 4},
+		{4, 2, 5, 1, 2, 3},
+		{4, 3, 1, 3, 5, 2},
+		{4, 4, 2, 5, 3, 1},
+		{4, 5, 3, 2, 1, 5},
+		{5, 1, 5, 5, 5, 5},
+		{5, 2, 1, 2, 3, 4},
+		{5, 3, 2, 4, 1, 3},
+		{5, 4, 3, 1, 4, 2},
+		{5, 5, 4, 3, 2, 1},
+	},
+	L27: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3},
+		{1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1},
+		{1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2},
+		{1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2},
+		{1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3},
+		{1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1},
+		{2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+		{2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1},
+		{2, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2},
+		{2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2},
+		{2, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3},
+		{2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1},
+		{2, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1},
+		{2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2},
+		{2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3},
+		{3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2},
+		{3, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3},
+		{3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1},
+		{3, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1},
+		{3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2},
+		{3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3},
+		{3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3},
+		{3, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1},
+		{3, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2},
+	},
+	L32: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2},
+	},
+	L36: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 1, 3, 2, 2, 3, 1, 2, 1, 2, 3, 3, 1},
+		{1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 3, 1, 3, 2, 2, 1, 2, 1, 3, 3, 2},
+		{2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 1, 2, 3, 2, 1, 2, 2, 3, 1, 1, 3, 3},
+		{1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 1, 1, 2, 1, 3, 3, 2, 2, 3, 2, 3},
+		{1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 3, 3, 2, 3, 3, 1, 2, 2, 1},
+		{1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 3, 3, 1, 2, 1, 3, 3, 2, 1, 2, 2},
+		{2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 1, 1, 3, 1, 2, 3, 2, 2, 3, 2, 3, 1},
+		{2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 1, 3, 2, 2, 1, 3, 1, 3, 3, 2, 1, 2},
+		{2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 1, 2, 3, 3, 3, 1, 1, 2, 2, 2, 1, 3},
+		{1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 1, 3, 3, 3, 2, 1, 3, 1, 2, 2},
+		{2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 1, 2, 2, 1, 2, 2, 3, 1, 3, 3, 1, 3},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 1, 3, 3, 1, 2, 3, 2, 3, 1, 1, 2},
+		{1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 2, 1, 2, 1, 3, 3, 2, 3, 2, 1, 1, 3},
+		{2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 3, 1, 3, 2, 3, 3, 1, 2, 2, 1, 1},
+		{1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 2, 2, 2, 3, 2, 1, 1, 3, 3, 1, 3, 1},
+		{1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 2, 2, 3, 1, 1, 3, 1, 1, 2, 3, 3, 2},
+		{1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 2, 1, 1, 2, 3, 2, 1, 1, 3, 2, 3, 3},
+		{2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 2, 1, 2, 3, 1, 3, 3, 1, 3, 1, 2},
+		{2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 1, 3, 3, 2, 1, 2, 1, 1, 3, 2, 3},
+		{2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 3, 1, 1, 1, 2, 2, 3, 3, 3, 2, 1},
+		{1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 2, 3, 2, 1, 1, 1, 3, 2, 1, 2, 3, 3},
+		{2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 3, 3, 2, 3, 3, 1, 2, 1, 1, 2, 1},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 3, 2, 1, 1, 2, 3, 1, 3, 1, 2, 2, 3},
+		{1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 2, 3, 2, 3, 2, 1, 1, 3, 1, 3, 2, 2, 1},
+		{2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 1, 3, 1, 2, 1, 3, 1, 1, 2, 3, 3, 2, 2},
+		{1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 1, 3, 3, 3, 1, 3, 2, 2, 1, 1, 2, 1, 2},
+		{1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 1, 3, 3, 1, 2, 2, 1, 2, 2, 3, 1, 1, 3},
+		{1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 2, 3, 2, 2, 3, 1, 3, 2, 2, 1, 3, 1, 1},
+		{2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 2, 3, 3, 2, 3, 1, 2, 1, 1, 2, 1, 2, 3},
+		{2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 2, 3, 2, 1, 1, 3, 2, 3, 2, 2, 1, 3, 1},
+		{2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 1, 3, 1, 2, 2, 2, 3, 3, 1, 1, 1, 3, 2},
+		{1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 2, 3, 1, 3, 2, 2, 2, 1, 3, 2, 3, 1, 1},
+		{2, 1, 2, 2, 2, 1, 1, 1, 2, 1, 2, 3, 1, 1, 3, 1, 1, 2, 3, 2, 2, 3, 2},
+	},
+	L50: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{1, 1, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4},
+		{1, 1, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5},
+		{1, 2, 1, 1, 2, 3, 5, 5, 4, 3, 2, 4},
+		{1, 2, 2, 2, 3, 4, 1, 1, 5, 4, 3, 5},
+		{1, 2, 3, 3, 4, 5, 2, 2, 1, 5, 4, 1},
+		{1, 2, 4, 4, 5, 1, 3, 3, 2, 1, 5, 2},
+		{1, 2, 5, 5, 1, 2, 4, 4, 3, 2, 1, 3},
+		{1, 3, 1, 2, 5, 5, 3, 1, 4, 2, 4, 3},
+		{1, 3, 2, 3, 1, 1, 4, 2, 5, 3, 5, 4},
+		{1, 3, 3, 4, 2, 2, 5, 3, 1, 4, 1, 5},
+		{1, 3, 4, 5, 3, 3, 1, 4, 2, 5, 2, 1},
+		{1, 3, 5, 1, 4, 4, 2, 5, 3, 1, 3, 2},
+		{1, 4, 1, 5, 4, 3, 3, 2, 5, 4, 1, 2},
+		{1, 4, 2, 1, 5, 4, 4, 3, 1, 5, 2, 3},
+		{1, 4, 3, 2, 1, 5, 5, 4, 2, 1, 3, 4},
+		{1, 4, 4, 3, 2, 1, 1, 5, 3, 2, 4, 5},
+		{1, 4, 5, 4, 3, 2, 2, 1, 4, 3, 5, 1},
+		{1, 5, 1, 5, 1, 4, 2, 3, 2, 3, 4, 5},
+		{1, 5, 2, 1, 2, 5, 3, 4, 3, 4, 5, 1},
+		{1, 5, 3, 2, 3, 1, 4, 5, 4, 5, 1, 2},
+		{1, 5, 4, 3, 4, 2, 5, 1, 5, 1, 2, 3},
+		{1, 5, 5, 4, 5, 3, 1, 2, 1, 2, 3, 4},
+		{2, 1, 1, 3, 5, 2, 4, 5, 2, 4, 3, 1},
+		{2, 1, 2, 4, 1, 3, 5, 1, 3, 5, 4, 2},
+		{2, 1, 3, 5, 2, 4, 1, 2, 4, 1, 5, 3},
+		{2, 1, 4, 1, 3, 5, 2, 3, 5, 2, 1, 4},
+		{2, 1, 5, 2, 4, 1, 3, 4, 1, 3, 2, 5},
+		{2, 2, 1, 4, 2, 1, 2, 4, 5, 5, 3, 3},
+		{2, 2, 2, 5, 3, 2, 3, 5, 1, 1, 4, 4},
+		{2, 2, 3, 1, 4, 3, 4, 1, 2, 2, 5, 5},
+		{2, 2, 4, 2, 5, 4, 5, 2, 3, 3, 1, 1},
+		{2, 2, 5, 3, 1, 5, 1, 3, 4, 4, 2, 2},
+		{2, 3, 1, 2, 4, 2, 1, 3, 3, 5, 5, 4},
+		{2, 3, 2, 3, 5, 3, 2, 4, 4, 1, 1, 5},
+		{2, 3, 3, 4, 1, 4, 3, 5, 5, 2, 2, 1},
+		{2, 3, 4, 5, 2, 5, 4, 1, 1, 3, 3, 2},
+		{2, 3, 5, 1, 3, 1, 5, 2, 2, 4, 4, 3},
+		{2, 4, 1, 4, 3, 5, 4, 2, 3, 1, 2, 5},
+		{2, 4, 2, 5, 4, 1, 5, 3, 4, 2, 3, 1},
+		{2, 4, 3, 1, 5, 2, 1, 4, 5, 3, 4, 2},
+		{2, 4, 4, 2, 1, 3, 2, 5, 1, 4, 5, 3},
+		{2, 4, 5, 3, 2, 4, 3, 1, 2, 5, 1, 4},
+		{2, 5, 1, 3, 3, 4, 5, 4, 1, 2, 5, 2},
+		{2, 5, 2, 4, 4, 5, 1, 5, 2, 3, 1, 3},
+		{2, 5, 3, 5, 5, 1, 2, 1, 3, 4, 2, 4},
+		{2, 5, 4, 1, 1, 2, 3, 2, 4, 5, 3, 5},
+		{2, 5, 5, 2, 2, 3, 4, 3, 5, 1, 4, 1},
+	},
+	L54: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{1, 1, 2, 2, 2, 2, 2, 2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+		{1, 1, 2, 2, 2, 2, 2, 2, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1},
+		{1, 1, 2, 2, 2, 2, 2, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2},
+		{1, 1, 3, 3, 3, 3, 3, 3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2},
+		{1, 1, 3, 3, 3, 3, 3, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3},
+		{1, 1, 3, 3, 3, 3, 3, 3, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1},
+		{1, 2, 1, 1, 2, 2, 3, 3, 1, 1, 1, 3, 3, 3, 1, 1, 1, 2, 2, 2, 2, 2, 2, 3, 3, 3},
+		{1, 2, 1, 1, 2, 2, 3, 3, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 3, 3, 3, 1, 1, 1},
+		{1, 2, 1, 1, 2, 2, 3, 3, 3, 3, 3, 2, 2, 2, 3, 3, 3, 1, 1, 1, 1, 1, 1, 2, 2, 2},
+		{1, 2, 2, 2, 3, 3, 1, 1, 1, 2, 3, 3, 1, 2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 3, 1, 2},
+		{1, 2, 2, 2, 3, 3, 1, 1, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2, 3, 1, 2, 1, 2, 3},
+		{1, 2, 2, 2, 3, 3, 1, 1, 3, 1, 2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 1, 2, 3, 2, 3, 1},
+		{1, 2, 3, 3, 1, 1, 2, 2, 1, 3, 2, 3, 2, 1, 1, 3, 2, 2, 1, 3, 2, 1, 3, 3, 2, 1},
+		{1, 2, 3, 3, 1, 1, 2, 2, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1, 3, 2, 1, 1, 3, 2},
+		{1, 2, 3, 3, 1, 1, 2, 2, 3, 2, 1, 2, 1, 3, 3, 2, 1, 1, 3, 2, 1, 3, 2, 2, 1, 3},
+		{1, 3, 1, 2, 1, 3, 2, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2},
+		{1, 3, 1, 2, 1, 3, 2, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3},
+		{1, 3, 1, 2, 1, 3, 2, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1},
+		{1, 3, 2, 3, 2, 1, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1},
+		{1, 3, 2, 3, 2, 1, 3, 1, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2},
+		{1, 3, 2, 3, 2, 1, 3, 1, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3},
+		{1, 3, 3, 1, 3, 2, 1, 2, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3},
+		{1, 3, 3, 1, 3, 2, 1, 2, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1},
+		{1, 3, 3, 1, 3, 2, 1, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2},
+		{2, 1, 1, 3, 3, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 3, 3, 3},
+		{2, 1, 1, 3, 3, 2, 2, 1, 2, 2, 2, 3, 3, 3, 3, 3, 3, 1, 1, 1, 2, 2, 2, 1, 1, 1},
+		{2, 1, 1, 3, 3, 2, 2, 1, 3, 3, 3, 1, 1, 1, 1, 1, 1, 2, 2, 2, 3, 3, 3, 2, 2, 2},
+		{2, 1, 2, 1, 1, 3, 3, 2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3, 3, 1, 2},
+		{2, 1, 2, 1, 1, 3, 3, 2, 2, 3, 1, 3, 1, 2, 3, 1, 2, 1, 2, 3, 2, 3, 1, 1, 2, 3},
+		{2, 1, 2, 1, 1, 3, 3, 2, 3, 1, 2, 1, 2, 3, 1, 2, 3, 2, 3, 1, 3, 1, 2, 2, 3, 1},
+		{2, 1, 3, 2, 2, 1, 1, 3, 1, 3, 2, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 3, 2, 1},
+		{2, 1, 3, 2, 2, 1, 1, 3, 2, 1, 3, 3, 2, 1, 3, 2, 1, 1, 3, 2, 2, 1, 3, 1, 3, 2},
+		{2, 1, 3, 2, 2, 1, 1, 3, 3, 2, 1, 1, 3, 2, 1, 3, 2, 2, 1, 3, 3, 2, 1, 2, 1, 3},
+		{2, 2, 1, 2, 3, 1, 3, 2, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 2, 2, 2},
+		{2, 2, 1, 2, 3, 1, 3, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3},
+		{2, 2, 1, 2, 3, 1, 3, 2, 3, 3, 3, 3, 3, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1},
+		{2, 2, 2, 3, 1, 2, 1, 3, 1, 2, 3, 1, 2, 3, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3, 1},
+		{2, 2, 2, 3, 1, 2, 1, 3, 2, 3, 1, 2, 3, 1, 1, 2, 3, 1, 2, 3, 3, 1, 2, 3, 1, 2},
+		{2, 2, 2, 3, 1, 2, 1, 3, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3, 1, 1, 2, 3, 1, 2, 3},
+		{2, 2, 3, 1, 2, 3, 2, 1, 1, 3, 2, 1, 3, 2, 3, 2, 1, 3, 2, 1, 2, 1, 3, 2, 1, 3},
+		{2, 2, 3, 1, 2, 3, 2, 1, 2, 1, 3, 2, 1, 3, 1, 3, 2, 1, 3, 2, 3, 2, 1, 3, 2, 1},
+		{2, 2, 3, 1, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1, 2, 1, 3, 2, 1, 3, 1, 3, 2, 1, 3, 2},
+		{2, 3, 1, 3, 2, 3, 1, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 2, 2, 2, 3, 3, 3, 1, 1, 1},
+		{2, 3, 1, 3, 2, 3, 1, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 3, 3, 3, 1, 1, 1, 2, 2, 2},
+		{2, 3, 1, 3, 2, 3, 1, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3},
+		{2, 3, 2, 1, 3, 1, 2, 3, 1, 2, 3, 2, 3, 1, 3, 1, 2, 2, 3, 1, 3, 1, 2, 1, 2, 3},
+		{2, 3, 2, 1, 3, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 3, 1, 2, 1, 2, 3, 2, 3, 1},
+		{2, 3, 2, 1, 3, 1, 2, 3, 3, 1, 2, 1, 2, 3, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2},
+		{2, 3, 3, 2, 1, 2, 3, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 2, 1, 3, 3, 2, 1, 1, 3, 2},
+		{2, 3, 3, 2, 1, 2, 3, 1, 2, 1, 3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 1, 3, 2, 2, 1, 3},
+		{2, 3, 3, 2, 1, 2, 3, 1, 3, 2, 1, 1, 3, 2, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1},
+	},
+	L64: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2},
+		{1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2},
+		{1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2},
+		{1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1},
+		{1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2, 1, 1, 2, 2, 2, 2, 1, 1, 1, 1, 2, 2, 2, 2, 1, 1, 2, 2, 1, 1, 1, 1, 2, 2},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2},
+		{2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1},
+		{2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 2, 1, 2, 1, 1, 2, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1},
+		{2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1},
+		{2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2},
+		{2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1, 1, 2, 2, 1, 2, 1, 1, 2, 1, 2, 2, 1, 2, 1, 1, 2, 2, 1, 1, 2, 1, 2, 2, 1},
+	},
+	L81: {
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+		{1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3},
+		{1, 1, 1, 1, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1, 1, 1},
+		{1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3},
+		{1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1},
+		{1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2},
+		{1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 1, 1, 1, 2, 2, 2, 3, 3, 3, 2, 2, 2, 3, 3, 3, 1, 1, 1, 3, 3, 3, 1, 1, 1, 2, 2, 2},
+		{1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 3, 3, 3, 1, 1, 1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3},
+		{1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 3, 3, 3, 1, 1, 1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 2, 2, 2, 3, 3, 3, 1, 1, 1},
+		{1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 1, 1, 1, 2, 2, 2, 3, 3, 3, 3, 3, 3, 1, 1, 1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1},
+		{1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 1, 1, 1, 2, 2, 2, 3, 3, 3, 3, 3, 3, 1, 1, 1, 2, 2, 2},
+		{1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 3, 3, 3, 1, 1, 1, 2, 2, 2, 2, 2, 2, 3, 3, 3, 1, 1, 1, 1, 1, 1, 2, 2, 2, 3, 3, 3},
+		{1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2},
+		{1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3},
+		{1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1},
+		{1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1},
+		{1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1, 1, 1, 1, 3, 3, 3, 2, 2, 2},
+		{1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1, 1, 1, 1, 3, 3, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 3, 3, 3},
+		{1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1, 1, 1, 1, 3, 3, 3, 2, 2, 2, 3, 3, 3, 2, 2, 2, 1, 1, 1, 2, 2, 2, 1, 1, 1, 3, 3, 3},
+		{1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1, 2, 2, 2, 1, 1, 1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2, 3, 3, 3, 2, 2, 2, 1, 1, 1},
+		{1, 3, 3, 3, 3, 3, 3, 2, 2, 2, 1, 1, 1, 3, 3, 3, 2, 2, 2, 1, 1, 1, 2, 2, 2, 1, 1, 1, 3, 3, 3, 1, 1, 1, 3, 3, 3, 2, 2, 2},
+		{2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+		{2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1},
+		{2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2},
+		{2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1, 1, 2, 3, 1, 2, 3, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1, 3, 1, 2, 3, 1, 2, 3, 1, 2},
+		{2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 3, 1, 2, 3, 1, 2, 3, 1, 2, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+		{2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1, 3, 1, 2, 3, 1, 2, 3, 1, 2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 2, 3, 1, 2, 3, 1, 2, 3, 1},
+		{2, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2, 1, 2, 3, 1, 2, 3, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3, 1, 2, 3, 1},
+		{2, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3, 1, 2, 3, 1, 1, 2, 3, 1, 2, 3, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2},
+		{2, 1, 2, 3, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 2, 3, 1, 2, 3, 1, 1, 2, 3, 1, 2, 3, 1, 2, 3},
+		{2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2},
+		{2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3},
+		{2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1},
+		{2, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3, 1, 2, 3, 2, 3, 1, 3, 1, 2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 3, 1, 2, 1, 2, 3, 2, 3, 1},
+		{2, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 3, 1, 2, 1, 2, 3, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2},
+		{2, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3, 3, 1, 2, 1, 2, 3, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2, 2, 3, 1, 3, 1, 2, 1, 2, 3},
+		{2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 1, 2, 3, 2, 3, 1, 3, 1, 2, 3, 1, 2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3},
+		{2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3, 1, 2, 3, 2, 3, 1, 3, 1, 2, 3, 1, 2, 1, 2, 3, 2, 3, 1},
+		{2, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 3, 1, 2, 1, 2, 3, 2, 3, 1, 2, 3, 1, 3, 1, 2, 1, 2, 3, 1, 2, 3, 2, 3, 1, 3, 1, 2},
+		{2, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1},
+		{2, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2},
+		{2, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3},
+		{2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1, 2, 3, 1, 1, 2, 3, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3},
+		{2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 1, 2, 3, 3, 1, 2, 2, 3, 1},
+		{2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 1, 2, 3, 3, 1, 2, 2, 3, 1, 2, 3, 1, 1, 2, 3, 3, 1, 2},
+		{2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 1, 2, 3, 3, 1, 2, 2, 3, 1, 3, 1, 2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 1, 2, 3, 3, 1, 2},
+		{2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 1, 2, 3, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1, 3, 1, 2, 2, 3, 1, 1, 2, 3},
+		{2, 3, 1, 2, 3, 1, 2, 2, 3, 1, 1, 2, 3, 3, 1, 2, 2, 3, 1, 1, 2, 3, 2, 3, 1, 1, 2, 3, 3, 1, 2, 1, 2, 3, 3, 1, 2, 2, 3, 1},
+		{3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2},
+		{3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3},
+		{3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1},
+		{3, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 3, 2, 1, 3, 2, 1},
+		{3, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 3, 2, 1, 3, 2, 1, 1, 3, 2, 1, 3, 2, 1, 3, 2},
+		{3, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 3, 2, 1, 3, 2, 1, 1, 3, 2, 1, 3, 2, 1, 3, 2, 2, 1, 3, 2, 1, 3, 2, 1, 3},
+		{3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1, 1, 3, 2, 1, 3, 2, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1, 2, 1, 3, 2, 1, 3, 2, 1, 3},
+		{3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1, 2, 1, 3, 2, 1, 3, 2, 1, 3, 1, 3, 2, 1, 3, 2, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1},
+		{3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 3, 2, 1, 2, 1, 3, 2, 1, 3, 2, 1, 3, 1, 3, 2, 1, 3, 2, 1, 3, 2},
+		{3, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1},
+		{3, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2},
+		{3, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3},
+		{3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 1, 3, 2, 2, 1, 3, 3, 2, 1, 2, 1, 3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 1, 3, 2, 2, 1, 3},
+		{3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 1, 3, 2, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1},
+		{3, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 1, 3, 2, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1, 2, 1, 3, 3, 2, 1, 1, 3, 2},
+		{3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 1, 3, 2, 2, 1, 3, 3, 2, 1, 3, 2, 1, 1, 3, 2, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2},
+		{3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 1, 3, 2, 2, 1, 3, 3, 2, 1, 3, 2, 1, 1, 3, 2, 2, 1, 3},
+		{3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 3, 2, 1, 1, 3, 2, 2, 1, 3, 2, 1, 3, 3, 2, 1, 1, 3, 2, 1, 3, 2, 2, 1, 3, 3, 2, 1},
+		{3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3},
+		{3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1},
+		{3, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2},
+		{3, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3, 2, 1, 3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2},
+		{3, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2, 1, 3, 2, 3, 2, 1, 2, 1, 3},
+		{3, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2, 1, 3, 2, 3, 2, 1, 2, 1, 3, 2, 1, 3, 1, 3, 2, 3, 2, 1},
+		{3, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2, 1, 3, 2, 3, 2, 1, 2, 1, 3, 3, 2, 1, 2, 1, 3, 1, 3, 2, 2, 1, 3, 1, 3, 2, 3, 2, 1},
+		{3, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2, 2, 1, 3, 1, 3, 2, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3, 3, 2, 1, 2, 1, 3, 1, 3, 2},
+		{3, 3, 2, 1, 3, 2, 1, 2, 1, 3, 1, 3, 2, 3, 2, 1, 2, 1, 3, 1, 3, 2, 2, 1, 3, 1, 3, 2, 3, 2, 1, 1, 3, 2, 3, 2, 1, 2, 1, 3},
+	},
 }
@@ -0,0 +1,672 @@
+package taguchi
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestStandardArrays_Balanced verifies that every standard array is rectangular and
+// orthogonal.
+func TestStandardArrays_Balanced(t *testing.T) {
+	for name, oa := range StandardArrays {
+		checkBalanced(t, string(name), oa)
+	}
+}
+
+// checkBalanced verifies that oa is rectangular and that every pair of columns
+// contains each combination of their levels equally often.
+func checkBalanced(t *testing.T, name string, oa [][]int) {
+	t.Helper()
+	cols := len(oa[0])
+	levels := make([]int, cols)
+	for i, row := range oa {
+		if len(row) != cols {
+			t.Fatalf("%s: row %d has %d columns, want %d", name, i+1, len(row), cols)
+		}
+		for j, v := range row {
+			levels[j] = max(levels[j], v)
+		}
+	}
+
+	for a := 0; a < cols; a++ {
+		for b := a + 1; b < cols; b++ {
+			counts := map[[2]int]int{}
+			for _, row := range oa {
+				counts[[2]int{row[a], row[b]}]++
+			}
+			want := len(oa) / (levels[a] * levels[b])
+			for x := 1; x <= levels[a]; x++ {
+				for y := 1; y <= levels[b]; y++ {
+					if got := counts[[2]int{x, y}]; got != want {
+						t.Errorf("%s: columns %d,%d level pair (%d,%d) appears %d times, want %d", name, a+1, b+1, x, y, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestStandardArrays_Dimensions pins the run and column counts of each standard array.
+func TestStandardArrays_Dimensions(t *testing.T) {
+	want := map[ArrayType][2]int{
+		L4:    {4, 3},
+		L8:    {8, 7},
+		L9:    {9, 4},
+		L12:   {12, 11},
+		L16:   {16, 15},
+		L16_4: {16, 5},
+		L18:   {18, 8},
+		L25:   {25, 6},
+		L27:   {27, 13},
+		L32:   {32, 31},
+		L36:   {36, 23},
+		L50:   {50, 12},
+		L54:   {54, 26},
+		L64:   {64, 63},
+		L81:   {81, 40},
+	}
+	for name, dims := range want {
+		oa, ok := StandardArrays[name]
+		if !ok {
+			t.Errorf("%s: not in StandardArrays", name)
+			continue
+		}
+		if len(oa) != dims[0] || len(oa[0]) != dims[1] {
+			t.Errorf("%s: got %dx%d, want %dx%d", name, len(oa), len(oa[0]), dims[0], dims[1])
+		}
+	}
+}
+
+func TestNewExperiment_L32Capacity(t *testing.T) {
+	factors := make([]ControlFactor, 32)
+	for i := range factors {
+		factors[i] = ControlFactor{Name: fmt.Sprintf("F%d", i+1), Levels: []float64{1, 2}}
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors[:31], L32, nil)
+	if err != nil {
+		t.Fatalf("31 factors on L32: %v", err)
+	}
+	if got := len(exp.GenerateTrials()); got != 32 {
+		t.Errorf("trials: got %d, want 32", got)
+	}
+	if _, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L32, nil); err == nil {
+		t.Error("expected an error for 32 factors on L32")
+	}
+}
+
+func TestNewExperiment_ColumnLevels(t *testing.T) {
+	levels := ColumnLevels(StandardArrays[L36])
+	for j, want := range levels {
+		if (j < 11 && want != 2) || (j >= 11 && want != 3) {
+			t.Fatalf("L36 column %d: got %d levels", j+1, want)
+		}
+	}
+	for j, want := range ColumnLevels(StandardArrays[L54]) {
+		if (j == 0 && want != 2) || (j > 0 && want != 3) {
+			t.Fatalf("L54 column %d: got %d levels", j+1, want)
+		}
+	}
+
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2, 3}},
+	}
+	if _, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L36, nil); err == nil {
+		t.Error("expected an error for a three-level factor on a two-level column")
+	}
+	if _, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L18, nil); err != nil {
+		t.Errorf("two- and three-level factors on L18: %v", err)
+	}
+	swapped := []ControlFactor{factors[1], factors[0]}
+	if _, err := NewExperimentFromFactors(SmallerTheBetter{}, swapped, L18, nil); err == nil {
+		t.Error("expected an error for a three-level factor on a two-level column of L18")
+	}
+	if _, err := NewExperimentFromFactors(SmallerTheBetter{}, factors[:1], L36, nil); err != nil {
+		t.Errorf("two-level factor on L36: %v", err)
+	}
+}
+
+func TestArraySpec_CustomArray(t *testing.T) {
+	oa := [][]int{{1, 1}, {1, 2}, {2, 3}, {2, 1}, {1, 3}, {2, 2}}
+	spec, err := NewArraySpec(oa)
+	if err != nil {
+		t.Fatalf("NewArraySpec: %v", err)
+	}
+	if spec.Levels[0] != 2 || spec.Levels[1] != 3 {
+		t.Errorf("Levels: got %v, want [2 3]", spec.Levels)
+	}
+	if _, err := NewArraySpec([][]int{{1, 1}, {2}}); err == nil {
+		t.Error("expected an error for a ragged array")
+	}
+
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2, 3}},
+		{Name: "B", Levels: []float64{1, 2, 3}},
+	}
+	if _, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, oa, nil); err == nil {
+		t.Error("expected an error for a three-level factor on a two-level custom column")
+	}
+	if _, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors[1:], [][]int{{1}, {2}, {3}}, nil); err != nil {
+		t.Errorf("matching custom array: %v", err)
+	}
+}
+
+func TestSelectArray(t *testing.T) {
+	factorsWithLevels := func(counts ...int) []ControlFactor {
+		factors := make([]ControlFactor, len(counts))
+		for i, n := range counts {
+			factors[i] = ControlFactor{Name: fmt.Sprintf("F%d", i+1), Levels: make([]float64, n)}
+			for l := range factors[i].Levels {
+				factors[i].Levels[l] = float64(l + 1)
+			}
+		}
+		return factors
+	}
+	tests := []struct {
+		counts []int
+		want   ArrayType
+	}{
+		{[]int{2, 2, 2}, L4},
+		{[]int{2, 2, 2, 2}, L8},
+		{[]int{2, 2, 2, 2, 2, 2, 2, 2}, L12},
+		{[]int{3, 3, 3, 3}, L9},
+		{[]int{2, 3, 3}, L18},
+		{[]int{3, 3, 3, 3, 3}, L27},
+		{[]int{4, 4}, L16_4},
+		{[]int{5, 5, 5}, L25},
+	}
+	for _, tt := range tests {
+		got, err := SelectArray(factorsWithLevels(tt.counts...))
+		if err != nil || got != tt.want {
+			t.Errorf("SelectArray(%v): got %s, %v; want %s", tt.counts, got, err, tt.want)
+		}
+	}
+	if _, err := SelectArray(factorsWithLevels(7)); err == nil {
+		t.Error("expected an error for a seven-level factor")
+	}
+
+	type factors struct{ A, B, C []float64 }
+	exp, err := NewExperimentAuto[factors, struct{}](SmallerTheBetter{}, factors{A: []float64{1, 2}, B: []float64{1, 2}, C: []float64{1, 2}}, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentAuto: %v", err)
+	}
+	if len(exp.OrthogonalArray) != 4 {
+		t.Errorf("NewExperimentAuto: got %d rows, want L4", len(exp.OrthogonalArray))
+	}
+}
+
+func TestGenerateTwoLevelArray(t *testing.T) {
+	for n := 4; n < 100; n += 4 {
+		oa, err := GenerateTwoLevelArray(n)
+		if n == 52 || n == 92 {
+			if err == nil {
+				t.Errorf("GenerateTwoLevelArray(%d): expected an error", n)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("GenerateTwoLevelArray(%d): %v", n, err)
+		}
+		if len(oa) != n || len(oa[0]) != n-1 {
+			t.Fatalf("GenerateTwoLevelArray(%d): got %dx%d, want %dx%d", n, len(oa), len(oa[0]), n, n-1)
+		}
+		checkBalanced(t, fmt.Sprintf("generated L%d", n), oa)
+	}
+	if _, err := GenerateTwoLevelArray(10); err == nil {
+		t.Error("expected an error for 10 runs")
+	}
+}
+
+func TestPlackettBurman(t *testing.T) {
+	for _, n := range []int{12, 20, 24, 28} {
+		oa, err := PlackettBurman(n)
+		if err != nil {
+			t.Fatalf("PlackettBurman(%d): %v", n, err)
+		}
+		if len(oa) != n || len(oa[0]) != n-1 {
+			t.Fatalf("PlackettBurman(%d): got %dx%d", n, len(oa), len(oa[0]))
+		}
+		checkBalanced(t, fmt.Sprintf("PB%d", n), oa)
+	}
+
+	factors := make([]ControlFactor, 15)
+	for i := range factors {
+		factors[i] = ControlFactor{Name: fmt.Sprintf("F%d", i+1), Levels: []float64{0, 1}}
+	}
+	exp, err := NewScreeningExperiment(SmallerTheBetter{}, factors, nil)
+	if err != nil {
+		t.Fatalf("NewScreeningExperiment: %v", err)
+	}
+	if len(exp.OrthogonalArray) != 16 {
+		t.Errorf("NewScreeningExperiment(15 factors): got %d runs, want 16", len(exp.OrthogonalArray))
+	}
+	exp, err = NewScreeningExperiment(SmallerTheBetter{}, factors[:11], nil)
+	if err != nil || len(exp.OrthogonalArray) != 12 {
+		t.Errorf("NewScreeningExperiment(11 factors): want 12 runs, got err %v", err)
+	}
+}
+
+func TestGenerateFullFactorial(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{10, 20, 30}},
+	}
+	oa := GenerateFullFactorial(factors)
+	if len(oa) != 6 || len(oa[0]) != 2 {
+		t.Fatalf("GenerateFullFactorial: got %dx%d, want 6x2", len(oa), len(oa[0]))
+	}
+	checkBalanced(t, "full factorial", oa)
+
+	exp, err := NewFullFactorialExperiment(LargerTheBetter{}, factors, nil)
+	if err != nil {
+		t.Fatalf("NewFullFactorialExperiment: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		exp.AddResult(trial, []float64{trial.Control["A"] * trial.Control["B"]})
+	}
+	result := exp.Analyze()
+	if result.OptimalLevels["A"] != 2 || result.OptimalLevels["B"] != 30 {
+		t.Errorf("OptimalLevels: got %v, want A=2 B=30", result.OptimalLevels)
+	}
+}
+
+func TestValidateArray(t *testing.T) {
+	for name, oa := range StandardArrays {
+		if err := ValidateArray(oa); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	tests := map[string][][]int{
+		"empty":         nil,
+		"ragged":        {{1, 1}, {1}},
+		"zero level":    {{0, 1}, {1, 2}},
+		"unbalanced":    {{1, 1}, {1, 2}, {2, 1}, {1, 2}},
+		"skipped level": {{1, 1}, {3, 2}},
+		"not pairwise":  {{1, 1}, {1, 1}, {2, 2}, {2, 2}},
+	}
+	for name, oa := range tests {
+		if err := ValidateArray(oa); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	if _, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, tests["not pairwise"], nil); err == nil {
+		t.Error("expected NewExperimentFromFactorsUsingArray to reject a non-orthogonal array")
+	}
+}
+
+func TestArrayInteractions(t *testing.T) {
+	l8, err := ArrayInteractions(L8)
+	if err != nil {
+		t.Fatalf("ArrayInteractions(L8): %v", err)
+	}
+	// Taguchi's L8 triangular table in zero-based columns: 1x2=3, 1x4=5, 2x4=6, 5x6=3.
+	for _, tt := range []struct{ a, b, want int }{{0, 1, 2}, {0, 3, 4}, {1, 3, 5}, {4, 5, 2}} {
+		if got := l8.Interaction(tt.a, tt.b); len(got) != 1 || got[0] != tt.want {
+			t.Errorf("L8 interaction of %d and %d: got %v, want [%d]", tt.a, tt.b, got, tt.want)
+		}
+	}
+	l9, _ := ArrayInteractions(L9)
+	if got := l9.Interaction(1, 0); len(got) != 2 || got[0] != 2 || got[1] != 3 {
+		t.Errorf("L9 interaction of 0 and 1: got %v, want [2 3]", got)
+	}
+	l12, _ := ArrayInteractions(L12)
+	if len(l12) != 0 {
+		t.Errorf("L12: got %d confined interactions, want none", len(l12))
+	}
+	if _, err := ArrayInteractions("L7"); err == nil {
+		t.Error("expected an error for an unknown array")
+	}
+}
+
+func TestAssignInteraction(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+		{Name: "C", Levels: []float64{1, 2}},
+		{Name: "D", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L8, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	// Unreserved, A x B falls on C's column.
+	if got := interactionColumns(exp.OrthogonalArray, 0, 1); len(got) != 1 || got[0] != 2 {
+		t.Fatalf("interaction of A and B before assignment: got %v, want [2]", got)
+	}
+	ab, err := exp.AssignInteraction("A", "B")
+	if err != nil {
+		t.Fatalf("AssignInteraction(A, B): %v", err)
+	}
+	ac, err := exp.AssignInteraction("A", "C")
+	if err != nil {
+		t.Fatalf("AssignInteraction(A, C): %v", err)
+	}
+	for _, c := range append(ab, ac...) {
+		if c < len(factors) {
+			t.Errorf("interaction column %d is assigned to factor %s", c, factors[c].Name)
+		}
+	}
+	if err := ValidateArray(exp.OrthogonalArray); err != nil {
+		t.Errorf("rearranged array: %v", err)
+	}
+	// B x C takes the last free column but D, moved to column 6, still fits.
+	if _, err := exp.AssignInteraction("B", "C"); err != nil {
+		t.Fatalf("AssignInteraction(B, C): %v", err)
+	}
+	if _, err := exp.AssignInteraction("A", "D"); err == nil {
+		t.Error("expected an error when the interaction falls on a reserved column")
+	}
+	if _, err := exp.AssignInteraction("A", "E"); err == nil {
+		t.Error("expected an error for an unknown factor")
+	}
+
+	exp, _ = NewExperimentFromFactors(SmallerTheBetter{}, factors[:2], L12, nil)
+	if _, err := exp.AssignInteraction("A", "B"); err == nil {
+		t.Error("expected an error for L12, whose interactions are not confined to columns")
+	}
+}
+
+func TestSuggestArrays(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2, 3}},
+		{Name: "B", Levels: []float64{1, 2, 3}},
+		{Name: "C", Levels: []float64{1, 2, 3}},
+		{Name: "D", Levels: []float64{0, 1}},
+	}
+	got := SuggestArrays(factors)
+	if len(got) != 2 || got[0].Array != L18 || got[1].Array != "" {
+		t.Fatalf("SuggestArrays: got %+v, want L18 then the full factorial", got)
+	}
+	if got[0].Factors[0].Name != "D" || got[0].SpareColumns != 4 {
+		t.Errorf("L18 suggestion: got first factor %s and %d spare columns, want D and 4", got[0].Factors[0].Name, got[0].SpareColumns)
+	}
+	if got[1].Runs != 54 {
+		t.Errorf("full factorial runs: got %d, want 54", got[1].Runs)
+	}
+
+	got = SuggestArrays(factors[3:])
+	if got[0].Array != "" || len(got) != 1 {
+		t.Errorf("SuggestArrays(one two-level factor): got %+v, want only the full factorial", got)
+	}
+	got = SuggestArrays([]ControlFactor{factors[3], factors[3], factors[3]})
+	if got[0].Array != L4 || got[0].SpareColumns != 0 {
+		t.Errorf("SuggestArrays(three two-level factors): got %+v, want a saturated L4 first", got[0])
+	}
+
+	// 3^40 runs overflow an int: L81 must still be offered, the full factorial must not.
+	many := make([]ControlFactor, 40)
+	for j := range many {
+		many[j] = ControlFactor{Name: fmt.Sprintf("F%d", j), Levels: []float64{1, 2, 3}}
+	}
+	got = SuggestArrays(many)
+	if len(got) != 1 || got[0].Array != L81 {
+		t.Errorf("SuggestArrays(40 three-level factors): got %+v, want only L81", got)
+	}
+}
+
+func TestArrayInfo(t *testing.T) {
+	names := ListArrays()
+	if len(names) != len(StandardArrays) || names[0] != L4 || names[len(names)-1] != L81 {
+		t.Fatalf("ListArrays: got %v", names)
+	}
+	for _, name := range names {
+		info, err := ArrayInfo(name)
+		if err != nil {
+			t.Fatalf("ArrayInfo(%s): %v", name, err)
+		}
+		if info.Strength != 2 {
+			t.Errorf("%s: strength %d, want 2", name, info.Strength)
+		}
+		if info.MaxFactors != info.Columns || len(info.Levels) != info.Columns {
+			t.Errorf("%s: got %+v", name, info)
+		}
+	}
+	info, _ := ArrayInfo(L18)
+	if got, want := info.String(), "L18: 18 runs, 8 columns (2^1 3^7), strength 2"; got != want {
+		t.Errorf("String: got %q, want %q", got, want)
+	}
+	if _, err := ArrayInfo("L7"); err == nil {
+		t.Error("expected an error for an unknown array")
+	}
+	if got := arrayStrength(GenerateFullFactorial([]ControlFactor{{Levels: []float64{1, 2}}, {Levels: []float64{1, 2, 3}}, {Levels: []float64{1, 2}}}), []int{2, 3, 2}); got != 3 {
+		t.Errorf("full factorial strength: got %d, want 3", got)
+	}
+}
+
+func TestGenerateFractionalFactorial(t *testing.T) {
+	tests := []struct {
+		k, resolution, runs, wantResolution int
+	}{
+		{3, 3, 4, 3},
+		{5, 5, 16, 5},
+		{7, 3, 8, 3},
+		{8, 4, 16, 4},
+		{11, 5, 128, 5},
+		{3, 4, 8, 0},
+	}
+	for _, tc := range tests {
+		d, err := GenerateFractionalFactorial(tc.k, tc.resolution)
+		if err != nil {
+			t.Fatalf("GenerateFractionalFactorial(%d, %d): %v", tc.k, tc.resolution, err)
+		}
+		if d.Runs != tc.runs || len(d.Array) != tc.runs || d.Resolution != tc.wantResolution {
+			t.Errorf("k=%d resolution %d: got %d runs at resolution %d, want %d at %d",
+				tc.k, tc.resolution, d.Runs, d.Resolution, tc.runs, tc.wantResolution)
+		}
+		if err := ValidateArray(d.Array); err != nil {
+			t.Errorf("k=%d resolution %d: %v", tc.k, tc.resolution, err)
+		}
+	}
+
+	d, _ := GenerateFractionalFactorial(4, 4)
+	if fmt.Sprint(d.Generators) != "[D = ABC]" || fmt.Sprint(d.DefiningRelation) != "[ABCD]" {
+		t.Errorf("2^(4-1): got generators %v, defining relation %v", d.Generators, d.DefiningRelation)
+	}
+	if got := fmt.Sprint(d.Aliases); got != "[[AB CD] [AC BD] [AD BC]]" {
+		t.Errorf("2^(4-1) aliases: got %s", got)
+	}
+	for _, row := range d.Array {
+		if (row[0]+row[1]+row[2]+row[3])%2 != 0 {
+			t.Errorf("row %v breaks D = ABC", row)
+		}
+	}
+
+	if _, err := GenerateFractionalFactorial(5, 6); err == nil {
+		t.Error("expected an error for resolution VI")
+	}
+}
+
+func TestGenerateDOptimal(t *testing.T) {
+	twoLevel := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2}}, {Name: "C", Levels: []float64{1, 2}}}
+	oa, err := GenerateDOptimal(twoLevel, 4, 1)
+	if err != nil {
+		t.Fatalf("GenerateDOptimal: %v", err)
+	}
+	if err := ValidateArray(oa); err != nil {
+		t.Errorf("3 two-level factors in 4 runs: want an orthogonal array, got %v: %v", oa, err)
+	}
+
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{10, 20, 30}},
+		{Name: "C", Levels: []float64{5, 6, 7}},
+	}
+	design, err := GenerateDOptimal(factors, 7, 1)
+	if err != nil {
+		t.Fatalf("GenerateDOptimal: %v", err)
+	}
+	if len(design) != 7 {
+		t.Fatalf("got %d runs, want 7", len(design))
+	}
+	exp, err := NewExperimentFromFactorsUsingDesign(SmallerTheBetter{}, factors, design, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingDesign: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := trial.Control["A"] + trial.Control["B"]
+		exp.AddResult(trial, []float64{y, y})
+	}
+	result := exp.Analyze()
+	if result.Method != MethodRegression || result.OptimalLevels["B"] != 10 || result.OptimalLevels["A"] != 1 {
+		t.Errorf("got method %q and optimal levels %v", result.Method, result.OptimalLevels)
+	}
+	if result.Contributions["B"] < result.Contributions["C"] {
+		t.Errorf("contributions: got %v, want B above C", result.Contributions)
+	}
+
+	if _, err := GenerateDOptimal(factors, 4, 1); err == nil {
+		t.Error("expected an error for fewer runs than model parameters")
+	}
+	if _, err := NewExperimentFromFactorsUsingDesign(SmallerTheBetter{}, factors, [][]int{{1, 1, 1}, {2, 2, 2}, {1, 3, 3}, {2, 1, 1}, {1, 2, 2}}, nil); err == nil {
+		t.Error("expected an error for a design that confounds B and C")
+	}
+}
+
+func TestGenerateNearlyOrthogonal(t *testing.T) {
+	levels := func(n int) []float64 {
+		l := make([]float64, n)
+		for i := range l {
+			l[i] = float64(10 * (i + 1))
+		}
+		return l
+	}
+	factors := []ControlFactor{{Name: "A", Levels: levels(6)}}
+	for _, name := range []string{"B", "C", "D"} {
+		factors = append(factors, ControlFactor{Name: name, Levels: levels(3)})
+	}
+
+	// 12 runs cannot hold every 6×3 combination equally often.
+	noa, err := GenerateNearlyOrthogonal(factors, 0, 1)
+	if err != nil {
+		t.Fatalf("GenerateNearlyOrthogonal: %v", err)
+	}
+	if len(noa.Array) != 12 || noa.Orthogonal() || noa.J2 < noa.LowerBound || len(noa.NonOrthogonal) == 0 {
+		t.Errorf("12 runs: got %d runs, J2 %d (bound %d), non-orthogonal %v", len(noa.Array), noa.J2, noa.LowerBound, noa.NonOrthogonal)
+	}
+	for j, f := range factors {
+		counts := map[int]int{}
+		for _, row := range noa.Array {
+			counts[row[j]]++
+		}
+		if len(counts) != len(f.Levels) || counts[1] != 12/len(f.Levels) {
+			t.Errorf("factor %s is not balanced: %v", f.Name, counts)
+		}
+	}
+
+	// 18 runs admit an orthogonal array, which the exchange finds.
+	oa, err := GenerateNearlyOrthogonal(factors, 18, 1)
+	if err != nil {
+		t.Fatalf("GenerateNearlyOrthogonal: %v", err)
+	}
+	if !oa.Orthogonal() || len(oa.NonOrthogonal) != 0 {
+		t.Errorf("18 runs: J2 %d, bound %d, non-orthogonal %v", oa.J2, oa.LowerBound, oa.NonOrthogonal)
+	}
+	if _, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, oa.Array, nil); err != nil {
+		t.Errorf("18-run array rejected: %v", err)
+	}
+
+	exp, err := NewExperimentFromFactorsUsingDesign(SmallerTheBetter{}, factors, noa.Array, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingDesign: %v", err)
+	}
+	for _, trial := range exp.GenerateTrials() {
+		y := trial.Control["A"] + trial.Control["C"]
+		exp.AddResult(trial, []float64{y, y})
+	}
+	if got := exp.Analyze().OptimalLevels; got["A"] != 10 || got["C"] != 10 {
+		t.Errorf("OptimalLevels: got %v", got)
+	}
+	if _, err := GenerateNearlyOrthogonal(factors, 15, 1); err == nil {
+		t.Error("expected an error for runs that cannot balance the 6-level factor")
+	}
+}
+
+func TestRegisterArray(t *testing.T) {
+	const name ArrayType = "L6_inhouse"
+	rows := [][]int{{1, 1}, {1, 2}, {1, 3}, {2, 1}, {2, 2}, {2, 3}}
+	if err := RegisterArray(name, rows); err != nil {
+		t.Fatalf("RegisterArray: %v", err)
+	}
+	defer delete(StandardArrays, name)
+
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}, {Name: "B", Levels: []float64{1, 2, 3}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, name, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	if len(exp.GenerateTrials()) != 6 {
+		t.Errorf("got %d trials, want 6", len(exp.GenerateTrials()))
+	}
+	if got, err := SelectArray(factors); err != nil || got != name {
+		t.Errorf("SelectArray: got %s, %v, want %s", got, err, name)
+	}
+
+	if err := RegisterArray(name, rows); err == nil {
+		t.Error("expected an error for a duplicate name")
+	}
+	if err := RegisterArray(L8, rows); err == nil {
+		t.Error("expected an error for a built-in name")
+	}
+	if err := RegisterArray("L3_bad", [][]int{{1, 1}, {2, 2}, {2, 1}}); err == nil {
+		t.Error("expected an error for a non-orthogonal array")
+	}
+}
+
+func FuzzRandomOrthogonalArray(f *testing.F) {
+	f.Add(0, int64(1))
+	f.Add(5, int64(2))
+	f.Add(7, int64(3))
+	names := ListArrays()
+	f.Fuzz(func(t *testing.T, index int, seed int64) {
+		if index < 0 {
+			index = -index
+		}
+		name := names[index%len(names)]
+		oa, err := RandomOrthogonalArray(name, seed)
+		if err != nil {
+			t.Fatalf("RandomOrthogonalArray(%s): %v", name, err)
+		}
+		if err := ValidateArray(oa); err != nil {
+			t.Fatalf("variant of %s is not orthogonal: %v", name, err)
+		}
+		if got, want := ColumnLevels(oa), ColumnLevels(StandardArrays[name]); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("variant of %s: column levels %v, want %v", name, got, want)
+		}
+	})
+}
+
+func FuzzValidateArray(f *testing.F) {
+	f.Add(uint8(8), uint8(3), uint8(2), int64(1))
+	f.Add(uint8(9), uint8(4), uint8(3), int64(2))
+	f.Add(uint8(4), uint8(1), uint8(4), int64(3))
+	f.Fuzz(func(t *testing.T, rows, cols, levels uint8, seed int64) {
+		oa, err := RandomBalancedArray(int(rows), int(cols%16), int(levels%8), seed)
+		if err != nil {
+			return
+		}
+		for j, n := range ColumnLevels(oa) {
+			if n != int(levels%8) {
+				t.Fatalf("column %d has %d levels, want %d", j+1, n, levels%8)
+			}
+		}
+		if ValidateArray(oa) != nil {
+			return
+		}
+		// An accepted array must support an experiment on all of its columns.
+		factors := make([]ControlFactor, len(oa[0]))
+		for j := range factors {
+			factors[j] = ControlFactor{Name: fmt.Sprintf("F%d", j), Levels: make([]float64, levels%8)}
+			for l := range factors[j].Levels {
+				factors[j].Levels[l] = float64(l)
+			}
+		}
+		if _, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, oa, nil); err != nil {
+			t.Fatalf("ValidateArray accepted %v but NewExperimentFromFactorsUsingArray failed: %v", oa, err)
+		}
+	})
+}
@@ -0,0 +1,68 @@
+package taguchi
+
+import "slices"
+
+// OuterRun is one noise condition of an inner-array row, as recorded in a grouped
+// TrialResult (see GroupNoiseReplicates).
+// Noise: The noise factor levels of the run.
+// Observations: The observations measured under that noise condition.
+type OuterRun struct {
+	Noise        map[string]float64
+	Observations []float64
+}
+
+// GroupNoiseReplicates sets whether results of the same control configuration are
+// merged into one TrialResult per inner-array row, as in classical Taguchi tables:
+// its Observations hold every noise replicate, Outer records which noise condition
+// produced which observations, Cost is summed, and the other fields of the first run
+// are kept. Enabling it regroups the results recorded so far. Analysis computes each
+// row's SNR across all of its noise conditions either way.
+func (e *Experiment[P]) GroupNoiseReplicates(group bool) {
+	e.groupNoise = group
+	if !group {
+		return
+	}
+	results := e.Results
+	e.Results = nil
+	for _, r := range results {
+		if r.Outer != nil {
+			e.Results = append(e.Results, r)
+			continue
+		}
+		e.addOuterRun(r)
+	}
+}
+
+// addOuterRun merges result into the grouped result of its control configuration,
+// creating that result for the configuration's first run.
+func (e *Experiment[P]) addOuterRun(result TrialResult) {
+	run := OuterRun{Noise: result.Trial.Noise, Observations: result.Observations}
+	for k := range e.Results {
+		grouped := &e.Results[k]
+		if !e.sameControl(grouped.Trial, result.Trial) {
+			continue
+		}
+		if grouped.Weights != nil || result.Weights != nil {
+			grouped.Weights = append(weightsOf(*grouped), weightsOf(result)...)
+		}
+		grouped.Observations = append(grouped.Observations, result.Observations...)
+		grouped.Outer = append(grouped.Outer, run)
+		grouped.Cost += result.Cost
+		return
+	}
+	result.Trial.Noise = nil
+	result.Observations = slices.Clone(result.Observations)
+	result.Weights = slices.Clone(result.Weights)
+	result.Outer = []OuterRun{run}
+	e.Results = append(e.Results, result)
+}
+
+// sameControl reports whether two trials set every control factor to the same level.
+func (e *Experiment[P]) sameControl(a, b Trial) bool {
+	for _, factor := range e.ControlFactors {
+		if a.Control[factor.Name] != b.Control[factor.Name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package taguchi
+
+import "math"
+
+// PairedDifference is the goal for observations that are paired differences, treatment
+// minus control per replicate, as recorded by CanaryMeasure or by lab before/after
+// studies. Differences can be negative or zero, which the logarithmic SNRs of the other
+// goals cannot handle, so the SNR is the standardized mean difference d̄/s: the
+// improvement in units of its own replicate-to-replicate noise, i.e. the paired t
+// statistic per replicate. It rewards a large improvement that holds consistently
+// across replicates.
+// Larger: Whether the treatment should raise the response (e.g. throughput); by default
+// it should lower it (e.g. latency), and the SNR is -d̄/s.
+type PairedDifference struct {
+	Larger bool
+}
+
+// CalculateSNR computes the standardized mean difference, signed so that improvements
+// are positive. It is 0 for fewer than 2 differences, and ±Inf when they are all equal
+// and nonzero.
+func (p PairedDifference) CalculateSNR(obs []float64) float64 {
+	n := len(obs)
+	if n < 2 {
+		return 0
+	}
+	m := mean(obs)
+	variance := 0.0
+	for _, d := range obs {
+		variance += (d - m) * (d - m)
+	}
+	variance /= float64(n - 1)
+	if !p.Larger {
+		m = -m
+	}
+	if variance == 0 {
+		if m == 0 {
+			return 0
+		}
+		return math.Inf(int(math.Copysign(1, m)))
+	}
+	return m / math.Sqrt(variance)
+}
+
+// String returns the human-readable name for the PairedDifference goal.
+func (p PairedDifference) String() string {
+	if p.Larger {
+		return "Paired Difference (Larger-the-Better)"
+	}
+	return "Paired Difference (Smaller-the-Better)"
+}
@@ -0,0 +1,90 @@
+package taguchi
+
+import (
+	"fmt"
+	"sync"
+)
+
+// AnalysisPass is a custom analysis step that runs at the end of Analyze and
+// attaches an extra section to the AnalysisResult and the printed report.
+type AnalysisPass interface {
+	Name() string
+	Run(in PassInput) AnalysisSection
+}
+
+// PassInput is the data made available to an AnalysisPass.
+// ControlFactors / NoiseFactors / OrthogonalArray / Results: The experiment being analyzed.
+// Array: The orthogonal array as an ArraySource; unlike OrthogonalArray it is always set.
+// RowSNR: Signal-to-noise ratio of each orthogonal array row.
+// Result: The built-in analysis, computed before any pass runs.
+type PassInput struct {
+	Goal            OptimizationGoal
+	ControlFactors  []ControlFactor
+	NoiseFactors    []NoiseFactor
+	OrthogonalArray [][]int
+	Array           ArraySource
+	Results         []TrialResult
+	RowSNR          []float64
+	Result          AnalysisResult
+}
+
+// AnalysisSection is the output of an AnalysisPass.
+// Pass: Name of the pass that produced the section.
+// Title: Heading printed in the report.
+// Values: Named numeric results, for programmatic use.
+// Lines: Free-form lines printed in the report under the heading.
+type AnalysisSection struct {
+	Pass   string
+	Title  string
+	Values map[string]float64
+	Lines  []string
+}
+
+var (
+	passesMu sync.RWMutex
+	passes   []AnalysisPass
+)
+
+// RegisterAnalysisPass adds a pass that runs inside every subsequent Analyze call.
+// Passes run in registration order. Registering two passes with the same name is an error.
+func RegisterAnalysisPass(p AnalysisPass) error {
+	passesMu.Lock()
+	defer passesMu.Unlock()
+	for _, existing := range passes {
+		if existing.Name() == p.Name() {
+			return fmt.Errorf("analysis pass %s already registered", p.Name())
+		}
+	}
+	passes = append(passes, p)
+	return nil
+}
+
+// UnregisterAnalysisPass removes the pass with the given name, if registered.
+func UnregisterAnalysisPass(name string) {
+	passesMu.Lock()
+	defer passesMu.Unlock()
+	for i, p := range passes {
+		if p.Name() == name {
+			passes = append(passes[:i], passes[i+1:]...)
+			return
+		}
+	}
+}
+
+// runPasses executes all registered passes and returns their sections.
+func runPasses(in PassInput) []AnalysisSection {
+	passesMu.RLock()
+	registered := append([]AnalysisPass(nil), passes...)
+	passesMu.RUnlock()
+
+	var sections []AnalysisSection
+	for _, p := range registered {
+		section := p.Run(in)
+		section.Pass = p.Name()
+		if section.Title == "" {
+			section.Title = p.Name()
+		}
+		sections = append(sections, section)
+	}
+	return sections
+}
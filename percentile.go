@@ -0,0 +1,62 @@
+package taguchi
+
+import "fmt"
+
+// QuantileSketch summarizes a stream of observations in bounded memory, so that tail
+// percentiles of millions of samples per trial can be recorded without keeping them.
+// TDigest implements it.
+type QuantileSketch interface {
+	Add(x float64)
+	Quantile(q float64) float64
+	Count() int64
+}
+
+// PercentileGoal optimizes a percentile of each replicate's response distribution
+// instead of the raw samples, e.g. SmallerTheBetter on p99 latency.
+// Goal: The goal applied to the percentiles (SmallerTheBetter, LargerTheBetter, ...).
+// Percentile: The percentile summarizing each replicate, in percent (e.g. 99).
+// Each observation of a trial is one replicate's percentile, as recorded by
+// AddSketchResult, so the SNR is Goal's SNR over those percentiles.
+type PercentileGoal struct {
+	Goal       OptimizationGoal
+	Percentile float64
+}
+
+// CalculateSNR applies Goal to the per-replicate percentiles.
+func (p PercentileGoal) CalculateSNR(obs []float64) float64 {
+	return p.Goal.CalculateSNR(obs)
+}
+
+// String returns the underlying goal's name with the percentile, e.g.
+// "Smaller-the-Better (p99)".
+func (p PercentileGoal) String() string {
+	return fmt.Sprintf("%s (p%g)", p.Goal, p.Percentile)
+}
+
+// AddSketchResult records a trial whose samples were summarized online: each sketch is
+// one replicate, and contributes its percentile at the goal's Percentile as an
+// observation. The experiment's goal must be a PercentileGoal.
+func (e *Experiment[P]) AddSketchResult(trial Trial, sketches ...QuantileSketch) error {
+	goal, ok := e.Goal.(PercentileGoal)
+	if !ok {
+		return fmt.Errorf("optimization goal %s is not a percentile goal", e.Goal)
+	}
+	observations := make([]float64, 0, len(sketches))
+	for i, s := range sketches {
+		if s.Count() == 0 {
+			return fmt.Errorf("sketch %d of trial %d has no observations", i+1, trial.ID)
+		}
+		observations = append(observations, s.Quantile(goal.Percentile/100))
+	}
+	e.AddResult(trial, observations)
+	return nil
+}
+
+// baseGoal returns the goal a PercentileGoal applies to its percentiles, and goal itself
+// otherwise.
+func baseGoal(goal OptimizationGoal) OptimizationGoal {
+	if p, ok := goal.(PercentileGoal); ok {
+		return p.Goal
+	}
+	return goal
+}
@@ -0,0 +1,43 @@
+package taguchi
+
+import "math/rand"
+
+// PermutationTest computes distribution-free p-values for each factor's effect.
+// The observation sets of the recorded results are shuffled across trials, which
+// breaks any link between control settings and response, and the factor sums of
+// squares are recomputed; a factor's p-value is the share of permutations whose SS
+// is at least the observed one (with the usual +1 correction). Unlike the F-ratio
+// it does not need error degrees of freedom, so it also works for saturated designs.
+// A permutations value below 1 defaults to 999.
+func (e *Experiment[P]) PermutationTest(permutations int, seed int64) map[string]float64 {
+	if permutations < 1 {
+		permutations = 999
+	}
+	oaSNR, grandMean := e.computeOASNR()
+	observed, _, _ := e.computeANOVA(oaSNR, grandMean)
+
+	rng := rand.New(rand.NewSource(seed))
+	shuffled := *e
+	shuffled.Results = append([]TrialResult(nil), e.Results...)
+	exceed := make(map[string]int, len(e.ControlFactors))
+	for p := 0; p < permutations; p++ {
+		rng.Shuffle(len(shuffled.Results), func(i, j int) {
+			a, b := &shuffled.Results[i], &shuffled.Results[j]
+			a.Observations, b.Observations = b.Observations, a.Observations
+		})
+		snr, mean := shuffled.computeOASNR()
+		anova, _, _ := shuffled.computeANOVA(snr, mean)
+		for _, factor := range e.ControlFactors {
+			// A small tolerance counts permutations tying the observed SS.
+			if anova.FactorSS[factor.Name] >= observed.FactorSS[factor.Name]*(1-1e-12) {
+				exceed[factor.Name]++
+			}
+		}
+	}
+
+	pValues := make(map[string]float64, len(e.ControlFactors))
+	for _, factor := range e.ControlFactors {
+		pValues[factor.Name] = float64(exceed[factor.Name]+1) / float64(permutations+1)
+	}
+	return pValues
+}
@@ -0,0 +1,99 @@
+package taguchi
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ArraySuggestion is a candidate design for a set of control factors, as offered by the
+// `taguchi plan` command.
+// Array: Standard array name, or empty for the full factorial.
+// Runs: Number of control configurations (rows), before crossing with noise.
+// SpareColumns: Columns left after assigning the factors.
+// Factors: The factors in the column order the array needs.
+// Notes: Trade-offs of the design in plain language.
+type ArraySuggestion struct {
+	Array        ArrayType
+	Runs         int
+	SpareColumns int
+	Factors      []ControlFactor
+	Notes        []string
+}
+
+// maxFullFactorialRuns is the largest full factorial SuggestArrays offers; bigger ones
+// are not practical to generate, let alone run.
+const maxFullFactorialRuns = 100_000
+
+// SuggestArrays lists the standard arrays that accommodate the factors, smallest first,
+// followed by the full factorial unless it has more than 100,000 runs, each with notes on
+// its trade-offs. Arrays that only fit with the factors ordered by level count (fewest
+// first, as mixed-level arrays such as L18 need) are suggested with that order.
+func SuggestArrays(factors []ControlFactor) []ArraySuggestion {
+	reordered := slices.Clone(factors)
+	slices.SortStableFunc(reordered, func(a, b ControlFactor) int { return len(a.Levels) - len(b.Levels) })
+
+	// The full factorial's size is counted in floating point, which cannot overflow
+	// however many factors there are.
+	full := 1.0
+	for _, f := range factors {
+		full *= float64(len(f.Levels))
+	}
+
+	var suggestions []ArraySuggestion
+	for name := range StandardArrays {
+		spec, err := StandardArraySpec(name)
+		if err != nil {
+			continue
+		}
+		s := ArraySuggestion{
+			Array:        name,
+			Runs:         len(spec.Rows),
+			SpareColumns: len(spec.Levels) - len(factors),
+			Factors:      factors,
+		}
+		if spec.Validate(factors) != nil {
+			if spec.Validate(reordered) != nil {
+				continue
+			}
+			s.Factors = reordered
+			names := make([]string, len(reordered))
+			for i, f := range reordered {
+				names[i] = f.Name
+			}
+			s.Notes = append(s.Notes, "factors reordered to "+strings.Join(names, ", ")+" to match the columns")
+		}
+		if float64(s.Runs) >= full {
+			// Never smaller than the full factorial, which the final entry covers.
+			continue
+		}
+		if s.SpareColumns == 0 {
+			s.Notes = append(s.Notes, "saturated: no columns are left to estimate error, so replicate the runs or pool small effects")
+		} else {
+			s.Notes = append(s.Notes, fmt.Sprintf("%d spare columns for estimating error or reserving interactions", s.SpareColumns))
+		}
+		if len(factors) >= 2 && len(interactionColumns(spec.Rows, 0, 1)) == 0 {
+			s.Notes = append(s.Notes, "interactions are spread over all columns, so effects are main effects only but robust to moderate interactions")
+		}
+		s.Notes = append(s.Notes, fmt.Sprintf("%.2g%% of the full factorial's %g runs", 100*float64(s.Runs)/full, full))
+		suggestions = append(suggestions, s)
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].Runs != suggestions[j].Runs {
+			return suggestions[i].Runs < suggestions[j].Runs
+		}
+		return suggestions[i].Array < suggestions[j].Array
+	})
+
+	if full > maxFullFactorialRuns {
+		return suggestions
+	}
+	return append(suggestions, ArraySuggestion{
+		Runs:    int(full),
+		Factors: factors,
+		Notes: []string{
+			"full factorial: every combination is run, so all interactions can be estimated",
+		},
+	})
+}
@@ -0,0 +1,26 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"os"
+	"runtime/pprof"
+)
+
+// startCPUProfile starts writing a CPU profile to path and returns a function that
+// stops profiling and closes the file.
+func startCPUProfile(path string) (func() error, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return nil, err
+	}
+	return func() error {
+		pprof.StopCPUProfile()
+		return f.Close()
+	}, nil
+}
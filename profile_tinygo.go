@@ -0,0 +1,10 @@
+//go:build tinygo
+
+package taguchi
+
+import "errors"
+
+// startCPUProfile is not supported under TinyGo.
+func startCPUProfile(path string) (func() error, error) {
+	return nil, errors.New("CPU profiling is not supported under TinyGo")
+}
@@ -0,0 +1,122 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PrometheusSource is an ObservationSource running PromQL queries over a trial's
+// measurement window, e.g. as FlagRunner's Source, so live-system experiments need no
+// measurement code of their own.
+// URL: The Prometheus server, e.g. "http://prometheus:9090".
+// Queries: The PromQL instant queries, evaluated at the end of the window; "$window" is
+// replaced by the window's length, e.g. "histogram_quantile(0.99,
+// sum by (le) (rate(http_request_duration_seconds_bucket[$window])))". Every sample of
+// every query's result is one observation.
+// Client: The HTTP client to use; http.DefaultClient if nil.
+type PrometheusSource struct {
+	URL     string
+	Queries []string
+	Client  *http.Client
+}
+
+// Observations runs the queries for the window from start to end.
+func (p PrometheusSource) Observations(st ScheduledTrial, start, end time.Time) ([]float64, error) {
+	if len(p.Queries) == 0 {
+		return nil, fmt.Errorf("prometheus source has no queries")
+	}
+	// Prometheus durations take whole units; round the window up to a second.
+	window := fmt.Sprintf("%ds", int64(math.Ceil(end.Sub(start).Seconds())))
+	var observations []float64
+	for _, query := range p.Queries {
+		values, err := p.query(strings.ReplaceAll(query, "$window", window), end)
+		if err != nil {
+			return nil, fmt.Errorf("trial %d: %w", st.Trial.ID, err)
+		}
+		observations = append(observations, values...)
+	}
+	return observations, nil
+}
+
+// query runs an instant query and returns the values of its result.
+func (p PrometheusSource) query(query string, at time.Time) ([]float64, error) {
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	params := url.Values{
+		"query": {query},
+		"time":  {strconv.FormatFloat(float64(at.UnixMilli())/1000, 'f', 3, 64)},
+	}
+	resp, err := client.Get(strings.TrimSuffix(p.URL, "/") + "/api/v1/query?" + params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("query %q: %w", query, err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			ResultType string          `json:"resultType"`
+			Result     json.RawMessage `json:"result"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("query %q: %s: %w", query, resp.Status, err)
+	}
+	if body.Status != "success" {
+		return nil, fmt.Errorf("query %q: %s", query, body.Error)
+	}
+
+	// A sample value is a [timestamp, "value"] pair.
+	var samples [][2]json.RawMessage
+	switch body.Data.ResultType {
+	case "scalar":
+		var sample [2]json.RawMessage
+		if err := json.Unmarshal(body.Data.Result, &sample); err != nil {
+			return nil, fmt.Errorf("query %q: %w", query, err)
+		}
+		samples = append(samples, sample)
+	case "vector":
+		var series []struct {
+			Value [2]json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal(body.Data.Result, &series); err != nil {
+			return nil, fmt.Errorf("query %q: %w", query, err)
+		}
+		for _, s := range series {
+			samples = append(samples, s.Value)
+		}
+	default:
+		return nil, fmt.Errorf("query %q: unsupported result type %q; use an instant vector or scalar", query, body.Data.ResultType)
+	}
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("query %q returned no samples", query)
+	}
+
+	values := make([]float64, len(samples))
+	for i, sample := range samples {
+		var s string
+		if err := json.Unmarshal(sample[1], &s); err != nil {
+			return nil, fmt.Errorf("query %q: %w", query, err)
+		}
+		v, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("query %q: %w", query, err)
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, fmt.Errorf("query %q returned %s", query, s)
+		}
+		values[i] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,54 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestPrometheusSource(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		queries = append(queries, q.Get("query")+"@"+q.Get("time"))
+		switch q.Get("query") {
+		case "p99[70s]":
+			io.WriteString(w, `{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"instance":"a"},"value":[1000,"0.25"]},
+				{"metric":{"instance":"b"},"value":[1000,"0.5"]}]}}`)
+		case "scalar(errors)":
+			io.WriteString(w, `{"status":"success","data":{"resultType":"scalar","result":[1000,"3"]}}`)
+		case "nan":
+			io.WriteString(w, `{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1000,"NaN"]}]}}`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			io.WriteString(w, `{"status":"error","errorType":"bad_data","error":"parse error"}`)
+		}
+	}))
+	defer srv.Close()
+
+	st := ScheduledTrial{Trial: Trial{ID: 7}}
+	start := time.Unix(1000, 0)
+	end := start.Add(69500 * time.Millisecond)
+	src := PrometheusSource{URL: srv.URL + "/", Queries: []string{"p99[$window]", "scalar(errors)"}}
+	got, err := src.Observations(st, start, end)
+	if err != nil {
+		t.Fatalf("Observations: %v", err)
+	}
+	if !slices.Equal(got, []float64{0.25, 0.5, 3}) {
+		t.Errorf("Observations: got %v, want [0.25 0.5 3]", got)
+	}
+	if queries[0] != "p99[70s]@1069.500" {
+		t.Errorf("query: got %q", queries[0])
+	}
+	for _, q := range []string{"nan", "bad"} {
+		if _, err := (PrometheusSource{URL: srv.URL, Queries: []string{q}}).Observations(st, start, end); err == nil {
+			t.Errorf("query %q: expected an error", q)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package taguchi
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Provisioner creates and destroys the infrastructure a trial runs on, e.g. cloud
+// instances sized by the trial's factor levels. Both calls must be idempotent: Provision
+// may find infrastructure left over from an interrupted run of the same trial, and
+// Destroy is also called after a failed or partial Provision.
+type Provisioner interface {
+	// Provision creates the trial's infrastructure and returns its outputs, such as
+	// endpoint addresses, for the workload.
+	Provision(st ScheduledTrial) (map[string]string, error)
+	// Destroy removes the trial's infrastructure.
+	Destroy(st ScheduledTrial) error
+}
+
+// ProvisionCoster is implemented by provisioners that can price a trial's
+// infrastructure, given how long it was up.
+type ProvisionCoster interface {
+	ProvisionCost(st ScheduledTrial, up time.Duration) (float64, error)
+}
+
+// ProvisionedRunner runs trials that each need their own infrastructure: provision,
+// run the workload, measure, destroy. It records each trial's infrastructure cost when
+// its Provisioner is a ProvisionCoster. It is safe for concurrent use.
+type ProvisionedRunner struct {
+	p     Provisioner
+	now   func() time.Time
+	mu    sync.Mutex
+	costs map[inflightKey]float64
+}
+
+// NewProvisionedRunner returns a runner provisioning trials with p.
+func NewProvisionedRunner(p Provisioner) *ProvisionedRunner {
+	return &ProvisionedRunner{p: p, now: time.Now, costs: make(map[inflightKey]float64)}
+}
+
+// Wrap adapts a workload into a Scheduler.Run measurement function. Each trial's
+// infrastructure is provisioned, the workload runs against its outputs, and the
+// infrastructure is destroyed even if provisioning or the workload failed; a failed
+// Destroy fails the trial, so that leaked infrastructure is noticed.
+func (r *ProvisionedRunner) Wrap(workload func(st ScheduledTrial, outputs map[string]string) ([]float64, error)) func(ScheduledTrial) ([]float64, error) {
+	return func(st ScheduledTrial) (observations []float64, err error) {
+		start := r.now()
+		defer func() {
+			if destroyErr := r.p.Destroy(st); destroyErr != nil {
+				err = errors.Join(err, fmt.Errorf("destroy: %w", destroyErr))
+			}
+			if err != nil {
+				return
+			}
+			if coster, ok := r.p.(ProvisionCoster); ok {
+				cost, costErr := coster.ProvisionCost(st, r.now().Sub(start))
+				if costErr != nil {
+					err = fmt.Errorf("provision cost: %w", costErr)
+					return
+				}
+				r.mu.Lock()
+				r.costs[inflightKey{st.Experiment, st.Trial.ID}] = cost
+				r.mu.Unlock()
+			}
+		}()
+
+		outputs, err := r.p.Provision(st)
+		if err != nil {
+			return nil, fmt.Errorf("provision: %w", err)
+		}
+		return workload(st, outputs)
+	}
+}
+
+// Cost returns the infrastructure cost recorded for a trial measured through Wrap, or 0
+// if none was; pass it to Scheduler.SetCostFunc to capture provisioning costs in
+// TrialResult.Cost.
+func (r *ProvisionedRunner) Cost(st ScheduledTrial, elapsed time.Duration) float64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.costs[inflightKey{st.Experiment, st.Trial.ID}]
+}
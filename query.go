@@ -0,0 +1,157 @@
+package taguchi
+
+import (
+	"slices"
+	"strings"
+)
+
+// Query is a read-only view of an experiment's results for ad-hoc exploration, built by
+// Experiment.Query and narrowed with Where. Columns are named "Control.<factor>",
+// "Noise.<factor>" or "Covariates.<name>"; a bare name is looked up among the control
+// factors, then the noise factors, then the covariates.
+type Query struct {
+	results []TrialResult
+}
+
+// Grouping is a Query whose results are grouped by the values of some columns.
+type Grouping struct {
+	columns []string
+	keys    [][]float64
+	groups  [][]TrialResult
+}
+
+// Table is the result of aggregating a Grouping: one row per group, sorted by the
+// group columns.
+// Columns: Column headers: the group columns, then the aggregate.
+// Rows: The group values followed by the aggregate value.
+type Table struct {
+	Columns []string
+	Rows    [][]float64
+}
+
+// Query returns a view of the recorded results. Grouped results (see
+// GroupNoiseReplicates) are split back into one result per noise condition, so that
+// noise columns can be filtered and grouped on.
+func (e *Experiment[P]) Query() Query {
+	q := Query{results: make([]TrialResult, 0, len(e.Results))}
+	for _, r := range e.Results {
+		if r.Outer == nil {
+			q.results = append(q.results, r)
+			continue
+		}
+		for _, run := range r.Outer {
+			split := r
+			split.Trial.Noise = run.Noise
+			split.Observations = run.Observations
+			split.Outer = nil
+			q.results = append(q.results, split)
+		}
+	}
+	return q
+}
+
+// Results returns the results selected by the query.
+func (q Query) Results() []TrialResult {
+	return slices.Clone(q.results)
+}
+
+// Where keeps the results whose column equals value. Results without the column are
+// dropped.
+func (q Query) Where(column string, value float64) Query {
+	var kept []TrialResult
+	for _, r := range q.results {
+		if v, ok := lookupColumn(r, column); ok && v == value {
+			kept = append(kept, r)
+		}
+	}
+	return Query{results: kept}
+}
+
+// GroupBy groups the results by the values of the given columns. Results missing any
+// of the columns are left out.
+func (q Query) GroupBy(columns ...string) Grouping {
+	g := Grouping{columns: columns}
+	for _, r := range q.results {
+		key := make([]float64, len(columns))
+		ok := true
+		for c, column := range columns {
+			if key[c], ok = lookupColumn(r, column); !ok {
+				break
+			}
+		}
+		if !ok {
+			continue
+		}
+		k := slices.IndexFunc(g.keys, func(other []float64) bool { return slices.Equal(other, key) })
+		if k < 0 {
+			g.keys = append(g.keys, key)
+			g.groups = append(g.groups, nil)
+			k = len(g.keys) - 1
+		}
+		g.groups[k] = append(g.groups[k], r)
+	}
+	return g
+}
+
+// Mean returns the mean observation of each group, in a column named "Mean".
+func (g Grouping) Mean() Table {
+	return g.aggregate("Mean", func(results []TrialResult) float64 {
+		var observations []float64
+		for _, r := range results {
+			observations = append(observations, r.Observations...)
+		}
+		if len(observations) == 0 {
+			return 0
+		}
+		return mean(observations)
+	})
+}
+
+// Count returns the number of observations of each group, in a column named "Count".
+func (g Grouping) Count() Table {
+	return g.aggregate("Count", func(results []TrialResult) float64 {
+		n := 0
+		for _, r := range results {
+			n += len(r.Observations)
+		}
+		return float64(n)
+	})
+}
+
+func (g Grouping) aggregate(name string, fn func([]TrialResult) float64) Table {
+	t := Table{
+		Columns: append(slices.Clone(g.columns), name),
+		Rows:    make([][]float64, len(g.keys)),
+	}
+	for k, key := range g.keys {
+		t.Rows[k] = append(slices.Clone(key), fn(g.groups[k]))
+	}
+	slices.SortFunc(t.Rows, func(a, b []float64) int {
+		return slices.Compare(a[:len(g.columns)], b[:len(g.columns)])
+	})
+	return t
+}
+
+// lookupColumn returns the value of a query column in a result.
+func lookupColumn(r TrialResult, column string) (float64, bool) {
+	prefix, name, found := strings.Cut(column, ".")
+	if found {
+		switch prefix {
+		case "Control":
+			v, ok := r.Trial.Control[name]
+			return v, ok
+		case "Noise":
+			v, ok := r.Trial.Noise[name]
+			return v, ok
+		case "Covariates":
+			v, ok := r.Covariates[name]
+			return v, ok
+		}
+	}
+	for _, m := range []map[string]float64{r.Trial.Control, r.Trial.Noise, r.Covariates} {
+		if v, ok := m[column]; ok {
+			return v, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,79 @@
+package taguchi
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+)
+
+// RandomBalancedArray returns a deterministic pseudo-random array with rows rows and
+// cols columns of levels levels each, in which every level appears equally often within
+// each column. Columns are shuffled independently, so the array is balanced but almost
+// never orthogonal: useful as property-based test input for code that must cope with
+// arbitrary designs, e.g. NewExperimentFromFactorsUsingDesign or ValidateArray.
+func RandomBalancedArray(rows, cols, levels int, seed int64) ([][]int, error) {
+	if rows < 1 || cols < 1 || levels < 1 {
+		return nil, fmt.Errorf("random array needs positive rows, columns and levels, got %d, %d and %d", rows, cols, levels)
+	}
+	if rows%levels != 0 {
+		return nil, fmt.Errorf("%d levels cannot be balanced over %d rows", levels, rows)
+	}
+	rng := rand.New(rand.NewSource(seed))
+	oa := make([][]int, rows)
+	for i := range oa {
+		oa[i] = make([]int, cols)
+	}
+	for j := 0; j < cols; j++ {
+		for i, k := range rng.Perm(rows) {
+			oa[i][j] = k%levels + 1
+		}
+	}
+	return oa, nil
+}
+
+// RandomOrthogonalArray returns a deterministic pseudo-random variant of a standard
+// array: rows shuffled, columns with the same number of levels swapped and the levels of
+// every column relabeled. The result is still an orthogonal array with the same column
+// level counts, so it exercises code that must not depend on the standard layout, such
+// as new array generators checked against ValidateArray and Analyze.
+func RandomOrthogonalArray(name ArrayType, seed int64) ([][]int, error) {
+	spec, err := StandardArraySpec(name)
+	if err != nil {
+		return nil, err
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	// Columns are permuted only among columns with the same level count, so the level
+	// counts of the columns stay where SelectArray and ArraySpec.Validate expect them.
+	columns := make([]int, len(spec.Levels))
+	for j := range columns {
+		columns[j] = j
+	}
+	distinct := slices.Clone(spec.Levels)
+	slices.Sort(distinct)
+	for _, levels := range slices.Compact(distinct) {
+		var same []int
+		for j, l := range spec.Levels {
+			if l == levels {
+				same = append(same, j)
+			}
+		}
+		for k, p := range rng.Perm(len(same)) {
+			columns[same[k]] = same[p]
+		}
+	}
+	labels := make([][]int, len(spec.Levels))
+	for j, levels := range spec.Levels {
+		labels[j] = rng.Perm(levels)
+	}
+
+	oa := make([][]int, len(spec.Rows))
+	for i, r := range rng.Perm(len(spec.Rows)) {
+		row := make([]int, len(spec.Levels))
+		for j, c := range columns {
+			row[j] = labels[j][spec.Rows[r][c]-1] + 1
+		}
+		oa[i] = row
+	}
+	return oa, nil
+}
@@ -0,0 +1,249 @@
+package taguchi
+
+import (
+	"math"
+	"slices"
+)
+
+// MethodRegression is the analysis method reported for designs that are not orthogonal
+// arrays, such as Latin hypercube and D-optimal designs.
+const MethodRegression = "Regression (least squares on SNR)"
+
+// Designs analyzed by regression rather than by level means.
+const (
+	designLatinHypercube      = "latin-hypercube"
+	designRegression          = "regression"
+	designSupersaturated      = "supersaturated"       // by forward selection
+	designDefinitiveScreening = "definitive-screening" // by quadratic forward selection
+	designBoxBehnken          = "box-behnken"          // by quadratic forward selection
+	designCentralComposite    = "central-composite"    // by quadratic forward selection
+)
+
+// regressionColumns returns the model columns of level l of control factor j: the level
+// value itself for a Latin hypercube, whose factors are continuous, and otherwise one
+// indicator per level after the first.
+func (e *Experiment[P]) regressionColumns(j, l int) []float64 {
+	factor := e.ControlFactors[j]
+	if e.design == designLatinHypercube {
+		return []float64{factor.Levels[l]}
+	}
+	columns := make([]float64, len(factor.Levels)-1)
+	if l > 0 {
+		columns[l-1] = 1
+	}
+	return columns
+}
+
+// analyzeRegression fits the row SNRs of the runs that have results by least squares on
+// the factors' model columns. MainEffects hold the fitted SNR at each level with the
+// other factors at their mean; a factor's SS is the increase of the residual SS when
+// its columns are left out of the fit, and the residual SS of the full fit is the error.
+func (e *Experiment[P]) analyzeRegression() AnalysisResult {
+	oaSNR, _ := e.computeOASNR()
+	xs, ys, factorColumns := e.regressionData(oaSNR)
+	active := make([]bool, len(e.ControlFactors))
+	for j := range active {
+		active[j] = true
+	}
+	result := e.fitFactors(oaSNR, xs, ys, factorColumns, active)
+	result.Method = MethodRegression
+	return e.runPasses(result, oaSNR)
+}
+
+// regressionData returns the model rows and SNRs of the array rows that have results,
+// and the model columns of each control factor.
+func (e *Experiment[P]) regressionData(oaSNR []float64) (xs [][]float64, ys []float64, factorColumns [][]int) {
+	oa := e.array()
+	rowResults := e.rowResults()
+
+	factorColumns = make([][]int, len(e.ControlFactors))
+	width := 0
+	for j := range e.ControlFactors {
+		for range e.regressionColumns(j, 0) {
+			factorColumns[j] = append(factorColumns[j], width)
+			width++
+		}
+	}
+	for i := 0; i < oa.Rows(); i++ {
+		if len(rowResults[i]) == 0 {
+			continue
+		}
+		x := make([]float64, 0, width)
+		for j := range e.ControlFactors {
+			x = append(x, e.regressionColumns(j, oa.Row(i)[j]-1)...)
+		}
+		xs = append(xs, x)
+		ys = append(ys, oaSNR[i])
+	}
+	return xs, ys, factorColumns
+}
+
+// fitFactors fits ys on the model columns of the active factors and builds the
+// analysis from the fit. Inactive factors get flat main effects and zero SS.
+func (e *Experiment[P]) fitFactors(oaSNR []float64, xs [][]float64, ys []float64, factorColumns [][]int, active []bool) AnalysisResult {
+	var fitted []int
+	for j, columns := range factorColumns {
+		if active[j] {
+			fitted = append(fitted, columns...)
+		}
+	}
+	width := modelWidth(factorColumns)
+	coef, means, errorSS := leastSquares(xs, ys, width, fitted)
+	yMean := 0.0
+	if len(ys) > 0 {
+		yMean = mean(ys)
+	}
+
+	k := len(e.ControlFactors)
+	anova := ANOVAResult{
+		FactorSS: make(map[string]float64, k),
+		FactorDF: make(map[string]int, k),
+		FactorMS: make(map[string]float64, k),
+		FactorF:  make(map[string]float64, k),
+	}
+	anova.ErrorSS = errorSS
+	anova.ErrorDF = max(len(xs)-1-len(fitted), 1)
+	anova.ErrorMS = errorSS / float64(anova.ErrorDF)
+
+	mainEffects := make(map[string][]float64, k)
+	snrPerFactor := make(map[string][]float64, k)
+	for j, factor := range e.ControlFactors {
+		ss := 0.0
+		if active[j] {
+			rest := slices.DeleteFunc(slices.Clone(fitted), func(c int) bool { return slices.Contains(factorColumns[j], c) })
+			_, _, reducedSS := leastSquares(xs, ys, width, rest)
+			ss = math.Max(reducedSS-errorSS, 0)
+		}
+		df := len(factorColumns[j])
+		anova.FactorSS[factor.Name] = ss
+		anova.FactorDF[factor.Name] = df
+		anova.FactorMS[factor.Name] = ss / float64(df)
+		anova.FactorF[factor.Name] = anova.FactorMS[factor.Name] / anova.ErrorMS
+
+		effects := make([]float64, len(factor.Levels))
+		for l := range factor.Levels {
+			effects[l] = yMean
+			for i, v := range e.regressionColumns(j, l) {
+				c := factorColumns[j][i]
+				effects[l] += coef[c] * (v - means[c])
+			}
+		}
+		mainEffects[factor.Name] = effects
+		snrPerFactor[factor.Name] = e.levelMeanSNR(j, oaSNR)
+	}
+
+	return AnalysisResult{
+		OptimalLevels: e.findOptimalLevels(mainEffects),
+		SNR:           snrPerFactor,
+		MainEffects:   mainEffects,
+		Contributions: computeContributions(anova),
+		ANOVA:         anova,
+	}
+}
+
+// levelMeanSNR returns the mean row SNR at each level of control factor j, as the SNR
+// field holds for orthogonal arrays.
+func (e *Experiment[P]) levelMeanSNR(j int, oaSNR []float64) []float64 {
+	oa := e.array()
+	levelSNR := make([]float64, len(e.ControlFactors[j].Levels))
+	counts := make([]int, len(levelSNR))
+	for i := 0; i < oa.Rows(); i++ {
+		l := oa.Row(i)[j] - 1
+		levelSNR[l] += oaSNR[i]
+		counts[l]++
+	}
+	for l, n := range counts {
+		if n > 0 {
+			levelSNR[l] /= float64(n)
+		}
+	}
+	return levelSNR
+}
+
+// modelWidth returns the number of model columns split into the given groups.
+func modelWidth(groups [][]int) int {
+	width := 0
+	for _, columns := range groups {
+		width += len(columns)
+	}
+	return width
+}
+
+// leastSquares fits y on the given columns of xs (and an intercept) by solving the
+// centered normal equations with Gaussian elimination and partial pivoting. width is
+// the number of model columns, so that the result is sized alike when xs is empty
+// because no run has results. It returns the slopes indexed by column, zero for columns
+// not fitted or collinear with earlier ones, the column means and the residual sum of
+// squares.
+func leastSquares(xs [][]float64, ys []float64, width int, columns []int) (coef, means []float64, rss float64) {
+	coef = make([]float64, width)
+	means = make([]float64, width)
+	if len(xs) == 0 {
+		return coef, means, 0
+	}
+	for _, x := range xs {
+		for c, v := range x {
+			means[c] += v / float64(len(xs))
+		}
+	}
+	yMean := mean(ys)
+
+	// a is the augmented matrix [X'X | X'y] of the centered data.
+	k := len(columns)
+	a := make([][]float64, k)
+	for p, cp := range columns {
+		a[p] = make([]float64, k+1)
+		for r, x := range xs {
+			dp := x[cp] - means[cp]
+			for q, cq := range columns {
+				a[p][q] += dp * (x[cq] - means[cq])
+			}
+			a[p][k] += dp * (ys[r] - yMean)
+		}
+	}
+
+	// Pivots below tol, relative to the largest diagonal entry, count as zero.
+	tol := 0.0
+	for p := range a {
+		tol = math.Max(tol, a[p][p])
+	}
+	tol *= 1e-10
+
+	var pivots []int
+	row := 0
+	for col := 0; col < k && row < k; col++ {
+		best := row
+		for p := row + 1; p < k; p++ {
+			if math.Abs(a[p][col]) > math.Abs(a[best][col]) {
+				best = p
+			}
+		}
+		if math.Abs(a[best][col]) <= tol {
+			continue
+		}
+		a[row], a[best] = a[best], a[row]
+		for p := 0; p < k; p++ {
+			if p == row || a[p][col] == 0 {
+				continue
+			}
+			m := a[p][col] / a[row][col]
+			for q := col; q <= k; q++ {
+				a[p][q] -= m * a[row][q]
+			}
+		}
+		pivots = append(pivots, col)
+		row++
+	}
+	for r, col := range pivots {
+		coef[columns[col]] = a[r][k] / a[r][col]
+	}
+
+	for r, x := range xs {
+		fit := yMean
+		for c, b := range coef {
+			fit += b * (x[c] - means[c])
+		}
+		rss += (ys[r] - fit) * (ys[r] - fit)
+	}
+	return coef, means, rss
+}
@@ -0,0 +1,94 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LevelRules constrains the levels a control factor may take, catching typos such as a
+// 150% ratio before any runs are wasted. Rules are saved with the design and checked by
+// every constructor and by LoadExperiment.
+// Min: Inclusive lower bound, or nil for none.
+// Max: Inclusive upper bound, or nil for none.
+// Integer: Levels must be whole numbers.
+// PowerOfTwo: Levels must be powers of two (1, 2, 4, ...).
+type LevelRules struct {
+	Min        *float64 `json:",omitempty"`
+	Max        *float64 `json:",omitempty"`
+	Integer    bool     `json:",omitempty"`
+	PowerOfTwo bool     `json:",omitempty"`
+}
+
+// Range returns rules bounding levels to [min, max], e.g. Range(0, 1) for a ratio.
+func Range(min, max float64) *LevelRules {
+	return &LevelRules{Min: &min, Max: &max}
+}
+
+// Check returns an error describing the first rule that level violates.
+func (r LevelRules) Check(level float64) error {
+	switch {
+	case r.Min != nil && level < *r.Min:
+		return fmt.Errorf("level %v is below the minimum %v", level, *r.Min)
+	case r.Max != nil && level > *r.Max:
+		return fmt.Errorf("level %v is above the maximum %v", level, *r.Max)
+	case r.Integer && level != math.Trunc(level):
+		return fmt.Errorf("level %v is not an integer", level)
+	case r.PowerOfTwo && !isPowerOfTwo(level):
+		return fmt.Errorf("level %v is not a power of two", level)
+	}
+	return nil
+}
+
+// isPowerOfTwo reports whether x is 2^k for some integer k >= 0.
+func isPowerOfTwo(x float64) bool {
+	frac, _ := math.Frexp(x)
+	return x >= 1 && frac == 0.5
+}
+
+// checkLevelRules checks every level of every factor against the factor's rules.
+func checkLevelRules(factors []ControlFactor) error {
+	for _, factor := range factors {
+		if factor.Rules == nil {
+			continue
+		}
+		for _, level := range factor.Levels {
+			if err := factor.Rules.Check(level); err != nil {
+				return fmt.Errorf("factor %s: %w", factor.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// parseLevelRules parses a `taguchi:"..."` struct tag such as "min=0,max=1" or
+// "integer,pow2" into rules. An empty tag yields nil rules.
+func parseLevelRules(tag string) (*LevelRules, error) {
+	if tag == "" {
+		return nil, nil
+	}
+	rules := &LevelRules{}
+	for _, part := range strings.Split(tag, ",") {
+		key, value, hasValue := strings.Cut(strings.TrimSpace(part), "=")
+		switch {
+		case key == "integer" && !hasValue:
+			rules.Integer = true
+		case key == "pow2" && !hasValue:
+			rules.PowerOfTwo = true
+		case (key == "min" || key == "max") && hasValue:
+			v, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q", key, value)
+			}
+			if key == "min" {
+				rules.Min = &v
+			} else {
+				rules.Max = &v
+			}
+		default:
+			return nil, fmt.Errorf("unknown rule %q", part)
+		}
+	}
+	return rules, nil
+}
@@ -0,0 +1,452 @@
+package taguchi
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ScheduledTrial is a trial handed out by a Scheduler, tagged with the name of the
+// experiment it belongs to, the agent that claimed it (if any) and the resources it
+// holds while in flight. Under Run with guardrails set, Abort is closed when a
+// guardrail is breached; measurement functions should then stop and return.
+type ScheduledTrial struct {
+	Experiment string
+	Trial      Trial
+	Agent      string
+	Resources  []string
+	Abort      <-chan struct{} `json:"-"`
+}
+
+// inflightKey identifies a dispatched trial.
+type inflightKey struct {
+	experiment string
+	trialID    int
+}
+
+// Scheduler interleaves the trials of several experiments that share one measurement
+// resource (e.g. a single test rig). Experiments progress in proportion to their
+// priority: an experiment with priority 2 is dispatched twice as often as one with
+// priority 1, and no experiment is starved. It is safe for concurrent use.
+//
+// Trials can require named resources (a GPU, a specific machine). NextFor only hands
+// such trials to agents advertising every required resource, and each resource is
+// held exclusively until the trial is completed or abandoned.
+//
+// Agents that Claim trials are expected to send a Heartbeat periodically;
+// RecoverOrphans requeues trials held by agents that have stopped doing so.
+type Scheduler struct {
+	mu         sync.Mutex
+	entries    []*scheduleEntry
+	locked     map[string]bool
+	inflight   map[inflightKey]ScheduledTrial
+	heartbeats map[string]time.Time
+	now        func() time.Time
+	signingKey []byte
+	sampler    MetricSampler
+	interval   time.Duration
+	costFn     func(ScheduledTrial, time.Duration) float64
+	profileDir string
+	profileIf  func(ScheduledTrial) bool
+	windows    []RunWindow
+	cooldown   time.Duration
+	lastEnd    time.Time
+	sleep      func(time.Duration)
+
+	guardSampler  MetricSampler
+	guardInterval time.Duration
+	guards        []Guardrail
+}
+
+type scheduleEntry struct {
+	name       string
+	priority   int
+	runner     Runner
+	pending    []Trial
+	dispatched int
+	requires   func(Trial) []string
+}
+
+// NewScheduler creates an empty scheduler.
+func NewScheduler() *Scheduler {
+	return &Scheduler{
+		locked:     map[string]bool{},
+		inflight:   map[inflightKey]ScheduledTrial{},
+		heartbeats: map[string]time.Time{},
+		now:        time.Now,
+		sleep:      time.Sleep,
+	}
+}
+
+// RequireResources sets the function returning the resources each trial of the named
+// experiment needs. Trials requiring no resources may return nil.
+func (s *Scheduler) RequireResources(name string, requires func(Trial) []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.entry(name)
+	if e == nil {
+		return fmt.Errorf("experiment %s not scheduled", name)
+	}
+	e.requires = requires
+	return nil
+}
+
+// Add registers an experiment under a unique name. All of its trials are queued.
+// priority is the experiment's relative share of the resource and must be at least 1.
+func (s *Scheduler) Add(name string, exp Study, priority int) error {
+	if priority < 1 {
+		return fmt.Errorf("experiment %s: priority must be at least 1, got %d", name, priority)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entry(name) != nil {
+		return fmt.Errorf("experiment %s already scheduled", name)
+	}
+	s.entries = append(s.entries, &scheduleEntry{
+		name:     name,
+		priority: priority,
+		runner:   exp,
+		pending:  exp.GenerateTrials(),
+	})
+	return nil
+}
+
+// Next returns the next trial to run on a single local resource, ignoring resource
+// requirements, or false once every trial has been dispatched.
+// The experiment with the lowest dispatched/priority ratio goes next; ties go to the
+// experiment added first.
+func (s *Scheduler) Next() (ScheduledTrial, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next(nil, false)
+}
+
+// NextFor returns the next trial an agent advertising the given resources may run.
+// Only trials whose required resources are all advertised and currently unlocked are
+// considered; the returned trial holds those resources until Complete or Abandon.
+// It returns false if no eligible trial is available right now.
+func (s *Scheduler) NextFor(agentResources []string) (ScheduledTrial, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.next(agentResources, true)
+}
+
+// Claim is NextFor on behalf of a named agent. The claim also counts as a heartbeat,
+// and the trial is reassigned by RecoverOrphans if the agent stops sending heartbeats.
+func (s *Scheduler) Claim(agentID string, agentResources []string) (ScheduledTrial, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heartbeats[agentID] = s.now()
+	st, ok := s.next(agentResources, true)
+	if !ok {
+		return st, false
+	}
+	st.Agent = agentID
+	s.inflight[inflightKey{st.Experiment, st.Trial.ID}] = st
+	return st, true
+}
+
+// Heartbeat records that the agent is still alive.
+func (s *Scheduler) Heartbeat(agentID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heartbeats[agentID] = s.now()
+}
+
+// RecoverOrphans requeues every trial claimed by an agent whose last heartbeat is
+// older than timeout, releasing its resources, and returns the requeued trials.
+// Observations later submitted for a recovered trial by the dead agent are rejected.
+func (s *Scheduler) RecoverOrphans(timeout time.Duration) []ScheduledTrial {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cutoff := s.now().Add(-timeout)
+	var recovered []ScheduledTrial
+	for key, st := range s.inflight {
+		if st.Agent == "" || !s.heartbeats[st.Agent].Before(cutoff) {
+			continue
+		}
+		delete(s.inflight, key)
+		s.requeue(st)
+		recovered = append(recovered, st)
+	}
+	return recovered
+}
+
+// next picks the fairest experiment with an eligible trial. When affinity is set,
+// trials are matched against the agent's resources and resource locks.
+func (s *Scheduler) next(agentResources []string, affinity bool) (ScheduledTrial, bool) {
+	advertised := make(map[string]bool, len(agentResources))
+	for _, r := range agentResources {
+		advertised[r] = true
+	}
+
+	var best *scheduleEntry
+	bestIdx := -1
+	var bestRes []string
+	for _, e := range s.entries {
+		// Compare dispatched/priority ratios without division.
+		if best != nil && e.dispatched*best.priority >= best.dispatched*e.priority {
+			continue
+		}
+		for i, trial := range e.pending {
+			var required []string
+			if affinity && e.requires != nil {
+				required = e.requires(trial)
+				if !s.available(required, advertised) {
+					continue
+				}
+			}
+			best, bestIdx, bestRes = e, i, required
+			break
+		}
+	}
+	if best == nil {
+		return ScheduledTrial{}, false
+	}
+
+	trial := best.pending[bestIdx]
+	best.pending = append(best.pending[:bestIdx], best.pending[bestIdx+1:]...)
+	best.dispatched++
+	for _, r := range bestRes {
+		s.locked[r] = true
+	}
+	st := ScheduledTrial{Experiment: best.name, Trial: trial, Resources: bestRes}
+	s.inflight[inflightKey{st.Experiment, st.Trial.ID}] = st
+	return st, true
+}
+
+// available reports whether every required resource is advertised and unlocked.
+func (s *Scheduler) available(required []string, advertised map[string]bool) bool {
+	for _, r := range required {
+		if !advertised[r] || s.locked[r] {
+			return false
+		}
+	}
+	return true
+}
+
+// Abandon returns a dispatched trial to the front of its experiment's queue and
+// releases the resources it was dispatched with, e.g. after an agent failure. Trials
+// that are not in flight, because they were already completed, abandoned or recovered,
+// are rejected.
+func (s *Scheduler) Abandon(st ScheduledTrial) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.entry(st.Experiment) == nil {
+		return fmt.Errorf("experiment %s not scheduled", st.Experiment)
+	}
+	key := inflightKey{st.Experiment, st.Trial.ID}
+	dispatched, ok := s.inflight[key]
+	if !ok {
+		return fmt.Errorf("experiment %s trial %d is not in flight", st.Experiment, st.Trial.ID)
+	}
+	delete(s.inflight, key)
+	s.requeue(dispatched)
+	return nil
+}
+
+// requeue puts a dispatched trial back at the front of its experiment's queue and
+// releases its resources.
+func (s *Scheduler) requeue(st ScheduledTrial) {
+	e := s.entry(st.Experiment)
+	e.pending = append([]Trial{st.Trial}, e.pending...)
+	e.dispatched--
+	s.release(st.Resources)
+}
+
+func (s *Scheduler) release(resources []string) {
+	for _, r := range resources {
+		delete(s.locked, r)
+	}
+}
+
+func (s *Scheduler) entry(name string) *scheduleEntry {
+	for _, e := range s.entries {
+		if e.name == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// Complete records the observations of a dispatched trial in its experiment and
+// releases the resources it held. Trials that are not in flight, because they were
+// already completed or abandoned, are rejected, as are claimed trials that were
+// recovered from their agent in the meantime and all unsigned submissions once a
+// signing key is set.
+func (s *Scheduler) Complete(st ScheduledTrial, observations []float64) error {
+	s.mu.Lock()
+	signed := s.signingKey != nil
+	s.mu.Unlock()
+	if signed {
+		return fmt.Errorf("experiment %s trial %d: scheduler requires signed results", st.Experiment, st.Trial.ID)
+	}
+	return s.complete(st, TrialResult{Trial: st.Trial, Observations: observations})
+}
+
+// trialResultRunner is implemented by runners that can store result metadata such as
+// covariates and the environment fingerprint.
+type trialResultRunner interface {
+	AddTrialResult(result TrialResult)
+}
+
+func (s *Scheduler) complete(st ScheduledTrial, result TrialResult) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e := s.entry(st.Experiment)
+	if e == nil {
+		return fmt.Errorf("experiment %s not scheduled", st.Experiment)
+	}
+	key := inflightKey{st.Experiment, st.Trial.ID}
+	dispatched, ok := s.inflight[key]
+	if !ok || dispatched.Agent != st.Agent {
+		if st.Agent != "" {
+			return fmt.Errorf("experiment %s trial %d: claim by agent %s has expired", st.Experiment, st.Trial.ID, st.Agent)
+		}
+		return fmt.Errorf("experiment %s trial %d is not in flight", st.Experiment, st.Trial.ID)
+	}
+	delete(s.inflight, key)
+	// The trial and resources are those dispatched, whatever the submission lists.
+	result.Trial = dispatched.Trial
+	if r, ok := e.runner.(trialResultRunner); ok {
+		r.AddTrialResult(result)
+	} else {
+		e.runner.AddResult(result.Trial, result.Observations)
+	}
+	s.release(dispatched.Resources)
+	return nil
+}
+
+// Remaining returns the number of trials not yet dispatched, per experiment.
+func (s *Scheduler) Remaining() map[string]int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	remaining := make(map[string]int, len(s.entries))
+	for _, e := range s.entries {
+		remaining[e.name] = len(e.pending)
+	}
+	return remaining
+}
+
+// SetMetricSampler makes Run sample m every interval while each trial is measured and
+// record the mean of each metric as the result's covariates. A nil m disables sampling.
+func (s *Scheduler) SetMetricSampler(m MetricSampler, interval time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sampler = m
+	s.interval = interval
+}
+
+// SetCostFunc makes Run record cost(st, elapsed) as each result's Cost, where elapsed
+// is the wall-clock time spent measuring the trial. The callback converts time, money
+// or energy into a single cost unit. A nil cost disables cost accounting.
+func (s *Scheduler) SetCostFunc(cost func(st ScheduledTrial, elapsed time.Duration) float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.costFn = cost
+}
+
+// SetProfileDir makes Run capture a CPU profile of each trial for which profileIf
+// returns true (every trial if profileIf is nil) into dir, named
+// <experiment>-trial-<id>.pprof, and record its path in TrialResult.ProfilePath.
+// Profile only the trials you need, e.g. those running candidate optimal levels, to
+// explain why a configuration wins. An empty dir disables profiling.
+func (s *Scheduler) SetProfileDir(dir string, profileIf func(ScheduledTrial) bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.profileDir = dir
+	s.profileIf = profileIf
+}
+
+// Run dispatches every queued trial in turn to measure and records its observations,
+// together with a fingerprint of the environment (see CaptureEnvironment).
+// Measurements are taken locally, so no result signature is required.
+// Each trial starts once its cooldown has passed and a run window is open (see
+// SetCooldown and SetRunWindows). It stops at the first measurement error, except for
+// trials aborted by a guardrail (see SetGuardrails).
+func (s *Scheduler) Run(measure func(ScheduledTrial) ([]float64, error)) error {
+	for {
+		if err := s.checkGuardedRunners(); err != nil {
+			return err
+		}
+		s.waitForSlot()
+		st, ok := s.Next()
+		if !ok {
+			return nil
+		}
+		result, err := s.runTrial(st, measure)
+		s.mu.Lock()
+		s.lastEnd = s.now()
+		s.mu.Unlock()
+		if err != nil {
+			return fmt.Errorf("experiment %s trial %d: %w", st.Experiment, st.Trial.ID, err)
+		}
+		if err := s.complete(st, result); err != nil {
+			return err
+		}
+		if result.Unsafe != "" {
+			s.skipUnsafe(st, result.Unsafe)
+		}
+	}
+}
+
+// runTrial measures one trial and collects its metadata: environment fingerprint,
+// sampled covariates, CPU profile, cost and guardrail breach, as configured on the
+// scheduler.
+func (s *Scheduler) runTrial(st ScheduledTrial, measure func(ScheduledTrial) ([]float64, error)) (result TrialResult, err error) {
+	s.mu.Lock()
+	sampler, interval, costFn := s.sampler, s.interval, s.costFn
+	profileDir, profileIf := s.profileDir, s.profileIf
+	guardSampler, guardInterval, guards := s.guardSampler, s.guardInterval, s.guards
+	s.mu.Unlock()
+
+	if guardSampler != nil {
+		abort := make(chan struct{})
+		st.Abort = abort
+		unguarded := measure
+		measure = func(st ScheduledTrial) (observations []float64, err error) {
+			result.Unsafe, err = guardTrial(guardSampler, guardInterval, guards, abort, func() error {
+				observations, err = unguarded(st)
+				return err
+			})
+			return observations, err
+		}
+	}
+
+	env := CaptureEnvironment()
+	result = TrialResult{Trial: st.Trial, Environment: &env}
+	if profileDir != "" && (profileIf == nil || profileIf(st)) {
+		result.ProfilePath = filepath.Join(profileDir, fmt.Sprintf("%s-trial-%d.pprof", st.Experiment, st.Trial.ID))
+		stop, err := startCPUProfile(result.ProfilePath)
+		if err != nil {
+			return TrialResult{}, fmt.Errorf("start CPU profile: %w", err)
+		}
+		defer func() {
+			if stopErr := stop(); err == nil && stopErr != nil {
+				err = fmt.Errorf("write CPU profile: %w", stopErr)
+			}
+		}()
+	}
+
+	start := s.now()
+	if sampler != nil {
+		var measureErr error
+		result.Covariates, err = SampleDuring(sampler, interval, func() error {
+			result.Observations, measureErr = measure(st)
+			return measureErr
+		})
+		if err != nil && measureErr == nil {
+			return TrialResult{}, fmt.Errorf("sample metrics: %w", err)
+		}
+	} else {
+		result.Observations, err = measure(st)
+	}
+	if err != nil {
+		return TrialResult{}, err
+	}
+	if costFn != nil {
+		result.Cost = costFn(st, s.now().Sub(start))
+	}
+	return result, nil
+}
@@ -0,0 +1,569 @@
+package taguchi
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newSchedulerExperiment(t *testing.T) *Experiment[struct{}] {
+	t.Helper()
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	return exp
+}
+
+// TestScheduler_PriorityShare verifies that experiments are interleaved in
+// proportion to their priority.
+func TestScheduler_PriorityShare(t *testing.T) {
+	s := NewScheduler()
+	if err := s.Add("low", newSchedulerExperiment(t), 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.Add("high", newSchedulerExperiment(t), 2); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	var order []string
+	for i := 0; i < 6; i++ {
+		st, ok := s.Next()
+		if !ok {
+			t.Fatalf("Next: no trial at step %d", i)
+		}
+		order = append(order, st.Experiment)
+		if err := s.Complete(st, []float64{1}); err != nil {
+			t.Fatalf("Complete: %v", err)
+		}
+	}
+	want := []string{"low", "high", "high", "low", "high", "high"}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("dispatch order: got %v, want %v", order, want)
+		}
+	}
+}
+
+// TestScheduler_ResourceAffinity verifies that trials requiring a resource are only
+// handed to agents advertising it, and that the resource is held until completion.
+func TestScheduler_ResourceAffinity(t *testing.T) {
+	s := NewScheduler()
+	if err := s.Add("gpu", newSchedulerExperiment(t), 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.RequireResources("gpu", func(Trial) []string { return []string{"gpu0"} }); err != nil {
+		t.Fatalf("RequireResources: %v", err)
+	}
+
+	if _, ok := s.NextFor([]string{"cpu"}); ok {
+		t.Fatal("NextFor: dispatched a gpu trial to an agent without gpu0")
+	}
+	st, ok := s.NextFor([]string{"gpu0"})
+	if !ok {
+		t.Fatal("NextFor: expected a trial for an agent with gpu0")
+	}
+	if _, ok := s.NextFor([]string{"gpu0"}); ok {
+		t.Fatal("NextFor: gpu0 handed out while still locked")
+	}
+	if err := s.Complete(st, []float64{1}); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if _, ok := s.NextFor([]string{"gpu0"}); !ok {
+		t.Fatal("NextFor: gpu0 not released after Complete")
+	}
+}
+
+// TestScheduler_Abandon verifies that an abandoned trial is requeued once with the
+// resources it was dispatched with released, and that trials no longer in flight
+// cannot be abandoned.
+func TestScheduler_Abandon(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewScheduler()
+	s.now = func() time.Time { return now }
+	if err := s.Add("exp", newSchedulerExperiment(t), 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.RequireResources("exp", func(t Trial) []string { return []string{fmt.Sprintf("rig%v", t.Control["A"])} }); err != nil {
+		t.Fatalf("RequireResources: %v", err)
+	}
+	rigs := []string{"rig1", "rig2"}
+
+	st, _ := s.NextFor(rigs)
+	other, _ := s.NextFor(rigs)
+	forged := st
+	forged.Resources = other.Resources
+	if err := s.Abandon(forged); err != nil {
+		t.Fatalf("Abandon: %v", err)
+	}
+	if again, ok := s.NextFor([]string{"rig1"}); !ok || again.Trial.ID != st.Trial.ID {
+		t.Fatalf("NextFor: got trial %d, want abandoned trial %d", again.Trial.ID, st.Trial.ID)
+	}
+	if _, ok := s.NextFor([]string{"rig2"}); ok {
+		t.Error("NextFor: Abandon released resources the trial was not dispatched with")
+	}
+	if err := s.Abandon(st); err != nil {
+		t.Fatalf("Abandon: %v", err)
+	}
+	if err := s.Abandon(st); err == nil {
+		t.Error("Abandon: expected error for a trial abandoned twice")
+	}
+
+	if err := s.Complete(other, []float64{1}); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if err := s.Abandon(other); err == nil {
+		t.Error("Abandon: expected error for a completed trial")
+	}
+
+	claimed, _ := s.Claim("agent", rigs)
+	now = now.Add(time.Minute)
+	if recovered := s.RecoverOrphans(time.Second); len(recovered) != 1 {
+		t.Fatalf("RecoverOrphans: got %d trials, want 1", len(recovered))
+	}
+	if err := s.Abandon(claimed); err == nil {
+		t.Error("Abandon: expected error for a recovered trial")
+	}
+	if got := s.Remaining()["exp"]; got != 3 {
+		t.Errorf("Remaining: got %d, want 3", got)
+	}
+}
+
+// TestScheduler_RecoverOrphans verifies that trials claimed by an agent that stops
+// sending heartbeats are requeued and its late results rejected.
+func TestScheduler_RecoverOrphans(t *testing.T) {
+	now := time.Unix(0, 0)
+	s := NewScheduler()
+	s.now = func() time.Time { return now }
+	if err := s.Add("exp", newSchedulerExperiment(t), 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	dead, ok := s.Claim("agent-1", nil)
+	if !ok {
+		t.Fatal("Claim: expected a trial")
+	}
+	if _, ok := s.Claim("agent-2", nil); !ok {
+		t.Fatal("Claim: expected a trial")
+	}
+
+	now = now.Add(time.Minute)
+	s.Heartbeat("agent-2")
+	recovered := s.RecoverOrphans(30 * time.Second)
+	if len(recovered) != 1 || recovered[0].Trial.ID != dead.Trial.ID {
+		t.Fatalf("RecoverOrphans: got %+v, want trial %d", recovered, dead.Trial.ID)
+	}
+	if err := s.Complete(dead, []float64{1}); err == nil {
+		t.Error("Complete: expected error for a recovered claim")
+	}
+
+	again, ok := s.Claim("agent-2", nil)
+	if !ok || again.Trial.ID != dead.Trial.ID {
+		t.Fatalf("Claim: got trial %d, want recovered trial %d", again.Trial.ID, dead.Trial.ID)
+	}
+	if err := s.Complete(again, []float64{1}); err != nil {
+		t.Errorf("Complete: %v", err)
+	}
+}
+
+// TestScheduler_SignedResults verifies that a scheduler with a signing key only
+// accepts results whose signature matches their contents.
+func TestScheduler_SignedResults(t *testing.T) {
+	key := []byte("secret")
+	s := NewScheduler()
+	s.SetSigningKey(key)
+	if err := s.Add("exp", newSchedulerExperiment(t), 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	st, _ := s.Claim("agent", nil)
+
+	if err := s.Complete(st, []float64{1}); err == nil {
+		t.Error("Complete: expected error for unsigned result")
+	}
+	tampered := SignResult(key, st, []float64{1})
+	tampered.Observations = []float64{2}
+	if err := s.CompleteSigned(tampered); err == nil {
+		t.Error("CompleteSigned: expected error for tampered result")
+	}
+	if err := s.CompleteSigned(SignResult(key, st, []float64{1})); err != nil {
+		t.Errorf("CompleteSigned: %v", err)
+	}
+	if err := s.CompleteSigned(SignResult(key, st, []float64{1})); err == nil {
+		t.Error("CompleteSigned: expected error for a replayed result")
+	}
+}
+
+// TestScheduler_SignedResources verifies that the signature covers the resources a
+// submission releases, and that unclaimed trials are accepted only once.
+func TestScheduler_SignedResources(t *testing.T) {
+	key := []byte("secret")
+	s := NewScheduler()
+	s.SetSigningKey(key)
+	exp := newSchedulerExperiment(t)
+	if err := s.Add("exp", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if err := s.RequireResources("exp", func(t Trial) []string { return []string{fmt.Sprintf("rig%v", t.Control["A"])} }); err != nil {
+		t.Fatalf("RequireResources: %v", err)
+	}
+	st, _ := s.NextFor([]string{"rig1", "rig2"})
+	other, _ := s.NextFor([]string{"rig1", "rig2"})
+
+	tampered := SignResult(key, st, []float64{1})
+	tampered.Trial.Resources = other.Resources
+	if err := s.CompleteSigned(tampered); err == nil {
+		t.Error("CompleteSigned: expected error for tampered resources")
+	}
+	if err := s.CompleteSigned(SignResult(key, st, []float64{1})); err != nil {
+		t.Fatalf("CompleteSigned: %v", err)
+	}
+	if err := s.CompleteSigned(SignResult(key, st, []float64{1})); err == nil {
+		t.Error("CompleteSigned: expected error for a replayed result")
+	}
+	if len(exp.Results) != 1 {
+		t.Errorf("results: got %d, want 1", len(exp.Results))
+	}
+	if _, ok := s.NextFor(other.Resources); ok {
+		t.Error("NextFor: resources of a trial still in flight were released")
+	}
+}
+
+type countingSampler struct{ n float64 }
+
+func (c *countingSampler) Sample() (map[string]float64, error) {
+	c.n++
+	return map[string]float64{"cpu_load": c.n}, nil
+}
+
+// TestScheduler_MetricCovariates verifies that Run records sampled metrics as covariates
+// and an environment fingerprint with each result.
+func TestScheduler_MetricCovariates(t *testing.T) {
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("exp", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	s.SetMetricSampler(&countingSampler{}, 0)
+	if err := s.Run(func(ScheduledTrial) ([]float64, error) { return []float64{1}, nil }); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	if len(exp.Results) != 4 {
+		t.Fatalf("results: got %d, want 4", len(exp.Results))
+	}
+	for i, r := range exp.Results {
+		if r.Environment == nil || r.Environment.GOMAXPROCS < 1 || r.Environment.OS == "" {
+			t.Errorf("result %d: got environment %+v, want a fingerprint", i, r.Environment)
+		}
+		// Without ticks, each trial is sampled once before and once after measuring.
+		if want := float64(2*i) + 1.5; r.Covariates["cpu_load"] != want {
+			t.Errorf("result %d cpu_load: got %v, want %v", i, r.Covariates["cpu_load"], want)
+		}
+	}
+}
+
+// TestScheduler_CostAccounting verifies that Run records per-trial costs and that the
+// analysis aggregates them per factor level.
+func TestScheduler_CostAccounting(t *testing.T) {
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("exp", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	// Level 2 of A costs three times as much to run as level 1.
+	s.SetCostFunc(func(st ScheduledTrial, _ time.Duration) float64 { return 1 + 2*(st.Trial.Control["A"]-1) })
+	err := s.Run(func(st ScheduledTrial) ([]float64, error) {
+		return []float64{10 * st.Trial.Control["A"]}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	result := exp.Analyze()
+	c := result.Cost
+	if c == nil {
+		t.Fatal("Cost: got nil, want a summary")
+	}
+	if c.Total != 8 || c.Trials != 4 {
+		t.Errorf("Total/Trials: got %v/%d, want 8/4", c.Total, c.Trials)
+	}
+	if got := c.PerLevel["A"]; got[0] != 1 || got[1] != 3 {
+		t.Errorf("PerLevel[A]: got %v, want [1 3]", got)
+	}
+	if c.ExpectedGain <= 0 || !almostEqual(c.CostPerDB, c.Total/c.ExpectedGain) {
+		t.Errorf("ExpectedGain/CostPerDB: got %v/%v", c.ExpectedGain, c.CostPerDB)
+	}
+}
+
+// TestScheduler_ProfileTrials verifies that Run captures CPU profiles for the selected
+// trials and records their paths.
+func TestScheduler_ProfileTrials(t *testing.T) {
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("exp", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	dir := t.TempDir()
+	s.SetProfileDir(dir, func(st ScheduledTrial) bool { return st.Trial.Control["A"] == 2 })
+	if err := s.Run(func(ScheduledTrial) ([]float64, error) { return []float64{1}, nil }); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	profiled := 0
+	for _, r := range exp.Results {
+		if r.Trial.Control["A"] != 2 {
+			if r.ProfilePath != "" {
+				t.Errorf("trial %d: unexpected profile %s", r.Trial.ID, r.ProfilePath)
+			}
+			continue
+		}
+		if _, err := os.Stat(r.ProfilePath); err != nil {
+			t.Errorf("trial %d: profile not written: %v", r.Trial.ID, err)
+		}
+		profiled++
+	}
+	if profiled != 2 {
+		t.Errorf("profiled trials: got %d, want 2", profiled)
+	}
+}
+
+// sourceFunc adapts a function to ObservationSource.
+type sourceFunc func(st ScheduledTrial, start, end time.Time) ([]float64, error)
+
+func (f sourceFunc) Observations(st ScheduledTrial, start, end time.Time) ([]float64, error) {
+	return f(st, start, end)
+}
+
+// TestFlagRunner verifies that trials are pushed as flags, measured over their window
+// and followed by a restore of the baseline flags.
+func TestFlagRunner(t *testing.T) {
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("flags", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	flags := &StaticFlags{}
+	clock := time.Unix(0, 0)
+	runner := &FlagRunner{
+		Provider: flags,
+		Source: sourceFunc(func(st ScheduledTrial, start, end time.Time) ([]float64, error) {
+			if end.Sub(start) != time.Minute {
+				return nil, fmt.Errorf("window: got %v, want 1m", end.Sub(start))
+			}
+			// The live system sees the trial's levels while it is measured.
+			a, b := flags.Value("A", 0.0).(float64), flags.Value("B", 0.0).(float64)
+			return []float64{10*a + b}, nil
+		}),
+		Baseline: map[string]any{"A": 1.0, "B": 1.0},
+		Settle:   10 * time.Second,
+		Window:   time.Minute,
+		sleep:    func(d time.Duration) { clock = clock.Add(d) },
+		now:      func() time.Time { return clock },
+	}
+	if err := s.Run(runner.Measure); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := exp.Analyze().OptimalLevels; got["A"] != 1 || got["B"] != 1 {
+		t.Errorf("OptimalLevels: got %v", got)
+	}
+	if flags.Value("A", nil) != 1.0 || clock != time.Unix(0, 0).Add(4*70*time.Second) {
+		t.Errorf("after Run: A = %v, clock = %v", flags.Value("A", nil), clock)
+	}
+}
+
+// TestScheduler_RunWindows verifies that Run starts trials only inside run windows and
+// after the cooldown.
+func TestScheduler_RunWindows(t *testing.T) {
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("exp", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	midnight := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	clock := midnight
+	s.now = func() time.Time { return clock }
+	s.sleep = func(d time.Duration) { clock = clock.Add(d) }
+	if err := s.SetRunWindows(RunWindow{Start: time.Hour, End: 3 * time.Hour}); err != nil {
+		t.Fatalf("SetRunWindows: %v", err)
+	}
+	s.SetCooldown(30 * time.Minute)
+
+	var starts []time.Duration
+	err := s.Run(func(st ScheduledTrial) ([]float64, error) {
+		starts = append(starts, clock.Sub(midnight))
+		clock = clock.Add(40 * time.Minute)
+		return []float64{st.Trial.Control["A"]}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	day := 24 * time.Hour
+	want := []time.Duration{
+		time.Hour, 2*time.Hour + 10*time.Minute,
+		day + time.Hour, day + 2*time.Hour + 10*time.Minute,
+	}
+	if !slices.Equal(starts, want) {
+		t.Errorf("trial starts: got %v, want %v", starts, want)
+	}
+
+	overnight := RunWindow{Start: 22 * time.Hour, End: 2 * time.Hour}
+	for at, want := range map[time.Duration]time.Duration{
+		23 * time.Hour: 23 * time.Hour,
+		time.Hour:      time.Hour,
+		3 * time.Hour:  22 * time.Hour,
+	} {
+		if got := overnight.nextOpen(midnight.Add(at)).Sub(midnight); got != want {
+			t.Errorf("overnight window at %v: opens at %v, want %v", at, got, want)
+		}
+	}
+	if err := s.SetRunWindows(RunWindow{Start: 25 * time.Hour}); err == nil {
+		t.Error("SetRunWindows accepted an offset beyond a day")
+	}
+}
+
+type samplerFunc func() (map[string]float64, error)
+
+func (f samplerFunc) Sample() (map[string]float64, error) { return f() }
+
+// TestScheduler_Guardrails verifies that a guardrail breach aborts the trial, skips the
+// rest of its configuration and keeps it out of the recommendation.
+func TestScheduler_Guardrails(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+	}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{1, 2}}}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	s := NewScheduler()
+	if err := s.Add("exp", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+
+	// A=1, B=1 is the fastest configuration, but fails a tenth of its requests.
+	var mu sync.Mutex
+	var live map[string]float64
+	s.SetGuardrails(samplerFunc(func() (map[string]float64, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if live["A"] == 1 && live["B"] == 1 {
+			return map[string]float64{"error_rate": 0.1}, nil
+		}
+		return map[string]float64{"error_rate": 0.001}, nil
+	}), time.Millisecond, Guardrail{Metric: "error_rate", Max: 0.01})
+
+	runs := 0
+	err = s.Run(func(st ScheduledTrial) ([]float64, error) {
+		runs++
+		mu.Lock()
+		live = st.Trial.Control
+		mu.Unlock()
+		a, b := st.Trial.Control["A"], st.Trial.Control["B"]
+		if a == 1 && b == 1 {
+			select {
+			case <-st.Abort:
+				return []float64{1}, fmt.Errorf("aborted")
+			case <-time.After(5 * time.Second):
+				t.Fatal("trial was not aborted")
+			}
+		}
+		return []float64{10*a*b + b}, nil
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if runs != 7 {
+		t.Errorf("ran %d trials, want 7: the unsafe configuration's second trial should be skipped", runs)
+	}
+	unsafe := 0
+	for _, r := range exp.Results {
+		if r.Unsafe != "" {
+			unsafe++
+		}
+	}
+	if len(exp.Results) != 8 || unsafe != 2 {
+		t.Errorf("got %d results with %d unsafe, want 8 with 2", len(exp.Results), unsafe)
+	}
+
+	result := exp.Analyze()
+	if len(result.Unsafe) != 1 {
+		t.Fatalf("unsafe configurations: got %v, want A=1, B=1", result.Unsafe)
+	}
+	if got := result.OptimalLevels; got["A"] != 2 || got["B"] != 1 {
+		t.Errorf("optimal levels: got %v, want the next best safe configuration A=2, B=1", got)
+	}
+}
+
+// plainStudy hides the AddTrialResult method of the experiment it wraps.
+type plainStudy struct{ Study }
+
+// TestScheduler_GuardrailsNeedTrialResults verifies that Run refuses guardrails for a
+// runner that would record unsafe trials as ordinary results.
+func TestScheduler_GuardrailsNeedTrialResults(t *testing.T) {
+	s := NewScheduler()
+	if err := s.Add("exp", plainStudy{newSchedulerExperiment(t)}, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	s.SetGuardrails(samplerFunc(func() (map[string]float64, error) { return nil, nil }), time.Millisecond, Guardrail{Metric: "error_rate", Max: 0.01})
+	runs := 0
+	err := s.Run(func(ScheduledTrial) ([]float64, error) {
+		runs++
+		return []float64{1}, nil
+	})
+	if err == nil || runs != 0 {
+		t.Errorf("Run: got error %v after %d trials, want an error before any trial", err, runs)
+	}
+}
+
+// TestCanaryMeasure verifies that canary observations are paired with the baseline, so
+// noise shared by both slices cancels out.
+func TestCanaryMeasure(t *testing.T) {
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("canary", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	// Traffic swings between trials dwarf the effect of the factors.
+	traffic := func(st ScheduledTrial) []float64 {
+		return []float64{1000 * float64(st.Trial.ID%3), 500, 2000}
+	}
+	measure := CanaryMeasure(func(st ScheduledTrial) ([]float64, error) {
+		obs := traffic(st)
+		for i := range obs {
+			obs[i] += 10*st.Trial.Control["A"] + st.Trial.Control["B"]
+		}
+		return obs, nil
+	}, func(st ScheduledTrial) ([]float64, error) {
+		return traffic(st), nil
+	})
+	if err := s.Run(measure); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	for _, r := range exp.Results {
+		want := 10*r.Trial.Control["A"] + r.Trial.Control["B"]
+		if !slices.Equal(r.Observations, []float64{want, want, want}) {
+			t.Errorf("trial %d: got %v, want the paired differences %v", r.Trial.ID, r.Observations, want)
+		}
+	}
+	if got := exp.Analyze().OptimalLevels; got["A"] != 1 || got["B"] != 1 {
+		t.Errorf("OptimalLevels: got %v", got)
+	}
+
+	short := CanaryMeasure(func(ScheduledTrial) ([]float64, error) {
+		return []float64{1, 2}, nil
+	}, func(ScheduledTrial) ([]float64, error) {
+		return []float64{1}, nil
+	})
+	if _, err := short(ScheduledTrial{}); err == nil {
+		t.Error("CanaryMeasure paired observations of different lengths")
+	}
+}
@@ -0,0 +1,190 @@
+package taguchi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// FormatVersion is the version of the serialized experiment format written by Save.
+// Version 1 is the first; there was no serialized format before it.
+const FormatVersion = 1
+
+// migrations upgrade a decoded document from version i to version i+1. A change to the
+// format bumps FormatVersion and registers the migration from the previous version.
+var migrations = map[int]func(doc map[string]json.RawMessage) error{}
+
+type savedGoal struct {
+	Type       string    `json:"type"`
+	Target     float64   `json:"target,omitempty"`
+	Percentile float64   `json:"percentile,omitempty"`
+	Signals    []float64 `json:"signals,omitempty"`
+}
+
+type savedExperiment struct {
+	Version         int             `json:"version"`
+	Goal            savedGoal       `json:"goal"`
+	ControlFactors  []ControlFactor `json:"controlFactors"`
+	NoiseFactors    []NoiseFactor   `json:"noiseFactors"`
+	OrthogonalArray [][]int         `json:"orthogonalArray"`
+	Results         []TrialResult   `json:"results"`
+	AdHocResults    []TrialResult   `json:"adHocResults,omitempty"`
+	Design          string          `json:"design,omitempty"`
+	GroupNoise      bool            `json:"groupNoise,omitempty"`
+	PoolIdle        bool            `json:"poolIdleColumns,omitempty"`
+	NoiseLimit      int             `json:"noiseLimit,omitempty"`
+	NoiseSeed       int64           `json:"noiseSeed,omitempty"`
+	Interactions    [][2]int        `json:"interactions,omitempty"`
+}
+
+// Save writes the experiment design and its recorded results to w as versioned JSON.
+// An array read from an ArraySource is written out in full.
+func (e *Experiment[P]) Save(w io.Writer) error {
+	goal, err := encodeGoal(e.Goal)
+	if err != nil {
+		return err
+	}
+	return json.NewEncoder(w).Encode(savedExperiment{
+		Version:         FormatVersion,
+		Goal:            goal,
+		ControlFactors:  e.ControlFactors,
+		NoiseFactors:    e.NoiseFactors,
+		OrthogonalArray: materializeArray(e.array()),
+		Results:         e.Results,
+		AdHocResults:    e.AdHocResults,
+		Design:          e.design,
+		GroupNoise:      e.groupNoise,
+		PoolIdle:        e.poolIdle,
+		NoiseLimit:      e.noiseLimit,
+		NoiseSeed:       e.noiseSeed,
+		Interactions:    e.interactions,
+	})
+}
+
+// LoadExperiment reads an experiment written by Save, migrating older format versions
+// to the current one. P is the params struct type used by Params.
+func LoadExperiment[P any](r io.Reader) (*Experiment[P], error) {
+	var doc map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("decode experiment: %w", err)
+	}
+
+	raw, ok := doc["version"]
+	if !ok {
+		return nil, fmt.Errorf("decode experiment: missing format version")
+	}
+	var version int
+	if err := json.Unmarshal(raw, &version); err != nil {
+		return nil, fmt.Errorf("decode version: %w", err)
+	}
+	if version > FormatVersion {
+		return nil, fmt.Errorf("experiment format version %d is newer than supported version %d", version, FormatVersion)
+	}
+	for ; version < FormatVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok {
+			return nil, fmt.Errorf("no migration from format version %d", version)
+		}
+		if err := migrate(doc); err != nil {
+			return nil, fmt.Errorf("migrate from version %d: %w", version, err)
+		}
+	}
+
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var saved savedExperiment
+	if err := json.Unmarshal(raw, &saved); err != nil {
+		return nil, fmt.Errorf("decode experiment: %w", err)
+	}
+	goal, err := decodeGoal(saved.Goal)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLevelRules(saved.ControlFactors); err != nil {
+		return nil, err
+	}
+	spec, err := NewArraySpec(saved.OrthogonalArray)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(saved.ControlFactors); err != nil {
+		return nil, err
+	}
+	return &Experiment[P]{
+		ControlFactors:  saved.ControlFactors,
+		NoiseFactors:    saved.NoiseFactors,
+		Goal:            goal,
+		OrthogonalArray: saved.OrthogonalArray,
+		Results:         saved.Results,
+		AdHocResults:    saved.AdHocResults,
+		design:          saved.Design,
+		groupNoise:      saved.GroupNoise,
+		poolIdle:        saved.PoolIdle,
+		noiseLimit:      saved.NoiseLimit,
+		noiseSeed:       saved.NoiseSeed,
+		interactions:    saved.Interactions,
+		controlAs:       buildControlAs[P](),
+	}, nil
+}
+
+// encodeGoal converts a built-in goal into its serialized form.
+func encodeGoal(g OptimizationGoal) (savedGoal, error) {
+	switch goal := g.(type) {
+	case SmallerTheBetter, *SmallerTheBetter, LargerTheBetter, *LargerTheBetter, NominalTheBestI, *NominalTheBestI, OperatingWindow, FractionDefective, PairedDifference:
+		return savedGoal{Type: goal.String()}, nil
+	case NominalTheBest:
+		return savedGoal{Type: goal.String(), Target: goal.Target}, nil
+	case *NominalTheBest:
+		return savedGoal{Type: goal.String(), Target: goal.Target}, nil
+	case DynamicCharacteristic:
+		return savedGoal{Type: goal.String(), Signals: goal.Signals}, nil
+	case PercentileGoal:
+		if _, nested := goal.Goal.(PercentileGoal); nested {
+			return savedGoal{}, fmt.Errorf("optimization goal %s cannot be serialized", g.String())
+		}
+		saved, err := encodeGoal(goal.Goal)
+		if err != nil {
+			return savedGoal{}, err
+		}
+		saved.Percentile = goal.Percentile
+		return saved, nil
+	case nil:
+		return savedGoal{}, fmt.Errorf("experiment has no optimization goal")
+	}
+	return savedGoal{}, fmt.Errorf("optimization goal %s cannot be serialized", g.String())
+}
+
+// decodeGoal converts a serialized goal back into a built-in goal, wrapped in a
+// PercentileGoal if it has a percentile, which must lie in (0, 100].
+func decodeGoal(g savedGoal) (OptimizationGoal, error) {
+	if g.Percentile != 0 {
+		if !(g.Percentile > 0 && g.Percentile <= 100) {
+			return nil, fmt.Errorf("optimization goal %s: percentile %v is outside (0, 100]", g.Type, g.Percentile)
+		}
+		goal, err := decodeGoal(savedGoal{Type: g.Type, Target: g.Target, Signals: g.Signals})
+		return PercentileGoal{Goal: goal, Percentile: g.Percentile}, err
+	}
+	switch g.Type {
+	case SmallerTheBetter{}.String():
+		return SmallerTheBetter{}, nil
+	case LargerTheBetter{}.String():
+		return LargerTheBetter{}, nil
+	case NominalTheBest{}.String():
+		return NominalTheBest{Target: g.Target}, nil
+	case NominalTheBestI{}.String():
+		return NominalTheBestI{}, nil
+	case OperatingWindow{}.String():
+		return OperatingWindow{}, nil
+	case FractionDefective{}.String():
+		return FractionDefective{}, nil
+	case PairedDifference{}.String():
+		return PairedDifference{}, nil
+	case PairedDifference{Larger: true}.String():
+		return PairedDifference{Larger: true}, nil
+	case DynamicCharacteristic{}.String():
+		return DynamicCharacteristic{Signals: g.Signals}, nil
+	}
+	return nil, fmt.Errorf("unknown optimization goal %q", g.Type)
+}
@@ -0,0 +1,356 @@
+package taguchi
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"io"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSaveLoad_RoundTrip verifies that a saved experiment reloads with the same
+// design and results, and analyzes identically.
+func TestSaveLoad_RoundTrip(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+	}
+	oa := [][]int{{1}, {2}}
+	noise := []NoiseFactor{
+		{Name: "N", Levels: []float64{0, 1}},
+	}
+
+	exp, err := NewExperimentFromFactorsUsingArray(NominalTheBest{Target: 5}, factors, oa, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+	trials := exp.GenerateTrials()
+	exp.AddResult(trials[0], []float64{3, 4})
+	exp.AddResult(trials[1], []float64{6, 7})
+	exp.AddResult(trials[2], []float64{5, 6})
+	exp.AddResult(trials[3], []float64{4, 5})
+
+	var buf bytes.Buffer
+	if err := exp.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadExperiment[struct{}](&buf)
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+
+	if d := DiffDesigns(exp, loaded); !d.Empty() {
+		t.Errorf("loaded design differs: %+v", d)
+	}
+	if len(loaded.Results) != len(exp.Results) {
+		t.Fatalf("results: got %d, want %d", len(loaded.Results), len(exp.Results))
+	}
+	want := exp.Analyze().MainEffects["A"]
+	got := loaded.Analyze().MainEffects["A"]
+	for i := range want {
+		if !almostEqual(got[i], want[i]) {
+			t.Errorf("MainEffects[A][%d]: got %.4f, want %.4f", i, got[i], want[i])
+		}
+	}
+}
+
+// TestSaveLoad_KeepsTrials verifies that the noise combination limit and the assigned
+// interactions survive a round trip, so that the loaded experiment generates the same
+// trials.
+func TestSaveLoad_KeepsTrials(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "A", Levels: []float64{1, 2}},
+		{Name: "B", Levels: []float64{1, 2}},
+		{Name: "C", Levels: []float64{1, 2}},
+		{Name: "D", Levels: []float64{1, 2}},
+	}
+	noise := []NoiseFactor{
+		{Name: "N1", Levels: []float64{1, 2, 3}},
+		{Name: "N2", Levels: []float64{1, 2, 3}},
+	}
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, factors, L8, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	exp.LimitNoiseCombinations(4, 7)
+	if _, err := exp.AssignInteraction("A", "B"); err != nil {
+		t.Fatalf("AssignInteraction: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := exp.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := LoadExperiment[struct{}](&buf)
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	if got, want := loaded.GenerateTrials(), exp.GenerateTrials(); !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded experiment generates different trials:\ngot  %v\nwant %v", got, want)
+	}
+	// Further interactions are placed around the column reserved for A x B in both.
+	for _, pair := range [][2]string{{"A", "C"}, {"B", "C"}} {
+		want, err := exp.AssignInteraction(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("AssignInteraction(%s, %s): %v", pair[0], pair[1], err)
+		}
+		got, err := loaded.AssignInteraction(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("AssignInteraction(%s, %s) on the loaded experiment: %v", pair[0], pair[1], err)
+		}
+		if !reflect.DeepEqual(got, want) || !reflect.DeepEqual(loaded.OrthogonalArray, exp.OrthogonalArray) {
+			t.Errorf("%s x %s on the loaded experiment: got columns %v, want %v", pair[0], pair[1], got, want)
+		}
+	}
+}
+
+// TestLoadExperiment_OmittedOptionalFields verifies that a document without a noise
+// combination limit or interactions loads with every noise combination and re-saves at
+// the current version.
+func TestLoadExperiment_OmittedOptionalFields(t *testing.T) {
+	doc := `{"version": 1, "goal": {"type": "Smaller-the-Better"},
+		"controlFactors": [{"Name": "A", "Levels": [1, 2]}],
+		"noiseFactors": [{"Name": "N", "Levels": [1, 2]}],
+		"orthogonalArray": [[1], [2]]}`
+	exp, err := LoadExperiment[struct{}](strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	if n := len(exp.GenerateTrials()); n != 4 {
+		t.Errorf("trials: got %d, want every noise combination of both rows", n)
+	}
+	var buf bytes.Buffer
+	if err := exp.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	var saved struct{ Version int }
+	if err := json.Unmarshal(buf.Bytes(), &saved); err != nil || saved.Version != FormatVersion {
+		t.Errorf("re-saved version: got %d (%v), want %d", saved.Version, err, FormatVersion)
+	}
+}
+
+// TestLoadExperiment_RejectsBadPercentile verifies that percentile goals outside
+// (0, 100] are rejected.
+func TestLoadExperiment_RejectsBadPercentile(t *testing.T) {
+	for _, p := range []string{"-5", "150"} {
+		doc := `{"version": 1, "goal": {"type": "Smaller-the-Better", "percentile": ` + p + `},
+			"controlFactors": [{"Name": "A", "Levels": [1, 2]}], "orthogonalArray": [[1], [2]]}`
+		if _, err := LoadExperiment[struct{}](strings.NewReader(doc)); err == nil {
+			t.Errorf("percentile %s: expected an error", p)
+		}
+	}
+	doc := `{"version": 1, "goal": {"type": "Smaller-the-Better", "percentile": 100},
+		"controlFactors": [{"Name": "A", "Levels": [1, 2]}], "orthogonalArray": [[1], [2]]}`
+	if _, err := LoadExperiment[struct{}](strings.NewReader(doc)); err != nil {
+		t.Errorf("percentile 100: %v", err)
+	}
+}
+
+// TestLoadExperiment_RejectsUnversioned verifies that documents without a format
+// version, such as a plain json.Marshal of an Experiment, are rejected rather than
+// misread.
+func TestLoadExperiment_RejectsUnversioned(t *testing.T) {
+	exp, err := NewExperimentFromFactors(SmallerTheBetter{}, []ControlFactor{{Name: "A", Levels: []float64{1, 2}}}, L4, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+	raw, err := json.Marshal(exp)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if _, err := LoadExperiment[struct{}](bytes.NewReader(raw)); err == nil {
+		t.Error("expected error for a document without a format version")
+	}
+}
+
+// TestLoadExperiment_RejectsNewerVersion verifies that documents from a newer
+// format version are not silently misread.
+func TestLoadExperiment_RejectsNewerVersion(t *testing.T) {
+	doc := `{"version": 99, "goal": {"type": "Smaller-the-Better"}}`
+	if _, err := LoadExperiment[struct{}](strings.NewReader(doc)); err == nil {
+		t.Error("expected error for newer format version")
+	}
+}
+
+// TestLevelRules verifies that level rules reject bad levels at construction, on
+// relabelling, and when loading a saved design.
+func TestLevelRules(t *testing.T) {
+	type factors struct {
+		Ratio   []float64 `taguchi:"min=0,max=1"`
+		Buffer  []int     `taguchi:"integer,pow2"`
+		Workers []float64 `taguchi:"min=1,integer"`
+	}
+	good := factors{Ratio: []float64{0.5, 0.9}, Buffer: []int{64, 256}, Workers: []float64{1, 8}}
+	if _, err := NewExperiment[factors, struct{}](SmallerTheBetter{}, good, L4, nil); err != nil {
+		t.Fatalf("valid levels: %v", err)
+	}
+	for name, f := range map[string]factors{
+		"above max":    {Ratio: []float64{0.5, 1.5}, Buffer: good.Buffer, Workers: good.Workers},
+		"power of two": {Ratio: good.Ratio, Buffer: []int{64, 100}, Workers: good.Workers},
+		"integer":      {Ratio: good.Ratio, Buffer: good.Buffer, Workers: []float64{1, 2.5}},
+		"below min":    {Ratio: good.Ratio, Buffer: good.Buffer, Workers: []float64{0, 8}},
+	} {
+		if _, err := NewExperiment[factors, struct{}](SmallerTheBetter{}, f, L4, nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	type badTag struct {
+		A []float64 `taguchi:"maximum=3"`
+	}
+	if _, err := NewExperiment[badTag, struct{}](SmallerTheBetter{}, badTag{A: []float64{1, 2}}, L4, nil); err == nil {
+		t.Error("expected an error for an unknown rule")
+	}
+
+	ratio := ControlFactor{Name: "Ratio", Levels: []float64{0.25, 0.75}, Rules: Range(0, 1)}
+	exp, err := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, []ControlFactor{ratio}, [][]int{{1}, {2}}, nil)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactorsUsingArray: %v", err)
+	}
+	if err := exp.RelabelLevel("Ratio", 0.75, 75); err == nil {
+		t.Error("expected RelabelLevel to reject a level above the maximum")
+	}
+
+	var buf bytes.Buffer
+	if err := exp.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if _, err := LoadExperiment[struct{}](bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("LoadExperiment: %v", err)
+	}
+	tampered := strings.Replace(buf.String(), "0.75", "75", 1)
+	if _, err := LoadExperiment[struct{}](strings.NewReader(tampered)); err == nil {
+		t.Error("expected LoadExperiment to reject a level above the maximum")
+	}
+}
+
+// TestExportAsGo verifies that the exported Go source parses and reconstructs the design.
+func TestExportAsGo(t *testing.T) {
+	factors := []ControlFactor{
+		{Name: "Ratio", Levels: []float64{0.25, 0.75}, Rules: Range(0, 1)},
+		{Name: "Timeout", Levels: []float64{1e9, 2e9}, Duration: true},
+	}
+	noise := []NoiseFactor{{Name: "N", Levels: []float64{0, 1}}}
+	exp, err := NewExperimentFromFactors(NominalTheBest{Target: 5}, factors, L4, noise)
+	if err != nil {
+		t.Fatalf("NewExperimentFromFactors: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := ExportAsGo(&buf, exp); err != nil {
+		t.Fatalf("ExportAsGo: %v", err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "design.go", buf.Bytes(), 0); err != nil {
+		t.Fatalf("exported source does not parse: %v\n%s", err, buf.String())
+	}
+	for _, want := range []string{
+		"package design",
+		"taguchi.NominalTheBest{Target: 5}",
+		`{Name: "Ratio", Levels: []float64{0.25, 0.75}, Rules: &taguchi.LevelRules{Min: float64Ptr(0), Max: float64Ptr(1)}}`,
+		`{Name: "Timeout", Levels: []float64{1e+09, 2e+09}, Duration: true}`,
+		"{1, 2, 2},",
+		`{Name: "N", Levels: []float64{0, 1}}`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("exported source lacks %q:\n%s", want, buf.String())
+		}
+	}
+
+	exp.Goal = PercentileGoal{Goal: NominalTheBest{Target: 5}, Percentile: 99}
+	buf.Reset()
+	if err := ExportAsGo(&buf, exp); err != nil {
+		t.Fatalf("ExportAsGo: %v", err)
+	}
+	if want := "taguchi.PercentileGoal{Goal: taguchi.NominalTheBest{Target: 5}, Percentile: 99}"; !strings.Contains(buf.String(), want) {
+		t.Errorf("exported source lacks %q", want)
+	}
+
+	exp.Goal = PercentileGoal{Goal: DynamicCharacteristic{Signals: []float64{1, 2}}, Percentile: 99}
+	buf.Reset()
+	if err := ExportAsGo(&buf, exp); err != nil {
+		t.Fatalf("ExportAsGo: %v", err)
+	}
+	if want := "taguchi.PercentileGoal{Goal: taguchi.DynamicCharacteristic{Signals: []float64{1, 2}}, Percentile: 99}"; !strings.Contains(buf.String(), want) {
+		t.Errorf("exported source lacks %q", want)
+	}
+
+	exp.Goal = nil
+	if err := ExportAsGo(&buf, exp); err == nil {
+		t.Error("expected an error for an experiment without a goal")
+	}
+}
+
+// TestLoadTestParsers checks the k6, vegeta and wrk parsers on sample outputs.
+func TestLoadTestParsers(t *testing.T) {
+	k6 := `{"metrics": {
+		"http_req_duration": {"avg": 12.5, "min": 1, "med": 10, "max": 80, "p(90)": 20, "p(95)": 30, "thresholds": {"p(95)<500": false}},
+		"http_reqs": {"count": 1000, "rate": 99.5},
+		"http_req_failed": {"passes": 5, "fails": 995, "value": 0.005}
+	}}`
+	k6Nested := `{"metrics": {"http_req_duration": {"type": "trend", "values": {"avg": 12.5, "p(99.9)": 70}}}}`
+	vegeta := `{"latencies": {"total": 1000000000, "mean": 2000000, "50th": 1500000, "90th": 3000000, "95th": 4000000, "99th": 9000000, "max": 20000000, "min": 500000},
+		"requests": 500, "rate": 50.1, "throughput": 49.6, "success": 0.99, "status_codes": {"200": 495, "500": 5}, "errors": ["500 Internal Server Error"]}`
+	wrk := `Running 30s test @ http://127.0.0.1:8080/index.html
+  12 threads and 400 connections
+  Thread Stats   Avg      Stdev     Max   +/- Stdev
+    Latency   635.91us    0.89ms  12.92ms   93.69%
+    Req/Sec    56.20k     8.07k   62.00k    86.54%
+  Latency Distribution
+     50%  250.00us
+     75%  491.00us
+     90%  700.00us
+     99%    5.80ms
+  22464657 requests in 30.00s, 17.76GB read
+  Socket errors: connect 0, read 3, write 0, timeout 2
+  Non-2xx or 3xx responses: 12
+Requests/sec: 748868.53
+Transfer/sec:      2.48GB
+`
+	cases := []struct {
+		name   string
+		parse  func(io.Reader) (LoadTestResult, error)
+		input  string
+		metric string
+		want   float64
+	}{
+		{"k6", ParseK6Summary, k6, "p95", 30},
+		{"k6 mean", ParseK6Summary, k6, "mean", 12.5},
+		{"k6 errors", ParseK6Summary, k6, "error_rate", 0.005},
+		{"k6 handleSummary", ParseK6Summary, k6Nested, "p99.9", 70},
+		{"vegeta", ParseVegetaReport, vegeta, "p99", 9},
+		{"vegeta errors", ParseVegetaReport, vegeta, "errors", 5},
+		{"vegeta throughput", ParseVegetaReport, vegeta, "throughput", 49.6},
+		{"wrk", ParseWrkOutput, wrk, "p99", 5.8},
+		{"wrk max", ParseWrkOutput, wrk, "max", 12.92},
+		{"wrk errors", ParseWrkOutput, wrk, "errors", 17},
+		{"wrk throughput", ParseWrkOutput, wrk, "throughput", 748868.53},
+	}
+	for _, c := range cases {
+		result, err := c.parse(strings.NewReader(c.input))
+		if err != nil {
+			t.Errorf("%s: %v", c.name, err)
+			continue
+		}
+		if got, err := result.Metric(c.metric); err != nil || math.Abs(got-c.want) > 1e-9*math.Max(1, c.want) {
+			t.Errorf("%s: %s = %v (%v), want %v", c.name, c.metric, got, err, c.want)
+		}
+	}
+	if _, err := ParseWrkOutput(strings.NewReader("garbage")); err == nil {
+		t.Error("ParseWrkOutput accepted output without latencies")
+	}
+
+	factors := []ControlFactor{{Name: "A", Levels: []float64{1, 2}}}
+	exp, _ := NewExperimentFromFactorsUsingArray(SmallerTheBetter{}, factors, [][]int{{1}, {2}}, nil)
+	result, _ := ParseVegetaReport(strings.NewReader(vegeta))
+	if err := exp.AddLoadTestResult(exp.GenerateTrials()[0], "p99", result, result); err != nil {
+		t.Fatalf("AddLoadTestResult: %v", err)
+	}
+	if obs := exp.Results[0].Observations; len(obs) != 2 || obs[0] != 9 {
+		t.Errorf("observations: got %v, want [9 9]", obs)
+	}
+	if err := exp.AddLoadTestResult(exp.GenerateTrials()[1], "p99.99", result); err == nil {
+		t.Error("AddLoadTestResult accepted a missing metric")
+	}
+}
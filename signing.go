@@ -0,0 +1,96 @@
+package taguchi
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// SignedResult is a result submitted by an agent together with an HMAC-SHA256
+// signature over the experiment name, agent, trial, resources and observations.
+type SignedResult struct {
+	Trial        ScheduledTrial
+	Observations []float64
+	Signature    []byte
+}
+
+// SignResult signs a trial's observations with key. Agents call it before submitting
+// results to the coordinator.
+func SignResult(key []byte, st ScheduledTrial, observations []float64) SignedResult {
+	return SignedResult{
+		Trial:        st,
+		Observations: observations,
+		Signature:    resultMAC(key, st, observations),
+	}
+}
+
+// VerifyResult reports whether the signature of r was produced with key over its
+// current contents.
+func VerifyResult(key []byte, r SignedResult) bool {
+	return hmac.Equal(r.Signature, resultMAC(key, r.Trial, r.Observations))
+}
+
+// SetSigningKey makes the scheduler require signed submissions: once set, results
+// must be submitted through CompleteSigned and carry a valid signature under key.
+func (s *Scheduler) SetSigningKey(key []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.signingKey = append([]byte(nil), key...)
+}
+
+// CompleteSigned verifies a signed submission and records it like Complete.
+func (s *Scheduler) CompleteSigned(r SignedResult) error {
+	s.mu.Lock()
+	key := s.signingKey
+	s.mu.Unlock()
+	if key == nil {
+		return fmt.Errorf("scheduler has no signing key")
+	}
+	if !VerifyResult(key, r) {
+		return fmt.Errorf("experiment %s trial %d: invalid result signature", r.Trial.Experiment, r.Trial.Trial.ID)
+	}
+	return s.complete(r.Trial, TrialResult{Trial: r.Trial.Trial, Observations: r.Observations})
+}
+
+// resultMAC computes the HMAC over a canonical encoding of the submission. Maps are
+// encoded in sorted key order so that the signature does not depend on iteration order.
+func resultMAC(key []byte, st ScheduledTrial, observations []float64) []byte {
+	mac := hmac.New(sha256.New, key)
+	writeString := func(v string) {
+		binary.Write(mac, binary.LittleEndian, uint64(len(v)))
+		mac.Write([]byte(v))
+	}
+	writeFloat := func(v float64) {
+		binary.Write(mac, binary.LittleEndian, math.Float64bits(v))
+	}
+	writeMap := func(m map[string]float64) {
+		keys := make([]string, 0, len(m))
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		binary.Write(mac, binary.LittleEndian, uint64(len(keys)))
+		for _, k := range keys {
+			writeString(k)
+			writeFloat(m[k])
+		}
+	}
+
+	writeString(st.Experiment)
+	writeString(st.Agent)
+	binary.Write(mac, binary.LittleEndian, int64(st.Trial.ID))
+	writeMap(st.Trial.Control)
+	writeMap(st.Trial.Noise)
+	binary.Write(mac, binary.LittleEndian, uint64(len(st.Resources)))
+	for _, r := range st.Resources {
+		writeString(r)
+	}
+	binary.Write(mac, binary.LittleEndian, uint64(len(observations)))
+	for _, y := range observations {
+		writeFloat(y)
+	}
+	return mac.Sum(nil)
+}
@@ -0,0 +1,97 @@
+package taguchi
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Snap describes the values a continuous factor can practically be set to. Set one of
+// Step and Allowed.
+// Step: Settable values are the multiples of Step, e.g. 0.5.
+// Allowed: Settable values are exactly these, e.g. the instance sizes on offer.
+type Snap struct {
+	Step    float64
+	Allowed []float64
+}
+
+// SnapResult is the optimal configuration moved to settable values.
+// Levels: The snapped level of every factor; factors without a Snap keep their optimal level.
+// SNR: Predicted SNR (dB) of the snapped configuration.
+// OptimalSNR: Predicted SNR (dB) of the unsnapped optimal configuration.
+type SnapResult struct {
+	Levels     map[string]float64
+	SNR        float64
+	OptimalSNR float64
+}
+
+// SnapOptimalLevels moves the optimal levels of the factors in snaps to the nearest
+// settable value and recomputes the predicted SNR there. Between and beyond the
+// experiment's levels a factor's effect is interpolated linearly between neighboring
+// levels and held at the outermost level, which is exact for the linear model of a
+// Latin hypercube experiment.
+func (r AnalysisResult) SnapOptimalLevels(snaps map[string]Snap) (SnapResult, error) {
+	result := SnapResult{Levels: make(map[string]float64, len(r.OptimalLevels)), OptimalSNR: r.optimalSNR()}
+	for name, level := range r.OptimalLevels {
+		result.Levels[name] = level
+	}
+	result.SNR = result.OptimalSNR
+	for _, name := range sortedKeys(snaps) {
+		snap := snaps[name]
+		effects, ok := r.MainEffects[name]
+		if !ok {
+			return SnapResult{}, fmt.Errorf("unknown factor %s", name)
+		}
+		level, err := snap.nearest(r.OptimalLevels[name])
+		if err != nil {
+			return SnapResult{}, fmt.Errorf("factor %s: %w", name, err)
+		}
+		result.Levels[name] = level
+		result.SNR += interpolateEffect(r.Levels[name], effects, level) - r.optimalEffect(name, effects)
+	}
+	return result, nil
+}
+
+// nearest returns the settable value closest to level.
+func (s Snap) nearest(level float64) (float64, error) {
+	switch {
+	case s.Step > 0 && len(s.Allowed) > 0:
+		return 0, fmt.Errorf("set either a step or allowed values, not both")
+	case s.Step > 0:
+		return math.Round(level/s.Step) * s.Step, nil
+	case len(s.Allowed) > 0:
+		best := s.Allowed[0]
+		for _, v := range s.Allowed[1:] {
+			if math.Abs(v-level) < math.Abs(best-level) {
+				best = v
+			}
+		}
+		return best, nil
+	}
+	return 0, fmt.Errorf("snap needs a positive step or allowed values")
+}
+
+// interpolateEffect returns the main effect at value, linearly interpolated between
+// the levels around it and held constant beyond the outermost levels.
+func interpolateEffect(levels, effects []float64, value float64) float64 {
+	order := make([]int, min(len(levels), len(effects)))
+	for i := range order {
+		order[i] = i
+	}
+	if len(order) == 0 {
+		return math.NaN()
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(levels[a], levels[b]) })
+	if value <= levels[order[0]] {
+		return effects[order[0]]
+	}
+	for k := 1; k < len(order); k++ {
+		lo, hi := order[k-1], order[k]
+		if value <= levels[hi] {
+			t := (value - levels[lo]) / (levels[hi] - levels[lo])
+			return effects[lo] + t*(effects[hi]-effects[lo])
+		}
+	}
+	return effects[order[len(order)-1]]
+}
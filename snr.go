@@ -5,15 +5,15 @@ import "math"
 // CalculateSNR computes the Signal-to-Noise ratio for "smaller-the-better" experiments.
 // Formula: -10 * log10(mean(y_i^2))
 func (s SmallerTheBetter) CalculateSNR(obs []float64) float64 {
-	if len(obs) == 0 {
+	return s.CalculateWeightedSNR(obs, nil)
+}
+
+// CalculateWeightedSNR computes the smaller-the-better SNR with the weighted mean.
+func (s SmallerTheBetter) CalculateWeightedSNR(obs, weights []float64) float64 {
+	msd, ok := weightedMean(obs, weights, func(y float64) float64 { return y * y })
+	if !ok {
 		return 0
 	}
-	msd := 0.0
-	for _, y := range obs {
-		msd += y * y
-	}
-	msd /= float64(len(obs))
-
 	if msd == 0 {
 		return math.Inf(1)
 	}
@@ -28,17 +28,20 @@ func (s SmallerTheBetter) String() string {
 // CalculateSNR computes the Signal-to-Noise ratio for "larger-the-better" experiments.
 // Formula: -10 * log10(mean(1/y_i^2))
 func (l LargerTheBetter) CalculateSNR(obs []float64) float64 {
-	if len(obs) == 0 {
-		return 0
-	}
-	msd := 0.0
-	for _, y := range obs {
+	return l.CalculateWeightedSNR(obs, nil)
+}
+
+// CalculateWeightedSNR computes the larger-the-better SNR with the weighted mean.
+func (l LargerTheBetter) CalculateWeightedSNR(obs, weights []float64) float64 {
+	msd, ok := weightedMean(obs, weights, func(y float64) float64 {
 		if y == 0 {
 			y = 1e-10 // avoid division by zero
 		}
-		msd += 1 / (y * y)
+		return 1 / (y * y)
+	})
+	if !ok {
+		return 0
 	}
-	msd /= float64(len(obs))
 	return -10 * math.Log10(msd)
 }
 
@@ -50,15 +53,15 @@ func (l LargerTheBetter) String() string {
 // CalculateSNR computes the Signal-to-Noise ratio for "nominal-the-best" experiments.
 // Formula: -10 * log10(mean((y_i - Target)^2))
 func (n NominalTheBest) CalculateSNR(obs []float64) float64 {
-	if len(obs) == 0 {
+	return n.CalculateWeightedSNR(obs, nil)
+}
+
+// CalculateWeightedSNR computes the nominal-the-best SNR with the weighted mean.
+func (n NominalTheBest) CalculateWeightedSNR(obs, weights []float64) float64 {
+	msd, ok := weightedMean(obs, weights, func(y float64) float64 { return (y - n.Target) * (y - n.Target) })
+	if !ok {
 		return 0
 	}
-	msd := 0.0
-	for _, y := range obs {
-		msd += (y - n.Target) * (y - n.Target)
-	}
-	msd /= float64(len(obs))
-
 	if msd == 0 {
 		return math.Inf(1)
 	}
@@ -69,3 +72,33 @@ func (n NominalTheBest) CalculateSNR(obs []float64) float64 {
 func (n NominalTheBest) String() string {
 	return "Nominal-the-Best"
 }
+
+// CalculateSNR computes the Type I Signal-to-Noise ratio for "nominal-the-best"
+// experiments, as most textbooks and Minitab report it.
+// Formula: 10 * log10(mean(y)^2 / s^2), with s^2 the sample variance
+// It is 0 for fewer than 2 observations, whose variance is undefined.
+func (n NominalTheBestI) CalculateSNR(obs []float64) float64 {
+	if len(obs) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, y := range obs {
+		mean += y
+	}
+	mean /= float64(len(obs))
+	variance := 0.0
+	for _, y := range obs {
+		variance += (y - mean) * (y - mean)
+	}
+	variance /= float64(len(obs) - 1)
+
+	if variance == 0 {
+		return math.Inf(1)
+	}
+	return 10 * math.Log10(mean*mean/variance)
+}
+
+// String returns the human-readable name for the NominalTheBestI goal.
+func (n NominalTheBestI) String() string {
+	return "Nominal-the-Best (Type I)"
+}
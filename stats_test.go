@@ -0,0 +1,138 @@
+package taguchi
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestStudentTQuantile checks t critical values against standard tables.
+func TestStudentTQuantile(t *testing.T) {
+	cases := []struct {
+		p, df, want float64
+	}{
+		{0.975, 10, 2.228},
+		{0.975, 1, 12.706},
+		{0.95, 30, 1.697},
+	}
+	for _, c := range cases {
+		if got := studentTQuantile(c.p, c.df); !almostEqual(got, c.want) {
+			t.Errorf("studentTQuantile(%v, %v): got %.4f, want %.3f", c.p, c.df, got, c.want)
+		}
+	}
+}
+
+// TestFSurvival checks F-distribution tail probabilities at tabulated 5% critical values.
+func TestFSurvival(t *testing.T) {
+	cases := []struct {
+		f, d1, d2 float64
+	}{
+		{4.965, 1, 10},
+		{3.708, 3, 10},
+		{2.711, 5, 20},
+	}
+	for _, c := range cases {
+		if got := fSurvival(c.f, c.d1, c.d2); !almostEqual(got, 0.05) {
+			t.Errorf("fSurvival(%v, %v, %v): got %.4f, want 0.05", c.f, c.d1, c.d2, got)
+		}
+	}
+}
+
+// TestChiSquareSurvival checks chi-square tail probabilities at tabulated 5% critical values.
+func TestChiSquareSurvival(t *testing.T) {
+	cases := []struct {
+		x, df float64
+	}{
+		{3.841, 1},
+		{5.991, 2},
+		{18.307, 10},
+	}
+	for _, c := range cases {
+		if got := chiSquareSurvival(c.x, c.df); !almostEqual(got, 0.05) {
+			t.Errorf("chiSquareSurvival(%v, %v): got %.4f, want 0.05", c.x, c.df, got)
+		}
+	}
+}
+
+// TestTDigest checks digest quantiles of an exponential sample against the exact ones,
+// and that merging per-worker digests matches a single digest.
+func TestTDigest(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]float64, 200000)
+	d, a, b := NewTDigest(0), NewTDigest(0), NewTDigest(0)
+	for i := range samples {
+		samples[i] = rng.ExpFloat64()
+		d.Add(samples[i])
+		if i%2 == 0 {
+			a.Add(samples[i])
+		} else {
+			b.Add(samples[i])
+		}
+	}
+	a.Merge(b)
+	slices.Sort(samples)
+	for _, q := range []float64{0.5, 0.9, 0.99, 0.999} {
+		exact := samples[int(q*float64(len(samples)))]
+		for name, digest := range map[string]*TDigest{"single": d, "merged": a} {
+			if got := digest.Quantile(q); math.Abs(got-exact)/exact > 0.01 {
+				t.Errorf("%s Quantile(%v): got %.4f, want %.4f", name, q, got, exact)
+			}
+		}
+	}
+	if d.Count() != int64(len(samples)) || a.Count() != d.Count() {
+		t.Errorf("Count: got %d and %d, want %d", d.Count(), a.Count(), len(samples))
+	}
+	if len(d.centroids) > 2*DefaultTDigestCompression {
+		t.Errorf("digest kept %d centroids", len(d.centroids))
+	}
+	if got := NewTDigest(0).Quantile(0.5); !math.IsNaN(got) {
+		t.Errorf("empty digest: got %v, want NaN", got)
+	}
+}
+
+// TestHdrHistogram checks percentiles against the configured precision and that the
+// compressed V2 encoding round-trips.
+func TestHdrHistogram(t *testing.T) {
+	h, err := NewHdrHistogram(1, 3600000000, 3)
+	if err != nil {
+		t.Fatalf("NewHdrHistogram: %v", err)
+	}
+	for v := int64(1); v <= 10000; v++ {
+		if err := h.RecordValues(v, 1); err != nil {
+			t.Fatalf("RecordValues(%d): %v", v, err)
+		}
+	}
+	h.RecordValues(1000000, 10)
+	for _, c := range []struct{ q, want float64 }{{0.5, 5005}, {0.9, 9009}, {0.9999, 1000000}} {
+		if got := h.Quantile(c.q); math.Abs(got-c.want)/c.want > 0.001 {
+			t.Errorf("Quantile(%v): got %v, want %v", c.q, got, c.want)
+		}
+	}
+	if err := h.RecordValues(-1, 1); err == nil {
+		t.Error("RecordValues accepted a negative value")
+	}
+
+	encoded, err := h.Encode()
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if !strings.HasPrefix(encoded, "HISTFAAA") {
+		t.Errorf("encoding starts %q, want the V2 compressed cookie HISTFAAA", encoded[:8])
+	}
+	decoded, err := DecodeHdrHistogram(encoded)
+	if err != nil {
+		t.Fatalf("DecodeHdrHistogram: %v", err)
+	}
+	if decoded.Count() != h.Count() || !slices.Equal(decoded.counts, h.counts) {
+		t.Errorf("decoded histogram differs: %d values, want %d", decoded.Count(), h.Count())
+	}
+	obs := decoded.Observations(100)
+	if len(obs) != 100 || math.Abs(obs[49]-5000)/5000 > 0.01 {
+		t.Errorf("Observations: got %d values, median %v", len(obs), obs[49])
+	}
+	if _, err := DecodeHdrHistogram("not a histogram"); err == nil {
+		t.Error("DecodeHdrHistogram accepted garbage")
+	}
+}
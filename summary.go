@@ -1,60 +1,150 @@
 package taguchi
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
 
-// PrintAnalysisReport prints a detailed, human-readable Taguchi analysis report.
+// sortedKeys returns the keys of a factor-keyed map in alphabetical order, so that
+// reports are deterministic.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatLevel formats a factor level for reports: duration levels (in nanoseconds) as
+// time.Duration strings such as "250ms", everything else with %v.
+func formatLevel(level float64, duration bool) string {
+	if duration {
+		return time.Duration(level).String()
+	}
+	return fmt.Sprint(level)
+}
+
+// PrintAnalysisReport prints a detailed, human-readable Taguchi analysis report to stdout.
 func PrintAnalysisReport(result AnalysisResult) {
-	fmt.Println("========================================")
-	fmt.Println("        TAGUCHI ANALYSIS REPORT")
-	fmt.Println("========================================")
+	FprintAnalysisReport(os.Stdout, result)
+}
+
+// FprintAnalysisReport writes a detailed, human-readable Taguchi analysis report to w.
+func FprintAnalysisReport(w io.Writer, result AnalysisResult) {
+	fmt.Fprintln(w, "========================================")
+	fmt.Fprintln(w, "        TAGUCHI ANALYSIS REPORT")
+	fmt.Fprintln(w, "========================================")
+	if result.Method != "" {
+		fmt.Fprintf(w, "Method: %s\n", result.Method)
+	}
+	for _, d := range result.Diagnostics {
+		fmt.Fprintf(w, "WARNING: %s\n", d)
+	}
 
 	// 1. Optimal Factor Levels
-	fmt.Println("1. Optimal Factor Levels")
-	fmt.Println("------------------------")
-	fmt.Println("These are the factor levels that maximize the performance metric (SNR):")
-	for factor, level := range result.OptimalLevels {
-		fmt.Printf("  - %s: %v\n", factor, level)
+	fmt.Fprintln(w, "1. Optimal Factor Levels")
+	fmt.Fprintln(w, "------------------------")
+	fmt.Fprintln(w, "These are the factor levels that maximize the performance metric (SNR):")
+	for _, factor := range sortedKeys(result.OptimalLevels) {
+		fmt.Fprintf(w, "  - %s: %s\n", factor, formatLevel(result.OptimalLevels[factor], result.Durations[factor]))
 	}
-	fmt.Println()
+	fmt.Fprintln(w)
 
 	// 2. Main Effects
-	fmt.Println("2. Main Effects (Average SNR per Factor Level)")
-	fmt.Println("-----------------------------------------------")
-	fmt.Println("This shows how each factor level affects the response variable.")
-	for factor, effects := range result.MainEffects {
-		fmt.Printf("  %s:\n", factor)
-		for i, val := range effects {
-			fmt.Printf("    Level %d: %.4f\n", i+1, val)
+	fmt.Fprintln(w, "2. Main Effects (Average SNR per Factor Level)")
+	fmt.Fprintln(w, "-----------------------------------------------")
+	fmt.Fprintln(w, "This shows how each factor level affects the response variable.")
+	for _, factor := range sortedKeys(result.MainEffects) {
+		fmt.Fprintf(w, "  %s:\n", factor)
+		for i, val := range result.MainEffects[factor] {
+			fmt.Fprintf(w, "    Level %d: %.4f\n", i+1, val)
 		}
-		fmt.Println("    => Higher values indicate a better effect on performance.")
+		fmt.Fprintln(w, "    => Higher values indicate a better effect on performance.")
 	}
 
 	// 3. Contributions of Each Factor
-	fmt.Println("3. Contribution of Each Factor")
-	fmt.Println("-------------------------------")
-	fmt.Println("This tells us how much each factor contributes to the total variation:")
-	for factor, contrib := range result.Contributions {
-		fmt.Printf("  - %s: %.2f%%\n", factor, contrib)
+	fmt.Fprintln(w, "3. Contribution of Each Factor")
+	fmt.Fprintln(w, "-------------------------------")
+	fmt.Fprintln(w, "This tells us how much each factor contributes to the total variation:")
+	for _, factor := range sortedKeys(result.Contributions) {
+		fmt.Fprintf(w, "  - %s: %.2f%%\n", factor, result.Contributions[factor])
 	}
-	fmt.Println("  => Factors with higher percentages are more influential.")
+	fmt.Fprintln(w, "  => Factors with higher percentages are more influential.")
 
 	// 4. ANOVA Results
-	fmt.Println("4. ANOVA (Analysis of Variance) Table")
-	fmt.Println("------------------------------------")
-	fmt.Println("ANOVA helps determine which factors significantly affect the response.")
-	fmt.Printf("%-15s %-12s %-8s %-10s\n", "Factor", "SS", "DF", "F-ratio")
-	for factor := range result.ANOVA.FactorSS {
-		fmt.Printf("%-15s %-12.4f %-8d %-10.4f\n",
+	fmt.Fprintln(w, "4. ANOVA (Analysis of Variance) Table")
+	fmt.Fprintln(w, "------------------------------------")
+	fmt.Fprintln(w, "ANOVA helps determine which factors significantly affect the response.")
+	fmt.Fprintf(w, "%-15s %-12s %-8s %-10s\n", "Factor", "SS", "DF", "F-ratio")
+	for _, factor := range sortedKeys(result.ANOVA.FactorSS) {
+		fmt.Fprintf(w, "%-15s %-12.4f %-8d %-10.4f\n",
 			factor,
 			result.ANOVA.FactorSS[factor],
 			result.ANOVA.FactorDF[factor],
 			result.ANOVA.FactorF[factor],
 		)
 	}
-	fmt.Printf("%-15s %-12.4f %-8d\n",
+	fmt.Fprintf(w, "%-15s %-12.4f %-8d\n",
 		"Error",
 		result.ANOVA.ErrorSS,
 		result.ANOVA.ErrorDF,
 	)
-	fmt.Println("  => Factors with higher F-ratio are more statistically significant.")
+	if len(result.ANOVA.PooledFactors) > 0 {
+		fmt.Fprintf(w, "  Error pooled from idle %s\n", strings.Join(result.ANOVA.PooledFactors, ", "))
+	}
+	fmt.Fprintln(w, "  => Factors with higher F-ratio are more statistically significant.")
+	if len(result.KruskalWallis) > 0 {
+		fmt.Fprintf(w, "%-15s %-12s %-8s %-10s\n", "Factor", "H", "DF", "p-value")
+		for _, factor := range sortedKeys(result.KruskalWallis) {
+			kw := result.KruskalWallis[factor]
+			fmt.Fprintf(w, "%-15s %-12.4f %-8d %-10.4f\n", factor, kw.H, kw.DF, kw.PValue)
+		}
+		fmt.Fprintln(w, "  => Kruskal-Wallis: factors with small p-values shift the SNR ranks significantly.")
+	}
+
+	next := 5
+	if len(result.Narrative) > 0 {
+		fmt.Fprintf(w, "%d. Interpretation\n", next)
+		fmt.Fprintln(w, "-----------------")
+		for _, line := range result.Narrative {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+		next++
+	}
+	if c := result.Cost; c != nil {
+		fmt.Fprintf(w, "%d. Cost of Experimentation vs Expected Benefit\n", next)
+		fmt.Fprintln(w, "-----------------------------------------------")
+		fmt.Fprintf(w, "  Total cost: %.4f over %d trials (%.4f per trial)\n", c.Total, c.Trials, c.Total/float64(c.Trials))
+		for _, factor := range sortedKeys(c.PerLevel) {
+			fmt.Fprintf(w, "  %s mean cost per level:", factor)
+			for _, v := range c.PerLevel[factor] {
+				fmt.Fprintf(w, " %.4f", v)
+			}
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "  Expected SNR gain at optimal levels: %.4f dB", c.ExpectedGain)
+		if c.ExpectedChange != "" {
+			fmt.Fprintf(w, " (%s)", c.ExpectedChange)
+		}
+		fmt.Fprintln(w)
+		if c.CostPerDB > 0 {
+			fmt.Fprintf(w, "  => Cost per dB of expected improvement: %.4f\n", c.CostPerDB)
+		}
+		next++
+	}
+
+	// Sections from registered analysis passes
+	for i, section := range result.Sections {
+		heading := fmt.Sprintf("%d. %s", i+next, section.Title)
+		fmt.Fprintln(w, heading)
+		fmt.Fprintln(w, strings.Repeat("-", len(heading)))
+		for _, line := range section.Lines {
+			fmt.Fprintf(w, "  %s\n", line)
+		}
+	}
 }
@@ -0,0 +1,171 @@
+package taguchi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// MethodForwardSelection is the analysis method reported by AnalyzeForwardSelection.
+const MethodForwardSelection = "Forward selection (stepwise regression on SNR)"
+
+// forwardSelectionAlpha is the significance level Analyze uses to enter factors or
+// terms in supersaturated and definitive screening designs.
+const forwardSelectionAlpha = 0.05
+
+// GenerateSupersaturated returns a two-level supersaturated design for nFactors factors:
+// fewer runs than factors, for screening studies where only a few of many factors are
+// expected to matter. It uses Lin's half-fraction construction on the smallest
+// Plackett–Burman design that yields enough columns: the rows where the first column
+// is at level 2, with that column removed, so that a 12-run design gives 6 runs for
+// up to 10 factors. Every column stays balanced but columns are no longer orthogonal;
+// columns identical or complementary to an earlier one are dropped.
+func GenerateSupersaturated(nFactors int) ([][]int, error) {
+	if nFactors < 2 {
+		return nil, fmt.Errorf("supersaturated design needs at least 2 factors, got %d", nFactors)
+	}
+	var errs []error
+	for nRuns := 8; nRuns <= 4*(nFactors+2); nRuns += 4 {
+		pb, err := PlackettBurman(nRuns)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var half [][]int
+		for _, row := range pb {
+			if row[0] == 2 {
+				half = append(half, row[1:])
+			}
+		}
+		columns := distinctColumns(half)
+		if len(columns) < nFactors {
+			continue
+		}
+		design := make([][]int, len(half))
+		for i, row := range half {
+			design[i] = make([]int, nFactors)
+			for j, c := range columns[:nFactors] {
+				design[i][j] = row[c]
+			}
+		}
+		return design, nil
+	}
+	return nil, fmt.Errorf("no supersaturated design for %d factors: %w", nFactors, errors.Join(errs...))
+}
+
+// distinctColumns returns the columns of a two-level array that are neither identical
+// nor complementary to an earlier column.
+func distinctColumns(oa [][]int) []int {
+	var kept []int
+	for j := range oa[0] {
+		duplicate := slices.ContainsFunc(kept, func(k int) bool {
+			same, complement := true, true
+			for _, row := range oa {
+				same = same && row[j] == row[k]
+				complement = complement && row[j] != row[k]
+			}
+			return same || complement
+		})
+		if !duplicate {
+			kept = append(kept, j)
+		}
+	}
+	return kept
+}
+
+// NewSupersaturatedExperiment initializes an experiment on GenerateSupersaturated's
+// design for two-level control factors. Its Analyze uses AnalyzeForwardSelection at the
+// 5% level, since a saturated ANOVA has no degrees of freedom left for error.
+func NewSupersaturatedExperiment(goal OptimizationGoal, controlFactors []ControlFactor, noiseFactors []NoiseFactor) (*Experiment[struct{}], error) {
+	design, err := GenerateSupersaturated(len(controlFactors))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkLevelRules(controlFactors); err != nil {
+		return nil, err
+	}
+	spec, err := NewArraySpec(design)
+	if err != nil {
+		return nil, err
+	}
+	if err := spec.Validate(controlFactors); err != nil {
+		return nil, err
+	}
+	return &Experiment[struct{}]{
+		ControlFactors:  controlFactors,
+		NoiseFactors:    noiseFactors,
+		Goal:            goal,
+		OrthogonalArray: design,
+		design:          designSupersaturated,
+	}, nil
+}
+
+// AnalyzeForwardSelection analyzes the row SNRs by forward selection, for designs with
+// more factor effects than runs (supersaturated, or saturated arrays such as L12 with 11
+// factors). Starting from the empty model, the factor whose columns most reduce the
+// residual SS enters the least-squares fit, as long as its partial F-test is significant
+// at alpha and a degree of freedom is left for error. Factors never entered get flat main
+// effects and zero SS; Selected lists the entered factors in order of entry.
+func (e *Experiment[P]) AnalyzeForwardSelection(alpha float64) AnalysisResult {
+	oaSNR, _ := e.computeOASNR()
+	xs, ys, factorColumns := e.regressionData(oaSNR)
+	active, order := forwardSelect(xs, ys, factorColumns, nil, alpha)
+	var selected []string
+	for _, j := range order {
+		selected = append(selected, e.ControlFactors[j].Name)
+	}
+
+	result := e.fitFactors(oaSNR, xs, ys, factorColumns, active)
+	result.Method = MethodForwardSelection
+	result.Selected = selected
+	result = e.runPasses(result, oaSNR)
+	if len(selected) == 0 {
+		result.Diagnostics = append(result.Diagnostics, fmt.Sprintf("Forward selection entered no factor at the %g level.", alpha))
+	} else {
+		result.Diagnostics = append(result.Diagnostics, fmt.Sprintf("Forward selection entered %s at the %g level; the other factors' effects are set to zero.", strings.Join(selected, ", "), alpha))
+	}
+	return result
+}
+
+// forwardSelect runs forward selection over groups of model columns of xs: starting
+// from the intercept-only model, the eligible group that most reduces the residual SS
+// enters while its partial F-test is significant at alpha and a degree of freedom is
+// left for error. eligible, if not nil, restricts the candidates given the groups
+// entered so far. It returns which groups entered, and their order of entry.
+func forwardSelect(xs [][]float64, ys []float64, groups [][]int, eligible func(entered []bool, g int) bool, alpha float64) ([]bool, []int) {
+	entered := make([]bool, len(groups))
+	width := modelWidth(groups)
+	var order, fitted []int
+	_, _, rss := leastSquares(xs, ys, width, nil)
+	for rss > 0 {
+		best, bestRSS := -1, math.Inf(1)
+		for g, columns := range groups {
+			if entered[g] || len(xs)-1-len(fitted)-len(columns) < 1 || eligible != nil && !eligible(entered, g) {
+				continue
+			}
+			_, _, candidate := leastSquares(xs, ys, width, append(slices.Clone(fitted), columns...))
+			if candidate < bestRSS {
+				best, bestRSS = g, candidate
+			}
+		}
+		if best < 0 {
+			break
+		}
+		df := len(groups[best])
+		errorDF := len(xs) - 1 - len(fitted) - df
+		pValue := 0.0
+		if bestRSS > 1e-12*rss {
+			pValue = fSurvival((rss-bestRSS)/float64(df)/(bestRSS/float64(errorDF)), float64(df), float64(errorDF))
+		}
+		if pValue >= alpha {
+			break
+		}
+		entered[best] = true
+		order = append(order, best)
+		fitted = append(fitted, groups[best]...)
+		rss = bestRSS
+	}
+	return entered, order
+}
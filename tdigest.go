@@ -0,0 +1,154 @@
+package taguchi
+
+import (
+	"math"
+	"slices"
+)
+
+// DefaultTDigestCompression is the compression NewTDigest uses when given zero. A digest
+// keeps on the order of compression centroids, and quantile errors shrink as it grows.
+const DefaultTDigestCompression = 200
+
+// centroid is a cluster of observations of a TDigest.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// TDigest is a merging t-digest (Dunning & Ertl): a quantile sketch that summarizes any
+// number of observations in a fixed amount of memory, with the highest accuracy in the
+// tails, where p99 and p99.9 latencies live. It is not safe for concurrent use; give
+// each worker its own digest and combine them with Merge.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min, max    float64
+}
+
+// NewTDigest returns an empty digest. compression <= 0 selects
+// DefaultTDigestCompression.
+func NewTDigest(compression float64) *TDigest {
+	if compression <= 0 {
+		compression = DefaultTDigestCompression
+	}
+	return &TDigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+}
+
+// Add records one observation. NaN observations are ignored.
+func (d *TDigest) Add(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+	d.add(centroid{mean: x, weight: 1})
+	d.min = math.Min(d.min, x)
+	d.max = math.Max(d.max, x)
+}
+
+// Merge adds every observation summarized by other to d.
+func (d *TDigest) Merge(other *TDigest) {
+	for _, c := range other.centroids {
+		d.add(c)
+	}
+	for _, c := range other.buffer {
+		d.add(c)
+	}
+	d.min = math.Min(d.min, other.min)
+	d.max = math.Max(d.max, other.max)
+}
+
+// Count returns the number of observations recorded.
+func (d *TDigest) Count() int64 {
+	return int64(d.count)
+}
+
+// Quantile returns an estimate of the q-quantile (0 <= q <= 1) of the observations, or
+// NaN if none were recorded.
+func (d *TDigest) Quantile(q float64) float64 {
+	d.compress()
+	if len(d.centroids) == 0 {
+		return math.NaN()
+	}
+	if q <= 0 {
+		return d.min
+	}
+	if q >= 1 {
+		return d.max
+	}
+	target := q * d.count
+	first, last := d.centroids[0], d.centroids[len(d.centroids)-1]
+	// Below the first centroid's center and above the last one's, interpolate towards
+	// the exact extremes.
+	if target < first.weight/2 {
+		return d.min + (first.mean-d.min)*target/(first.weight/2)
+	}
+	if target > d.count-last.weight/2 {
+		return last.mean + (d.max-last.mean)*(target-(d.count-last.weight/2))/(last.weight/2)
+	}
+	cumulative := 0.0
+	for i := 0; i+1 < len(d.centroids); i++ {
+		a, b := d.centroids[i], d.centroids[i+1]
+		left := cumulative + a.weight/2
+		right := cumulative + a.weight + b.weight/2
+		if target <= right {
+			return a.mean + (b.mean-a.mean)*(target-left)/(right-left)
+		}
+		cumulative += a.weight
+	}
+	return last.mean
+}
+
+func (d *TDigest) add(c centroid) {
+	d.buffer = append(d.buffer, c)
+	d.count += c.weight
+	if len(d.buffer) >= 5*int(d.compression) {
+		d.compress()
+	}
+}
+
+// compress merges the buffered centroids into the digest. Adjacent centroids are
+// combined as long as the merged cluster spans at most one unit of the scale function
+// k(q) = compression/(2π)·asin(2q-1), which keeps clusters small near q = 0 and q = 1.
+func (d *TDigest) compress() {
+	if len(d.buffer) == 0 {
+		return
+	}
+	all := append(d.centroids, d.buffer...)
+	slices.SortFunc(all, func(a, b centroid) int {
+		switch {
+		case a.mean < b.mean:
+			return -1
+		case a.mean > b.mean:
+			return 1
+		}
+		return 0
+	})
+
+	merged := []centroid{all[0]}
+	before := 0.0
+	limit := d.count * d.quantileLimit(0)
+	for _, c := range all[1:] {
+		last := &merged[len(merged)-1]
+		if before+last.weight+c.weight <= limit {
+			last.weight += c.weight
+			last.mean += (c.mean - last.mean) * c.weight / last.weight
+			continue
+		}
+		before += last.weight
+		limit = d.count * d.quantileLimit(before/d.count)
+		merged = append(merged, c)
+	}
+	d.centroids = merged
+	d.buffer = nil
+}
+
+// quantileLimit returns the quantile one unit of the scale function above q.
+func (d *TDigest) quantileLimit(q float64) float64 {
+	k := d.compression / (2 * math.Pi) * math.Asin(2*q-1)
+	k++
+	if k >= d.compression/4 {
+		return 1
+	}
+	return (math.Sin(k*2*math.Pi/d.compression) + 1) / 2
+}
@@ -0,0 +1,100 @@
+package taguchi
+
+import "fmt"
+
+// Template is a ready-made starting point for a common kind of experiment. Adjust the
+// factors and levels to the system under test, then call NewExperiment.
+// Name: Short identifier of the template.
+// Description: What the template tunes and what to measure.
+// Goal: The optimization goal of the measured response.
+// ControlFactors: Suggested control factors and levels.
+// NoiseFactors: Suggested noise factors.
+// NoiseStrategy: How to realize the noise conditions when running trials.
+// Array: The standard array the factors are laid out on.
+type Template struct {
+	Name           string
+	Description    string
+	Goal           OptimizationGoal
+	ControlFactors []ControlFactor
+	NoiseFactors   []NoiseFactor
+	NoiseStrategy  string
+	Array          ArrayType
+}
+
+// NewExperiment builds an experiment from the template.
+func (t Template) NewExperiment() (*Experiment[struct{}], error) {
+	return NewExperimentFromFactors(t.Goal, t.ControlFactors, t.Array, t.NoiseFactors)
+}
+
+// WebServiceLatencyTemplate tunes a web service for tail latency on a machine with cpus
+// CPUs serving up to peakRPS requests per second. Measure p99 latency per trial.
+func WebServiceLatencyTemplate(cpus int, peakRPS float64) (Template, error) {
+	if cpus < 1 || peakRPS <= 0 {
+		return Template{}, fmt.Errorf("web service template needs at least 1 CPU and a positive peak rate, got %d and %v", cpus, peakRPS)
+	}
+	c := float64(cpus)
+	return Template{
+		Name:        "web-service-latency",
+		Description: "Tune worker count, connection pool size and GC target for p99 latency.",
+		Goal:        SmallerTheBetter{},
+		ControlFactors: []ControlFactor{
+			{Name: "Workers", Levels: []float64{c, 2 * c, 4 * c}, Rules: &LevelRules{Integer: true}},
+			{Name: "PoolSize", Levels: []float64{16, 64, 256}, Rules: &LevelRules{Integer: true}},
+			{Name: "GOGC", Levels: []float64{50, 100, 200}, Rules: &LevelRules{Integer: true}},
+		},
+		NoiseFactors: []NoiseFactor{
+			{Name: "RequestRate", Levels: []float64{peakRPS / 4, peakRPS}},
+			{Name: "PayloadKB", Levels: []float64{1, 64}},
+		},
+		NoiseStrategy: "Replay each trial at a quarter of peak and at peak load, with small and large payloads, from a load generator on a separate machine.",
+		Array:         L9,
+	}, nil
+}
+
+// BatchJobThroughputTemplate tunes a batch job for throughput on a machine with cpus
+// CPUs. Measure records processed per second per trial.
+func BatchJobThroughputTemplate(cpus int) (Template, error) {
+	if cpus < 2 {
+		return Template{}, fmt.Errorf("batch job template needs at least 2 CPUs, got %d", cpus)
+	}
+	c := float64(cpus)
+	return Template{
+		Name:        "batch-job-throughput",
+		Description: "Tune parallelism, batch size and I/O buffer size for records per second.",
+		Goal:        LargerTheBetter{},
+		ControlFactors: []ControlFactor{
+			{Name: "Workers", Levels: []float64{float64(cpus / 2), c, 2 * c}, Rules: &LevelRules{Integer: true}},
+			{Name: "BatchSize", Levels: []float64{100, 1000, 10000}, Rules: &LevelRules{Integer: true}},
+			{Name: "BufferKB", Levels: []float64{64, 1024, 16384}, Rules: &LevelRules{PowerOfTwo: true}},
+		},
+		NoiseFactors: []NoiseFactor{
+			{Name: "InputSkew", Levels: []float64{0, 1}},
+			{Name: "ColdCache", Levels: []float64{0, 1}},
+		},
+		NoiseStrategy: "Run every trial on uniform and skewed input, once with warm and once with dropped page caches.",
+		Array:         L9,
+	}, nil
+}
+
+// CompileFlagsTemplate tunes Go build settings for a benchmark, with on/off factors for
+// inlining, bounds checking, profile-guided optimization and the GOAMD64 v3
+// instruction set. Measure the benchmark's ns/op per trial.
+func CompileFlagsTemplate() Template {
+	return Template{
+		Name:        "compile-flags",
+		Description: "Tune build flags (-gcflags=-l, -gcflags=-B, -pgo, GOAMD64) for benchmark time.",
+		Goal:        SmallerTheBetter{},
+		ControlFactors: []ControlFactor{
+			BoolFactor("Inlining"),
+			BoolFactor("BoundsChecks"),
+			BoolFactor("PGO"),
+			BoolFactor("GOAMD64v3"),
+		},
+		NoiseFactors: []NoiseFactor{
+			{Name: "Machine", Levels: []float64{0, 1}},
+			{Name: "InputSize", Levels: []float64{0, 1}},
+		},
+		NoiseStrategy: "Run every build on two machine types with a small and a large benchmark input; use -count to repeat within a trial.",
+		Array:         L8,
+	}
+}
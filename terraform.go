@@ -0,0 +1,162 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// terraformWorkspaceChars matches characters not allowed in workspace names.
+var terraformWorkspaceChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// TerraformProvisioner is a Provisioner that applies a Terraform (or OpenTofu) root
+// module with variables derived from the trial's factor levels. Every trial gets its
+// own workspace, taguchi-<experiment>-<trial ID>, so trials can run concurrently against
+// one module and re-applying an interrupted trial reuses its state.
+// Dir: The root module directory; it is initialized before each apply.
+// Binary: The CLI to run; "terraform" if empty, "tofu" for OpenTofu.
+// Vars: The -var values for a trial; nil passes every control and noise factor under
+// its own name.
+// HourlyCost: The trial's infrastructure price per hour, if known; makes the
+// provisioner a ProvisionCoster.
+type TerraformProvisioner struct {
+	Dir        string
+	Binary     string
+	Vars       func(ScheduledTrial) map[string]string
+	HourlyCost func(ScheduledTrial) float64
+}
+
+// Provision creates the trial's workspace if needed, applies the module and returns its
+// outputs, with non-string values in JSON.
+func (t TerraformProvisioner) Provision(st ScheduledTrial) (map[string]string, error) {
+	if _, err := t.run("", "init", "-input=false"); err != nil {
+		return nil, err
+	}
+	workspace := t.workspace(st)
+	if out, err := t.run("", "workspace", "new", workspace); err != nil && !strings.Contains(string(out), "already exists") {
+		return nil, err
+	}
+	if _, err := t.run(workspace, append([]string{"apply", "-auto-approve", "-input=false"}, t.varArgs(st)...)...); err != nil {
+		return nil, err
+	}
+	out, err := t.run(workspace, "output", "-json")
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(out, &raw); err != nil {
+		return nil, fmt.Errorf("decode outputs: %w", err)
+	}
+	outputs := make(map[string]string, len(raw))
+	for name, o := range raw {
+		var s string
+		if json.Unmarshal(o.Value, &s) == nil {
+			outputs[name] = s
+		} else {
+			outputs[name] = string(o.Value)
+		}
+	}
+	return outputs, nil
+}
+
+// Destroy destroys the trial's infrastructure and deletes its workspace. A trial whose
+// workspace does not exist has nothing to destroy.
+func (t TerraformProvisioner) Destroy(st ScheduledTrial) error {
+	workspace := t.workspace(st)
+	list, err := t.run("", "workspace", "list")
+	if err != nil {
+		return err
+	}
+	if !listsWorkspace(list, workspace) {
+		return nil
+	}
+	if _, err := t.run(workspace, append([]string{"destroy", "-auto-approve", "-input=false"}, t.varArgs(st)...)...); err != nil {
+		return err
+	}
+	_, err = t.run("", "workspace", "delete", workspace)
+	return err
+}
+
+// ProvisionCost prices the time the trial's infrastructure was up at HourlyCost.
+func (t TerraformProvisioner) ProvisionCost(st ScheduledTrial, up time.Duration) (float64, error) {
+	if t.HourlyCost == nil {
+		return 0, nil
+	}
+	return t.HourlyCost(st) * up.Hours(), nil
+}
+
+// workspace returns the name of the trial's workspace.
+func (t TerraformProvisioner) workspace(st ScheduledTrial) string {
+	name := terraformWorkspaceChars.ReplaceAllString(st.Experiment, "-")
+	return fmt.Sprintf("taguchi-%s-%d", name, st.Trial.ID)
+}
+
+// varArgs returns the -var arguments for the trial, sorted by name.
+func (t TerraformProvisioner) varArgs(st ScheduledTrial) []string {
+	vars := map[string]string{}
+	if t.Vars != nil {
+		vars = t.Vars(st)
+	} else {
+		for _, levels := range []map[string]float64{st.Trial.Control, st.Trial.Noise} {
+			for name, v := range levels {
+				vars[name] = strconv.FormatFloat(v, 'g', -1, 64)
+			}
+		}
+	}
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	args := make([]string, len(names))
+	for i, name := range names {
+		args[i] = "-var=" + name + "=" + vars[name]
+	}
+	return args
+}
+
+// run runs the CLI in Dir, in workspace unless it is empty, and returns its standard
+// output. On failure it returns the combined output, and an error quoting standard error.
+func (t TerraformProvisioner) run(workspace string, args ...string) ([]byte, error) {
+	binary := t.Binary
+	if binary == "" {
+		binary = "terraform"
+	}
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = t.Dir
+	// TF_WORKSPACE selects the workspace per process, unlike "workspace select", which
+	// would switch it for every trial sharing Dir.
+	cmd.Env = append(os.Environ(), "TF_IN_AUTOMATION=1")
+	if workspace != "" {
+		cmd.Env = append(cmd.Env, "TF_WORKSPACE="+workspace)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		output := append(stdout.Bytes(), stderr.Bytes()...)
+		return output, fmt.Errorf("%s %s: %w: %s", binary, args[0], err, bytes.TrimSpace(stderr.Bytes()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// listsWorkspace reports whether the output of "workspace list", where the current
+// workspace is marked with "*", names workspace.
+func listsWorkspace(list []byte, workspace string) bool {
+	for _, field := range strings.Fields(string(list)) {
+		if field == workspace {
+			return true
+		}
+	}
+	return false
+}
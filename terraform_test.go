@@ -0,0 +1,97 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestProvisionedRunner_Terraform verifies that every trial gets its own workspace,
+// applied with the trial's levels and destroyed afterwards, and that infrastructure
+// costs are recorded.
+func TestProvisionedRunner_Terraform(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake terraform CLI is a shell script")
+	}
+	dir := t.TempDir()
+	fake := `#!/bin/sh
+case "$1" in
+workspace)
+	case "$2" in
+	new) [ -e "ws-$3" ] && { echo "Workspace \"$3\" already exists" >&2; exit 1; }; touch "ws-$3" ;;
+	list) echo "* default"; for f in ws-*; do [ -e "$f" ] && echo "  ${f#ws-}"; done ;;
+	delete) rm "ws-$3" ;;
+	esac ;;
+apply) echo "$*" > "applied-$TF_WORKSPACE" ;;
+output) echo "{\"endpoint\":{\"value\":\"http://$TF_WORKSPACE\"},\"size\":{\"value\":3}}" ;;
+destroy) [ "$FAIL_DESTROY" = 1 ] && exit 1; rm "applied-$TF_WORKSPACE" ;;
+esac
+`
+	binary := filepath.Join(dir, "terraform")
+	if err := os.WriteFile(binary, []byte(fake), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := newSchedulerExperiment(t)
+	s := NewScheduler()
+	if err := s.Add("cloud tuning", exp, 1); err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	runner := NewProvisionedRunner(TerraformProvisioner{
+		Dir:        dir,
+		Binary:     binary,
+		HourlyCost: func(st ScheduledTrial) float64 { return 3600 * st.Trial.Control["A"] },
+	})
+	clock := time.Unix(0, 0)
+	runner.now = func() time.Time { clock = clock.Add(time.Second); return clock }
+	s.SetCostFunc(runner.Cost)
+	err := s.Run(runner.Wrap(func(st ScheduledTrial, outputs map[string]string) ([]float64, error) {
+		workspace := fmt.Sprintf("taguchi-cloud-tuning-%d", st.Trial.ID)
+		if outputs["endpoint"] != "http://"+workspace || outputs["size"] != "3" {
+			return nil, fmt.Errorf("outputs: got %v", outputs)
+		}
+		applied, err := os.ReadFile(filepath.Join(dir, "applied-"+workspace))
+		if err != nil {
+			return nil, err
+		}
+		want := fmt.Sprintf("-var=A=%v -var=B=%v", st.Trial.Control["A"], st.Trial.Control["B"])
+		if !strings.Contains(string(applied), want) {
+			return nil, fmt.Errorf("apply: got %q, want %q", applied, want)
+		}
+		return []float64{st.Trial.Control["A"]}, nil
+	}))
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if left, _ := filepath.Glob(filepath.Join(dir, "*-taguchi-*")); len(left) != 0 {
+		t.Errorf("infrastructure left behind: %v", left)
+	}
+	if c := exp.Analyze().Cost; c == nil || c.Total != 6 {
+		t.Errorf("Cost: got %+v, want a total of 6", c)
+	}
+
+	// A failed workload is still torn down; a failed teardown fails the trial.
+	tf := TerraformProvisioner{Dir: dir, Binary: binary}
+	st := ScheduledTrial{Experiment: "x", Trial: Trial{ID: 1, Control: map[string]float64{"A": 1}}}
+	_, err = NewProvisionedRunner(tf).Wrap(func(ScheduledTrial, map[string]string) ([]float64, error) {
+		return nil, fmt.Errorf("workload failed")
+	})(st)
+	if err == nil {
+		t.Error("workload error was not returned")
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "ws-taguchi-x-1")); !os.IsNotExist(statErr) {
+		t.Error("workspace of a failed trial was not deleted")
+	}
+	t.Setenv("FAIL_DESTROY", "1")
+	if _, err := NewProvisionedRunner(tf).Wrap(func(ScheduledTrial, map[string]string) ([]float64, error) {
+		return []float64{1}, nil
+	})(st); err == nil {
+		t.Error("destroy error was not returned")
+	}
+}
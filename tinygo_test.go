@@ -0,0 +1,44 @@
+//go:build !tinygo
+
+package taguchi
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// TestTinyGoBuild verifies that the module builds for WebAssembly with the tinygo tag,
+// as TinyGo sets it, and that none of its packages then imports reflect or net/http
+// directly. It uses the standard Go toolchain, not the TinyGo compiler, so it does not
+// catch indirect reflection such as encoding/json.
+func TestTinyGoBuild(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the module for WebAssembly")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	for _, target := range [][2]string{{"js", "wasm"}, {"wasip1", "wasm"}} {
+		cmd := exec.Command(goTool, "build", "-tags", "tinygo", "./...")
+		cmd.Env = append(os.Environ(), "GOOS="+target[0], "GOARCH="+target[1])
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Errorf("GOOS=%s GOARCH=%s go build -tags tinygo: %v\n%s", target[0], target[1], err, out)
+		}
+	}
+
+	out, err := exec.Command(goTool, "list", "-tags", "tinygo", "-f", "{{.ImportPath}}:{{range .Imports}} {{.}}{{end}}", "./...").Output()
+	if err != nil {
+		t.Fatalf("go list -tags tinygo: %v", err)
+	}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		pkg, imports, _ := strings.Cut(line, ":")
+		for _, imp := range strings.Fields(imports) {
+			if imp == "reflect" || imp == "net/http" {
+				t.Errorf("%s imports %s under TinyGo", pkg, imp)
+			}
+		}
+	}
+}
@@ -11,47 +11,51 @@ func (e *Experiment[P]) GenerateTrials() []Trial {
 	return finalTrials
 }
 
-// generateNoiseCombinations generates all combinations of noise factors.
+// generateNoiseCombinations generates all combinations of noise factors, or a stratified
+// sample of them if LimitNoiseCombinations set a cap below the full cross product.
 // Returns a slice of Trials containing only the Noise field populated (Control is nil).
 func (e *Experiment[P]) generateNoiseCombinations() []Trial {
-	var trials []Trial
-	id := 1
-
-	var helper func(idx int, current map[string]float64)
-	helper = func(idx int, current map[string]float64) {
-		if idx >= len(e.NoiseFactors) {
-			noiseCopy := make(map[string]float64, len(current))
-			for k, v := range current {
-				noiseCopy[k] = v
-			}
-			trials = append(trials, Trial{
-				ID:      id,
-				Control: nil, // to be filled later
-				Noise:   noiseCopy,
-			})
-			id++
-			return
-		}
-
-		factor := e.NoiseFactors[idx]
-		for _, level := range factor.Levels {
-			current[factor.Name] = level
-			helper(idx+1, current)
+	total := e.noiseCombinationCount()
+	if e.noiseLimit > 0 && total > e.noiseLimit {
+		samples := e.sampleNoiseCombinations(e.noiseLimit)
+		trials := make([]Trial, len(samples))
+		for i, noise := range samples {
+			trials[i] = Trial{ID: i + 1, Noise: noise}
 		}
+		return trials
 	}
 
-	helper(0, map[string]float64{})
+	trials := make([]Trial, 0, total)
+	e.EachNoiseCombination(func(noise map[string]float64) bool {
+		trials = append(trials, Trial{
+			ID:      len(trials) + 1,
+			Control: nil, // to be filled later
+			Noise:   noise,
+		})
+		return true
+	})
 	return trials
 }
 
+// noiseCombinationCount returns the number of noise combinations, i.e. the product
+// of the noise factors' level counts.
+func (e *Experiment[P]) noiseCombinationCount() int {
+	n := 1
+	for _, factor := range e.NoiseFactors {
+		n *= len(factor.Levels)
+	}
+	return n
+}
+
 // combineControlAndNoise takes a list of noise-only trials and combines them with all control factor configurations
 // defined by the orthogonal array. Returns a slice of fully defined Trials.
 func (e *Experiment[P]) combineControlAndNoise(noiseTrials []Trial) []Trial {
-	var finalTrials []Trial
+	oa := e.array()
+	finalTrials := make([]Trial, 0, oa.Rows()*len(noiseTrials))
 	id := 1 // reset ID for full trial list
 
-	for _, row := range e.OrthogonalArray {
-		controlConfig := e.getControlConfig(row)
+	for i := 0; i < oa.Rows(); i++ {
+		controlConfig := e.getControlConfig(oa.Row(i))
 
 		for _, noiseTrial := range noiseTrials {
 			t := Trial{
@@ -76,3 +80,52 @@ func (e *Experiment[P]) getControlConfig(row []int) map[string]float64 {
 	}
 	return controlConfig
 }
+
+// RowCoverage describes the data collected for one orthogonal array row.
+// Row: Zero-based index of the row in the orthogonal array.
+// Trials: The generated trials that run the row's control configuration.
+// Results: Number of recorded results matching the row's control configuration.
+type RowCoverage struct {
+	Row     int
+	Trials  []Trial
+	Results int
+}
+
+// GetTrialsForOARow returns the generated trials for the zero-based orthogonal array
+// row, with the same IDs as in GenerateTrials. It returns nil if row is out of range.
+func (e *Experiment[P]) GetTrialsForOARow(row int) []Trial {
+	oa := e.array()
+	if row < 0 || row >= oa.Rows() {
+		return nil
+	}
+	noiseTrials := e.generateNoiseCombinations()
+	controlConfig := e.getControlConfig(oa.Row(row))
+	trials := make([]Trial, len(noiseTrials))
+	for k, noiseTrial := range noiseTrials {
+		trials[k] = Trial{
+			ID:      row*len(noiseTrials) + k + 1,
+			Control: controlConfig,
+			Noise:   noiseTrial.Noise,
+		}
+	}
+	return trials
+}
+
+// RowCoverage reports, for every orthogonal array row, the trials that run it and how
+// many results have been recorded for it, so that unbalanced data collection can be
+// spotted before calling Analyze. Rows with identical control configurations (when
+// fewer factors than columns are assigned) share their results.
+func (e *Experiment[P]) RowCoverage() []RowCoverage {
+	trials := e.GenerateTrials()
+	counts := e.rowResultCounts()
+	perRow := len(trials) / len(counts)
+	coverage := make([]RowCoverage, len(counts))
+	for i := range coverage {
+		coverage[i] = RowCoverage{
+			Row:     i,
+			Trials:  trials[i*perRow : (i+1)*perRow],
+			Results: counts[i],
+		}
+	}
+	return coverage
+}
@@ -18,12 +18,28 @@ type NominalTheBest struct {
 	Target float64
 }
 
+// NominalTheBestI is the classical Type I nominal-the-best goal: minimize variability
+// relative to the mean, leaving the mean to be moved onto the target afterwards by an
+// adjustment factor that does not affect the SNR.
+type NominalTheBestI struct{}
+
 // ControlFactor represents a controllable input variable in the experiment.
 // Name: Identifier for the factor (e.g., "NumThreads").
 // Levels: A slice of possible numeric values that this factor can take.
+// Duration: Whether the levels are time.Duration values in nanoseconds, so that
+// reports print them as durations.
+// Rules: Optional constraints on the levels, checked when the experiment is built.
+// Components: For a compound factor (see CompoundFactor), the value of each underlying
+// setting at each level.
+// Labels: Optional display name of each level, e.g. the String() of an enum level, that
+// the narrative prints instead of the numeric code.
 type ControlFactor struct {
-	Name   string
-	Levels []float64
+	Name       string
+	Levels     []float64
+	Duration   bool                 `json:",omitempty"`
+	Rules      *LevelRules          `json:",omitempty"`
+	Components map[string][]float64 `json:",omitempty"`
+	Labels     []string             `json:",omitempty"`
 }
 
 // NoiseFactor represents an uncontrollable input variable (noise) in the experiment.
@@ -47,9 +63,25 @@ type Trial struct {
 // TrialResult stores the observed outcomes from a trial.
 // Trial: The trial configuration that produced these observations.
 // Observations: Measured results for this trial (e.g., latency measurements).
+// Covariates: Uncontrolled noise observed during the trial (e.g., CPU load), if recorded.
+// Environment: Fingerprint of the machine the trial ran on, if recorded.
+// Cost: Cost of running the trial (time, money, energy, ...), if recorded.
+// ProfilePath: Path of the CPU profile captured while the trial ran, if any.
+// Outer: The noise runs merged into this result by GroupNoiseReplicates, if enabled.
+// Unsafe: Why the trial was aborted by a guardrail, if it was (see SetGuardrails); its
+// configuration is never recommended, but its observations still count towards the row SNR.
+// Weights: The weight of each observation, if recorded with AddWeightedResult.
 type TrialResult struct {
 	Trial        Trial
 	Observations []float64
+	Covariates   map[string]float64 `json:",omitempty"`
+	Environment  *Environment       `json:",omitempty"`
+	Cost         float64            `json:",omitempty"`
+	ProfilePath  string             `json:",omitempty"`
+	Outer        []OuterRun         `json:",omitempty"`
+	Unsafe       string             `json:",omitempty"`
+	Weights      []float64          `json:",omitempty"`
+	row          int
 }
 
 // AnalysisResult stores the results of analyzing all experimental trials.
@@ -58,12 +90,47 @@ type TrialResult struct {
 // MainEffects: Average SNR per factor level, showing the effect of each factor.
 // Contributions: Percentage contribution of each factor to overall variability.
 // ANOVA: Detailed ANOVA statistics including SS, DF, MS, and F-ratio for factors.
+// Sections: Extra sections produced by registered analysis passes.
+// Method: The analysis path that produced the result (see MethodParametric).
+// KruskalWallis: Per-factor Kruskal-Wallis tests; set only by AnalyzeNonparametric.
+// Diagnostics: Warnings about the collected data, such as noise dominating the signal.
+// Cost: Cost of experimentation versus expected benefit; nil if no costs were recorded.
+// Durations: Control factors whose levels are time.Duration values.
+// Narrative: Plain-language interpretation of the results for non-statisticians.
+// Levels: The levels of each control factor, in the order of MainEffects.
+// GrandMean: Mean SNR over all orthogonal array rows.
+// Selected: Factors entered by forward selection, in order; set only by AnalyzeForwardSelection,
+// or model terms such as "A²" and "A×B" for definitive screening designs.
+// Unsafe: Control configurations aborted by a guardrail; OptimalLevels avoids them.
 type AnalysisResult struct {
+	Method        string
 	OptimalLevels map[string]float64
 	SNR           map[string][]float64
 	MainEffects   map[string][]float64
 	Contributions map[string]float64
 	ANOVA         ANOVAResult
+	Sections      []AnalysisSection
+	KruskalWallis map[string]KruskalWallisResult
+	Diagnostics   []string
+	Cost          *CostSummary
+	Durations     map[string]bool
+	Narrative     []string
+	Levels        map[string][]float64
+	GrandMean     float64
+	Selected      []string
+	Unsafe        []map[string]float64
+	goal          OptimizationGoal
+	designTables  func() DesignTables
+}
+
+// KruskalWallisResult stores the Kruskal-Wallis test for one factor.
+// H: Tie-corrected test statistic.
+// DF: Degrees of freedom (number of levels minus one).
+// PValue: Chi-square approximation of the p-value.
+type KruskalWallisResult struct {
+	H      float64
+	DF     int
+	PValue float64
 }
 
 // ANOVAResult stores detailed ANOVA calculations for the experiment.
@@ -74,7 +141,7 @@ type AnalysisResult struct {
 // ErrorSS: Sum of squares for residual/error.
 // ErrorDF: Degrees of freedom for residual/error.
 // ErrorMS: Mean square error.
-// PooledFactors: List of factors that were pooled together during analysis (optional).
+// PooledFactors: Columns pooled into the error term (see PoolIdleColumns), if any.
 type ANOVAResult struct {
 	FactorSS      map[string]float64
 	FactorDF      map[string]int
@@ -93,11 +160,22 @@ type ANOVAResult struct {
 // Goal: Optimization goal (Smaller, Larger, or Nominal).
 // OrthogonalArray: Predefined L4/L8/L9/etc. orthogonal array for trial combinations.
 // Results: Collection of TrialResults after experiments.
+// AdHocResults: Results for control configurations outside the design; kept out of the analysis.
+// When the experiment is built from an ArraySource, OrthogonalArray is nil and rows
+// are read from the source on demand.
 type Experiment[P any] struct {
 	ControlFactors  []ControlFactor
 	NoiseFactors    []NoiseFactor
 	Goal            OptimizationGoal
 	OrthogonalArray [][]int
 	Results         []TrialResult
+	AdHocResults    []TrialResult
 	controlAs       func(Trial) P
+	source          ArraySource
+	noiseLimit      int
+	noiseSeed       int64
+	interactions    [][2]int
+	design          string
+	groupNoise      bool
+	poolIdle        bool
 }
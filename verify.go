@@ -0,0 +1,74 @@
+package taguchi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// invariantTolerance is the absolute tolerance used when checking numeric invariants.
+const invariantTolerance = 1e-6
+
+// VerifyInvariants checks an experiment and its analysis for internal consistency:
+// every result matches an orthogonal array row, every control configuration has the
+// same number of results per row running it (rows repeating a configuration, such as
+// center runs, share its results), sums of squares are non-negative and finite, and
+// contributions sum to 100% whenever any factor has a non-zero effect.
+// It returns nil if all invariants hold, otherwise an error joining every violation.
+// It is intended for use in tests and fuzzing of code that feeds an experiment.
+func VerifyInvariants[P any](exp *Experiment[P]) error {
+	var errs []error
+
+	for k, r := range exp.Results {
+		if exp.resultRow(k) < 0 {
+			errs = append(errs, fmt.Errorf("trial %d matches no orthogonal array row", r.Trial.ID))
+		}
+	}
+	oa := exp.array()
+	first := exp.firstRows(oa)
+	runs := make([]int, len(first))
+	for _, f := range first {
+		runs[f]++
+	}
+	rowResults := exp.rowResults()
+	for i, f := range first {
+		if f != i || len(rowResults[i])*runs[0] == len(rowResults[0])*runs[i] {
+			continue
+		}
+		errs = append(errs, fmt.Errorf("row %d has %d results for %d rows, row 1 has %d for %d", i+1, len(rowResults[i]), runs[i], len(rowResults[0]), runs[0]))
+	}
+	if len(errs) > 0 || len(exp.Results) == 0 {
+		if len(exp.Results) == 0 {
+			errs = append(errs, fmt.Errorf("experiment has no results"))
+		}
+		return errors.Join(errs...)
+	}
+
+	result := exp.Analyze()
+	totalSS := 0.0
+	for _, factor := range exp.ControlFactors {
+		ss := result.ANOVA.FactorSS[factor.Name]
+		if math.IsNaN(ss) || math.IsInf(ss, 0) {
+			errs = append(errs, fmt.Errorf("factor %s: SS is %v", factor.Name, ss))
+			continue
+		}
+		if ss < -invariantTolerance {
+			errs = append(errs, fmt.Errorf("factor %s: SS is negative (%g)", factor.Name, ss))
+		}
+		totalSS += ss
+	}
+	if result.ANOVA.ErrorSS < -invariantTolerance {
+		errs = append(errs, fmt.Errorf("error SS is negative (%g)", result.ANOVA.ErrorSS))
+	}
+	if totalSS > invariantTolerance {
+		sum := 0.0
+		for _, c := range result.Contributions {
+			sum += c
+		}
+		if math.Abs(sum-100) > invariantTolerance*100 {
+			errs = append(errs, fmt.Errorf("contributions sum to %.6f%%, want 100%%", sum))
+		}
+	}
+
+	return errors.Join(errs...)
+}
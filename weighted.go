@@ -0,0 +1,68 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+)
+
+// WeightedGoal is implemented by goals whose SNR can weight observations, e.g. latencies
+// measured under several noise conditions weighted by each condition's share of
+// traffic. SmallerTheBetter, LargerTheBetter and NominalTheBest implement it by
+// replacing the mean in their SNR with the weighted mean. Analysis uses it for rows with
+// weighted results (see AddWeightedResult).
+type WeightedGoal interface {
+	OptimizationGoal
+	CalculateWeightedSNR(observations, weights []float64) float64
+}
+
+// AddWeightedResult records a trial's observations like AddResult, each with a weight,
+// e.g. the traffic share of the noise condition it was measured under. Weights are
+// relative: only their ratios matter. Observations of unweighted results count with
+// weight 1.
+func (e *Experiment[P]) AddWeightedResult(trial Trial, observations, weights []float64) error {
+	if len(weights) != len(observations) {
+		return fmt.Errorf("trial %d has %d weights for %d observations", trial.ID, len(weights), len(observations))
+	}
+	total := 0.0
+	for _, w := range weights {
+		if w < 0 || math.IsNaN(w) || math.IsInf(w, 0) {
+			return fmt.Errorf("trial %d has invalid weight %v", trial.ID, w)
+		}
+		total += w
+	}
+	if total == 0 && len(weights) > 0 {
+		return fmt.Errorf("trial %d has only zero weights", trial.ID)
+	}
+	e.AddTrialResult(TrialResult{Trial: trial, Observations: observations, Weights: weights})
+	return nil
+}
+
+// weightsOf returns the result's weights, 1 for every observation if it has none.
+func weightsOf(r TrialResult) []float64 {
+	if r.Weights != nil {
+		return r.Weights
+	}
+	weights := make([]float64, len(r.Observations))
+	for i := range weights {
+		weights[i] = 1
+	}
+	return weights
+}
+
+// weightedMean returns the mean of f(y) over obs, weighted by weights, or equally if
+// weights is nil. It reports false if there is nothing to average.
+func weightedMean(obs, weights []float64, f func(float64) float64) (float64, bool) {
+	sum, total := 0.0, 0.0
+	for i, y := range obs {
+		w := 1.0
+		if weights != nil {
+			w = weights[i]
+		}
+		sum += w * f(y)
+		total += w
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return sum / total, true
+}
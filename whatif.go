@@ -0,0 +1,91 @@
+package taguchi
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// WhatIfResult is the predicted outcome of holding one factor at a chosen level while
+// every other factor stays at its optimal level.
+// Factor: The factor held at Level.
+// Level: The chosen level.
+// SNR: Predicted SNR (dB) of that configuration.
+// OptimalSNR: Predicted SNR (dB) with every factor at its optimal level.
+// LossDB: OptimalSNR minus SNR; 0 when Level is the optimal level.
+// Response: Response implied by SNR: the root mean square of y for SmallerTheBetter,
+// of y-Target for NominalTheBest, the reciprocal root mean square of 1/y for
+// LargerTheBetter, and the coefficient of variation for NominalTheBestI; NaN for other
+// goals.
+// Change: The loss as a change of the raw response relative to the optimum, e.g.
+// "~15% higher response" (see DescribeChange).
+type WhatIfResult struct {
+	Factor     string
+	Level      float64
+	SNR        float64
+	OptimalSNR float64
+	LossDB     float64
+	Response   float64
+	Change     string
+}
+
+// WhatIf predicts the outcome of holding factor at level, e.g. a cheaper setting than
+// the optimal one, while every other factor stays optimal. Predictions use the additive
+// model behind the optimal levels: the grand mean plus each factor's main effect.
+func (r AnalysisResult) WhatIf(factor string, level float64) (WhatIfResult, error) {
+	effects, ok := r.MainEffects[factor]
+	if !ok {
+		return WhatIfResult{}, fmt.Errorf("unknown factor %s", factor)
+	}
+	l := slices.Index(r.Levels[factor], level)
+	if l < 0 || l >= len(effects) {
+		return WhatIfResult{}, fmt.Errorf("factor %s has no level %v (levels %v)", factor, level, r.Levels[factor])
+	}
+
+	optimal := r.optimalSNR()
+	loss := r.optimalEffect(factor, effects) - effects[l]
+	return WhatIfResult{
+		Factor:     factor,
+		Level:      level,
+		SNR:        optimal - loss,
+		OptimalSNR: optimal,
+		LossDB:     loss,
+		Response:   impliedResponse(r.goal, optimal-loss),
+		Change:     DescribeChange(r.goal, -loss),
+	}, nil
+}
+
+// optimalSNR predicts the SNR with every factor at its optimal level.
+func (r AnalysisResult) optimalSNR() float64 {
+	optimal := r.GrandMean
+	for name, effects := range r.MainEffects {
+		optimal += r.optimalEffect(name, effects) - r.GrandMean
+	}
+	return optimal
+}
+
+// optimalEffect returns the main effect of the factor's optimal level, or its largest
+// main effect if the optimal level is not among the levels.
+func (r AnalysisResult) optimalEffect(factor string, effects []float64) float64 {
+	if l := slices.Index(r.Levels[factor], r.OptimalLevels[factor]); l >= 0 && l < len(effects) {
+		return effects[l]
+	}
+	if len(effects) == 0 {
+		return r.GrandMean
+	}
+	return slices.Max(effects)
+}
+
+// impliedResponse inverts the built-in SNRs: the root mean square of y (SmallerTheBetter)
+// or of y-Target (NominalTheBest) and the coefficient of variation (NominalTheBestI) are
+// 10^(-SNR/20), and so is the root mean square of 1/y (LargerTheBetter), making y about
+// 10^(SNR/20).
+func impliedResponse(goal OptimizationGoal, snr float64) float64 {
+	switch baseGoal(goal).(type) {
+	case SmallerTheBetter, *SmallerTheBetter, NominalTheBest, *NominalTheBest, NominalTheBestI, *NominalTheBestI:
+		return math.Pow(10, -snr/20)
+	case LargerTheBetter, *LargerTheBetter:
+		return math.Pow(10, snr/20)
+	}
+	return math.NaN()
+}
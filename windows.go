@@ -0,0 +1,100 @@
+package taguchi
+
+import (
+	"fmt"
+	"time"
+)
+
+// RunWindow is a daily window in which a Scheduler may start trials, e.g. the
+// low-traffic hours of a production system.
+// Start: Offset from midnight at which the window opens.
+// End: Offset from midnight at which it closes; a window whose End is not after its
+// Start spans midnight.
+// Location: The time zone of the offsets; UTC if nil.
+type RunWindow struct {
+	Start    time.Duration
+	End      time.Duration
+	Location *time.Location
+}
+
+// nextOpen returns the earliest time at or after t inside the window.
+func (w RunWindow) nextOpen(t time.Time) time.Time {
+	loc := w.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+	t = t.In(loc)
+	// Check the window opening on the previous day too, which covers windows that span
+	// midnight.
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	for d := -1; d <= 1; d++ {
+		midnight := day.AddDate(0, 0, d)
+		open, end := midnight.Add(w.Start), midnight.Add(w.End)
+		if !end.After(open) {
+			end = end.AddDate(0, 0, 1)
+		}
+		switch {
+		case t.Before(open):
+			return open
+		case t.Before(end):
+			return t
+		}
+	}
+	return day.AddDate(0, 0, 2).Add(w.Start)
+}
+
+// SetRunWindows restricts Run to starting trials inside the given daily windows; a
+// trial started near a window's end may run past it. Without windows trials start at
+// any time.
+func (s *Scheduler) SetRunWindows(windows ...RunWindow) error {
+	for _, w := range windows {
+		if w.Start < 0 || w.Start >= 24*time.Hour || w.End < 0 || w.End > 24*time.Hour {
+			return fmt.Errorf("run window %v-%v: offsets must lie within a day", w.Start, w.End)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.windows = windows
+	return nil
+}
+
+// SetCooldown makes Run wait at least d after each trial ends before starting the next,
+// e.g. for caches and autoscalers of a shared system to return to steady state.
+func (s *Scheduler) SetCooldown(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cooldown = d
+}
+
+// waitForSlot sleeps until the cooldown since the last trial has passed and a run
+// window is open. It returns at once when no trials are pending.
+func (s *Scheduler) waitForSlot() {
+	s.mu.Lock()
+	windows, cooldown, lastEnd := s.windows, s.cooldown, s.lastEnd
+	pending := false
+	for _, e := range s.entries {
+		pending = pending || len(e.pending) > 0
+	}
+	s.mu.Unlock()
+	if !pending {
+		return
+	}
+
+	now := s.now()
+	start := now
+	if !lastEnd.IsZero() && lastEnd.Add(cooldown).After(start) {
+		start = lastEnd.Add(cooldown)
+	}
+	if len(windows) > 0 {
+		earliest := windows[0].nextOpen(start)
+		for _, w := range windows[1:] {
+			if open := w.nextOpen(start); open.Before(earliest) {
+				earliest = open
+			}
+		}
+		start = earliest
+	}
+	if wait := start.Sub(now); wait > 0 {
+		s.sleep(wait)
+	}
+}